@@ -0,0 +1,145 @@
+package app
+
+import (
+	"eagleeye/internal/core/timekeeper"
+	"eagleeye/internal/history"
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/i18n"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// activitySampleInterval is how often the idle checker is sampled for
+	// screen activity, independently of the break timer
+	activitySampleInterval = 15 * time.Second
+	// activityIdleAfter is how long without input counts as idle rather than
+	// reading or thinking
+	activityIdleAfter = 2 * time.Minute
+	// activityMaxGap drops time between samples that were held up, such as
+	// by suspend, instead of counting it
+	activityMaxGap = 3 * activitySampleInterval
+)
+
+// initializeActivity loads this week's screen activity and samples the idle
+// checker until the app exits. It runs whether or not the break timer is
+// started or paused, and must run after the tray exists
+func (rt *AppController) initializeActivity() {
+	activityPath, err := storage.ResolveActivityPath(appName)
+	if err != nil {
+		rt.logger.Warn("resolve screen activity path", "error", err)
+
+		return
+	}
+
+	var cutoff time.Time
+	if retention := historyRetention(rt.settings.HistoryRetentionDays); retention > 0 {
+		cutoff = time.Now().Add(-retention)
+	}
+
+	if err := history.CompactActivity(activityPath, cutoff); err != nil {
+		rt.logger.Warn("compact screen activity", "path", activityPath, "error", err)
+	}
+
+	now := time.Now()
+	minutes, err := history.ReadActivity(activityPath, history.Week(now).From, time.Time{})
+
+	var damaged *history.DamagedError
+	if err != nil && !errors.As(err, &damaged) {
+		rt.logger.Warn("load screen activity", "path", activityPath, "error", err)
+	}
+
+	rt.activity = history.NewActivityRecorder(activityIdleAfter, activityMaxGap)
+	rt.activity.Seed(minutes)
+	rt.activityPath = activityPath
+
+	go rt.sampleActivity()
+}
+
+// sampleActivity feeds idle samples to the activity recorder, saves finished
+// minutes and keeps the tray current
+func (rt *AppController) sampleActivity() {
+	ticker := time.NewTicker(activitySampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rt.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+
+		idle, err := rt.idleChecker.IdleDuration()
+		if errors.Is(err, timekeeper.ErrIdleUnsupported) {
+			rt.platformLog.Info("screen activity unavailable", "error", err)
+
+			return
+		}
+
+		if err != nil {
+			rt.platformLog.Debug("idle_sample_failed", "error", err)
+			rt.activity.Skip()
+		} else {
+			rt.platformLog.Debug("idle_sample", "idle", idle.String())
+			rt.activity.Observe(now, idle)
+		}
+
+		rt.saveActivity(now, false)
+		rt.trayManager.SetActivity(activityStatus(rt.activityToday(now), rt.localizer))
+	}
+}
+
+// saveActivity appends finished minutes, or every minute with all, and drops
+// minutes from before this week from memory
+func (rt *AppController) saveActivity(now time.Time, all bool) {
+	if rt.activity == nil {
+		return
+	}
+
+	if err := history.AppendActivity(rt.activityPath, rt.activity.Flush(now, all)); err != nil {
+		rt.logger.Warn("save screen activity", "error", err)
+	}
+
+	rt.activity.Trim(history.Week(now).From)
+}
+
+// activityToday is today's screen time so far
+func (rt *AppController) activityToday(now time.Time) history.ActivityDay {
+	if rt.activity == nil {
+		return history.ActivityDay{}
+	}
+
+	return history.SummarizeActivityPeriod(rt.activity.Minutes(), history.Day(now))
+}
+
+// activityWeek is this week's screen time so far
+func (rt *AppController) activityWeek(now time.Time) history.ActivityDay {
+	if rt.activity == nil {
+		return history.ActivityDay{}
+	}
+
+	return history.SummarizeActivityPeriod(rt.activity.Minutes(), history.Week(now))
+}
+
+// activityStatus is the tray note for today's active time, empty before any
+// activity was seen
+func activityStatus(today history.ActivityDay, localizer *i18n.Localizer) string {
+	if today.Active < time.Minute {
+		return ""
+	}
+
+	return localizer.T("tray.activityToday", formatActivity(today.Active))
+}
+
+// formatActivity shows a duration in whole minutes as "5h12m" or "48m"
+func formatActivity(duration time.Duration) string {
+	minutes := int(duration / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
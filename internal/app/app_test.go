@@ -1,9 +1,18 @@
 package app
 
 import (
+	"eagleeye/internal/core/timekeeper"
+	"eagleeye/internal/goals"
+	"eagleeye/internal/history"
+	"eagleeye/internal/logging"
 	"eagleeye/internal/platform"
 	"eagleeye/internal/ui/animation"
+	"eagleeye/internal/ui/i18n"
 	"eagleeye/internal/ui/preferences"
+	"io"
+	"log/slog"
+	"reflect"
+	"slices"
 	"testing"
 	"time"
 )
@@ -42,7 +51,7 @@ func TestShouldStartTimerOnLaunch(t *testing.T) {
 
 // TestAppStateExerciseCycle verifies exercise rotation and empty-cycle fallback
 func TestAppStateExerciseCycle(t *testing.T) {
-	state := newAppState(time.Minute)
+	state := newAppState()
 	cycle := []animation.ExerciseType{animation.ExerciseBlink, animation.ExerciseLookOutside}
 
 	if got := state.NextExercise(cycle); got != animation.ExerciseBlink {
@@ -64,7 +73,7 @@ func TestAppStateExerciseCycle(t *testing.T) {
 
 // TestAppStatePauseTimerReplacementAndStop verifies timer cleanup semantics
 func TestAppStatePauseTimerReplacementAndStop(t *testing.T) {
-	state := newAppState(time.Minute)
+	state := newAppState()
 	first := time.NewTimer(time.Hour)
 	second := time.NewTimer(time.Hour)
 
@@ -89,6 +98,45 @@ func TestAppStatePauseTimerReplacementAndStop(t *testing.T) {
 	}
 }
 
+// TestAppStateLockDuration verifies lock pauses are measured once
+func TestAppStateLockDuration(t *testing.T) {
+	state := newAppState()
+	lockedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, ok := state.EndLock(lockedAt); ok {
+		t.Fatalf("EndLock() without BeginLock ok = true, want false")
+	}
+
+	state.BeginLock(lockedAt)
+
+	lockedFor, ok := state.EndLock(lockedAt.Add(7 * time.Minute))
+	if !ok || lockedFor != 7*time.Minute {
+		t.Fatalf("EndLock() = %s, %t, want 7m, true", lockedFor, ok)
+	}
+
+	if _, ok := state.EndLock(lockedAt.Add(8 * time.Minute)); ok {
+		t.Fatalf("second EndLock() ok = true, want false")
+	}
+}
+
+// TestAppStateCountLongBreak verifies check-ins fall on every nth long break and never when off
+func TestAppStateCountLongBreak(t *testing.T) {
+	state := newAppState()
+
+	var due []bool
+	for count := 0; count < 4; count++ {
+		due = append(due, state.CountLongBreak(2))
+	}
+
+	if want := []bool{false, true, false, true}; !reflect.DeepEqual(due, want) {
+		t.Fatalf("CountLongBreak(2) = %v, want %v", due, want)
+	}
+
+	if state.CountLongBreak(0) {
+		t.Fatal("CountLongBreak(0) = true, want false")
+	}
+}
+
 // TestFormatRemaining verifies countdown formatting and negative clamping
 func TestFormatRemaining(t *testing.T) {
 	tests := []struct {
@@ -130,3 +178,201 @@ func TestOpacityToAlpha(t *testing.T) {
 		})
 	}
 }
+
+// TestSettingAssignments verifies both --set spellings and a missing value
+func TestSettingAssignments(t *testing.T) {
+	args := []string{platform.AutostartArg, "--set", "strict_mode=true", "--set=language=ru"}
+
+	assignments, err := SettingAssignments(args)
+	if err != nil {
+		t.Fatalf("SettingAssignments() error = %v", err)
+	}
+
+	if len(assignments) != 2 || assignments[0] != "strict_mode=true" || assignments[1] != "language=ru" {
+		t.Fatalf("SettingAssignments() = %v, want [strict_mode=true language=ru]", assignments)
+	}
+
+	if _, err := SettingAssignments([]string{"--set"}); err == nil {
+		t.Fatalf("SettingAssignments(--set) error = nil, want missing value")
+	}
+}
+
+// TestBreakRecorderCarriesScheduleAcrossPostpone verifies records keep the first due time and exercise
+func TestBreakRecorderCarriesScheduleAcrossPostpone(t *testing.T) {
+	recorder := newBreakRecorder("default")
+	dueAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	retryAt := dueAt.Add(5 * time.Minute)
+
+	first := timekeeper.BreakInfo{ID: "b1", Type: timekeeper.StateShortBreak, Planned: 20 * time.Second, StartedAt: dueAt}
+	recorder.noteExercise(animation.ExerciseBlink)
+
+	if _, ok := recorder.record(timekeeper.Event{Type: timekeeper.EventBreakStarted, Break: first}); ok {
+		t.Fatal("record(started) ok = true, want false")
+	}
+
+	first.SkipSource = timekeeper.SkipSourceTray
+	first.Postpone = 5 * time.Minute
+
+	postponed, ok := recorder.record(timekeeper.Event{Type: timekeeper.EventBreakPostponed, Break: first})
+	if !ok || postponed.Outcome != history.OutcomePostponed || postponed.Exercise != "blink" || postponed.SkipSource != "tray" {
+		t.Fatalf("postponed record = %+v, %t, want postponed blink from tray", postponed, ok)
+	}
+
+	second := timekeeper.BreakInfo{ID: "b2", Type: timekeeper.StateShortBreak, Planned: 20 * time.Second, StartedAt: retryAt}
+	recorder.record(timekeeper.Event{Type: timekeeper.EventBreakStarted, Break: second})
+
+	second.Actual = 20 * time.Second
+
+	completed, ok := recorder.record(timekeeper.Event{Type: timekeeper.EventBreakCompleted, Break: second})
+	if !ok || completed.Outcome != history.OutcomeCompleted {
+		t.Fatalf("completed record = %+v, %t, want completed", completed, ok)
+	}
+
+	if !completed.ScheduledAt.Equal(dueAt) || !completed.StartedAt.Equal(retryAt) {
+		t.Fatalf("completed scheduled/started = %v/%v, want %v/%v", completed.ScheduledAt, completed.StartedAt, dueAt, retryAt)
+	}
+
+	if completed.Exercise != "" || completed.Profile != "default" {
+		t.Fatalf("completed exercise/profile = %q/%q, want none/default", completed.Exercise, completed.Profile)
+	}
+}
+
+// TestWeeklyReportDueFromMondayMorning verifies last week's report waits for Monday 08:00
+func TestWeeklyReportDueFromMondayMorning(t *testing.T) {
+	monday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+
+	for _, tc := range []struct {
+		now  time.Time
+		want bool
+	}{
+		{monday.Add(7*time.Hour + 59*time.Minute), false},
+		{monday.Add(8 * time.Hour), true},
+		{monday.AddDate(0, 0, 2).Add(time.Hour), true},
+		{monday.AddDate(0, 0, 7).Add(time.Hour), false},
+	} {
+		if got := weeklyReportDue(tc.now); got != tc.want {
+			t.Fatalf("weeklyReportDue(%v) = %v, want %v", tc.now, got, tc.want)
+		}
+	}
+}
+
+// TestGoalLinesAndBadge verifies the goals submenu lines and the overlay badge choice
+func TestGoalLinesAndBadge(t *testing.T) {
+	localizer := i18n.New(i18n.LanguageEN)
+	now := time.Date(2026, 3, 9, 15, 0, 0, 0, time.Local)
+	status := goals.Status{
+		Progress: []goals.Progress{
+			{Goal: goals.Goal{ID: "honour_90"}, Current: 4, Best: 9, Today: true},
+			{Goal: goals.Goal{ID: "custom"}, Current: 0, Best: 1},
+		},
+		Earned: []goals.Achievement{
+			{GoalID: "honour_90", Days: 3, At: now.AddDate(0, 0, -1)},
+		},
+	}
+
+	want := []string{
+		"Honour 90% of breaks: 4-day streak (best 9) · done today",
+		"custom: 0-day streak (best 1)",
+		"Badge: Honour 90% of breaks, 3 days in a row",
+	}
+	if got := goalLines(status, localizer); !reflect.DeepEqual(got, want) {
+		t.Fatalf("goalLines() = %q, want %q", got, want)
+	}
+
+	if got := goalBadge(status, now, localizer); got != "4-day streak" {
+		t.Fatalf("goalBadge() = %q, want 4-day streak", got)
+	}
+
+	status.Earned = append(status.Earned, goals.Achievement{GoalID: "honour_90", Days: 7, At: now.Add(-time.Hour)})
+	if got := goalBadge(status, now, localizer); got != "New badge: 7 days" {
+		t.Fatalf("goalBadge(earned today) = %q, want New badge: 7 days", got)
+	}
+}
+
+// TestActivityStatusFormatsTodaysActiveTime verifies the tray note and its empty start
+func TestActivityStatusFormatsTodaysActiveTime(t *testing.T) {
+	localizer := i18n.New(i18n.LanguageEN)
+
+	if got := activityStatus(history.ActivityDay{Active: 30 * time.Second}, localizer); got != "" {
+		t.Fatalf("activityStatus(30s) = %q, want empty", got)
+	}
+
+	if got := activityStatus(history.ActivityDay{Active: 48*time.Minute + 59*time.Second}, localizer); got != "today: 48m active" {
+		t.Fatalf("activityStatus(48m59s) = %q, want today: 48m active", got)
+	}
+
+	if got := formatActivity(5*time.Hour + 2*time.Minute); got != "5h02m" {
+		t.Fatalf("formatActivity(5h2m) = %q, want 5h02m", got)
+	}
+}
+
+// TestLogLevelsFromSettings verifies every logging subsystem has a setting
+// and subsystems without one are left to the default level
+func TestLogLevelsFromSettings(t *testing.T) {
+	for _, subsystem := range []string{logging.SubsystemTimekeeper, logging.SubsystemOverlay, logging.SubsystemPlatform} {
+		if !slices.Contains(preferences.LogSubsystems, subsystem) {
+			t.Fatalf("LogSubsystems = %v, want %s", preferences.LogSubsystems, subsystem)
+		}
+	}
+
+	settings := preferences.DefaultSettings()
+	settings.LogLevel = "warn"
+	settings.OverlayLogLevel = "debug"
+
+	base, subsystems := logLevelsFromSettings(settings)
+	want := map[string]slog.Level{logging.SubsystemOverlay: slog.LevelDebug}
+
+	if base != slog.LevelWarn || !reflect.DeepEqual(subsystems, want) {
+		t.Fatalf("logLevelsFromSettings() = %v, %v, want %v, %v", base, subsystems, slog.LevelWarn, want)
+	}
+}
+
+// TestChangedLogLevelTakesOverFromEnv verifies EAGLEEYE_LOG_LEVEL sets the
+// default level until the default level is changed while running
+func TestChangedLogLevelTakesOverFromEnv(t *testing.T) {
+	t.Setenv("EAGLEEYE_LOG_LEVEL", "error")
+
+	rt := &AppController{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		logLevels: logging.NewLevels(slog.LevelInfo),
+		settings:  preferences.DefaultSettings(),
+	}
+	_, rt.envLogLevel = logging.EnvLevel()
+	rt.applyLogLevels()
+
+	if got := rt.logLevels.Level(logging.SubsystemOverlay); got != slog.LevelError {
+		t.Fatalf("Level() = %v, want %v from EAGLEEYE_LOG_LEVEL", got, slog.LevelError)
+	}
+
+	previous := rt.settings
+	rt.settings.OverlayLogLevel = "warn"
+	rt.changeLogLevels(previous)
+
+	if got := rt.logLevels.Level(logging.SubsystemTimekeeper); got != slog.LevelError {
+		t.Fatalf("Level() = %v, want %v kept after a subsystem change", got, slog.LevelError)
+	}
+
+	previous = rt.settings
+	rt.settings.LogLevel = "debug"
+	rt.changeLogLevels(previous)
+
+	if got := rt.logLevels.Level(logging.SubsystemTimekeeper); got != slog.LevelDebug {
+		t.Fatalf("Level() = %v, want %v after changing the default level", got, slog.LevelDebug)
+	}
+}
+
+// TestTakenOnScreen verifies idle and lock credits never prompt a check-in
+func TestTakenOnScreen(t *testing.T) {
+	tests := map[timekeeper.BreakTrigger]bool{
+		timekeeper.TriggerScheduled: true,
+		timekeeper.TriggerForced:    true,
+		timekeeper.TriggerIdle:      false,
+		timekeeper.TriggerLock:      false,
+	}
+
+	for trigger, want := range tests {
+		if got := takenOnScreen(timekeeper.BreakInfo{Trigger: trigger}); got != want {
+			t.Fatalf("takenOnScreen(%s) = %v, want %v", trigger, got, want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"eagleeye/internal/core/timekeeper"
+	"eagleeye/internal/history"
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/animation"
+	"time"
+)
+
+// breakOutcomes maps the lifecycle events that end a break to outcomes
+var breakOutcomes = map[timekeeper.EventType]history.Outcome{
+	timekeeper.EventBreakCompleted:   history.OutcomeCompleted,
+	timekeeper.EventBreakSkipped:     history.OutcomeSkipped,
+	timekeeper.EventBreakPostponed:   history.OutcomePostponed,
+	timekeeper.EventBreakInterrupted: history.OutcomeInterrupted,
+}
+
+// exerciseNames are the history names of the short break exercises
+var exerciseNames = map[animation.ExerciseType]string{
+	animation.ExerciseLeftRight:   "left_right",
+	animation.ExerciseUpDown:      "up_down",
+	animation.ExerciseBlink:       "blink",
+	animation.ExerciseLookOutside: "look_outside",
+}
+
+// startedBreak is what a break_started event contributes to the final record
+type startedBreak struct {
+	scheduledAt time.Time
+	exercise    string
+}
+
+// breakRecorder turns break lifecycle events into history records. It is
+// only used from the event loop goroutine
+type breakRecorder struct {
+	profile   string
+	exercise  string
+	started   map[string]startedBreak
+	postponed map[timekeeper.State]time.Time
+}
+
+func newBreakRecorder(profile string) *breakRecorder {
+	return &breakRecorder{
+		profile:   profile,
+		started:   map[string]startedBreak{},
+		postponed: map[timekeeper.State]time.Time{},
+	}
+}
+
+// noteExercise remembers the exercise shown for the break about to start
+func (recorder *breakRecorder) noteExercise(exercise animation.ExerciseType) {
+	recorder.exercise = exerciseNames[exercise]
+}
+
+// record returns the history record for a break that just ended. A started
+// break only opens a record; a postponed break passes its scheduled time on
+// to the next break of the same type
+func (recorder *breakRecorder) record(event timekeeper.Event) (history.Record, bool) {
+	info := event.Break
+
+	if event.Type == timekeeper.EventBreakStarted {
+		scheduledAt := info.StartedAt
+		if postponedAt, ok := recorder.postponed[info.Type]; ok {
+			scheduledAt = postponedAt
+			delete(recorder.postponed, info.Type)
+		}
+
+		recorder.started[info.ID] = startedBreak{scheduledAt: scheduledAt, exercise: recorder.exercise}
+		recorder.exercise = ""
+
+		return history.Record{}, false
+	}
+
+	outcome, ok := breakOutcomes[event.Type]
+	if !ok || info.ID == "" {
+		return history.Record{}, false
+	}
+
+	started, ok := recorder.started[info.ID]
+	delete(recorder.started, info.ID)
+
+	if !ok {
+		started.scheduledAt = info.StartedAt
+	}
+
+	if outcome == history.OutcomePostponed {
+		recorder.postponed[info.Type] = started.scheduledAt
+	}
+
+	return history.Record{
+		ID:          info.ID,
+		Type:        history.BreakType(info.Type),
+		Trigger:     string(info.Trigger),
+		ScheduledAt: started.scheduledAt,
+		StartedAt:   info.StartedAt,
+		Planned:     info.Planned,
+		Actual:      info.Actual,
+		Outcome:     outcome,
+		SkipSource:  string(info.SkipSource),
+		Postpone:    info.Postpone,
+		Exercise:    started.exercise,
+		Profile:     recorder.profile,
+	}, true
+}
+
+// initializeBreakHistory opens the break history store. Without it breaks
+// are still logged, just not recorded
+func (rt *AppController) initializeBreakHistory() {
+	rt.breaks = newBreakRecorder(storage.SettingsProfile())
+
+	historyPath, err := storage.ResolveHistoryPath(appName)
+	if err != nil {
+		rt.logger.Warn("resolve break history path", "error", err)
+
+		return
+	}
+
+	store, err := history.Open(historyPath, history.Options{Retention: historyRetention(rt.settings.HistoryRetentionDays)})
+	if err != nil {
+		rt.logger.Warn("open break history", "path", historyPath, "error", err)
+
+		return
+	}
+
+	rt.breakStore = store
+}
+
+// recordBreak appends a finished break to the history store
+func (rt *AppController) recordBreak(event timekeeper.Event) {
+	record, ok := rt.breaks.record(event)
+	if !ok || rt.breakStore == nil {
+		return
+	}
+
+	if err := rt.breakStore.Append(record); err != nil {
+		rt.logger.Warn("record break", "id", record.ID, "error", err)
+
+		return
+	}
+
+	rt.refreshStatistics()
+	rt.refreshGoals()
+}
+
+// historyRetention converts the retention setting; zero keeps all records
+func historyRetention(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
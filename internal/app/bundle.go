@@ -0,0 +1,50 @@
+package app
+
+import (
+	"bytes"
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"io"
+)
+
+// exportBundle writes the saved settings as a shareable bundle
+func (rt *AppController) exportBundle(writer io.Writer, includeMachine bool) error {
+	options := storage.BundleOptions{IncludeMachineSpecific: includeMachine}
+
+	if err := storage.ExportBundle(writer, rt.settings, options); err != nil {
+		rt.logger.Warn("export settings bundle", "error", err)
+
+		return err
+	}
+
+	rt.logger.Info("settings bundle exported", "include_machine", includeMachine)
+
+	return nil
+}
+
+// previewImport applies a bundle to the saved settings without persisting it
+// and describes the differences for confirmation
+func (rt *AppController) previewImport(data []byte, includeMachine bool) (preferences.ImportPreview, error) {
+	options := storage.BundleOptions{IncludeMachineSpecific: includeMachine}
+	imported, err := storage.ImportBundle(bytes.NewReader(data), rt.settings, options)
+
+	var validation *storage.SettingsValidationError
+	if err != nil && !errors.As(err, &validation) {
+		rt.logger.Warn("import settings bundle", "error", err)
+
+		return preferences.ImportPreview{}, err
+	}
+
+	preview := preferences.ImportPreview{Settings: imported}
+
+	for _, change := range storage.DiffSettings(rt.settings, imported) {
+		preview.Changes = append(preview.Changes, change.String())
+	}
+
+	if validation != nil {
+		preview.Ignored = settingsIssueLines(validation.Issues)
+	}
+
+	return preview, nil
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"eagleeye/internal/core/timekeeper"
+	"eagleeye/internal/history"
+	"eagleeye/internal/storage"
+	"errors"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// comfortTrendDays is how far back the statistics window correlates comfort
+// ratings with breaks taken
+const comfortTrendDays = 28
+
+// initializeCheckIns resolves where comfort check-ins are kept. Without a
+// path the card is never shown
+func (rt *AppController) initializeCheckIns() {
+	checkInPath, err := storage.ResolveCheckInPath(appName)
+	if err != nil {
+		rt.logger.Warn("resolve comfort check-in path", "error", err)
+
+		return
+	}
+
+	rt.checkInPath = checkInPath
+}
+
+// promptCheckIn asks how the user's eyes feel after every nth long break
+// taken on the overlay. The card is its own window, shown after the break
+// overlay, so it never keeps a strict break from closing
+func (rt *AppController) promptCheckIn(event timekeeper.Event) {
+	if event.Type != timekeeper.EventBreakCompleted || event.Break.Type != timekeeper.StateLongBreak {
+		return
+	}
+
+	if !takenOnScreen(event.Break) {
+		return
+	}
+
+	if rt.checkInPath == "" || !rt.state.CountLongBreak(rt.state.Settings().CheckInEvery) {
+		return
+	}
+
+	breakID := event.Break.ID
+	rt.logger.Info("comfort_checkin_prompt", "id", breakID)
+
+	fyne.Do(func() {
+		rt.checkInCard.Show(func(rating int, note string) {
+			go rt.saveCheckIn(breakID, rating, note)
+		})
+	})
+}
+
+// takenOnScreen reports whether a break ran on the overlay rather than being
+// credited for time nobody was at the screen
+func takenOnScreen(info timekeeper.BreakInfo) bool {
+	return info.Trigger != timekeeper.TriggerIdle && info.Trigger != timekeeper.TriggerLock
+}
+
+// saveCheckIn stores one comfort rating and refreshes the statistics window
+func (rt *AppController) saveCheckIn(breakID string, rating int, note string) {
+	checkIn := history.CheckIn{
+		Time:    time.Now(),
+		Rating:  rating,
+		Note:    note,
+		BreakID: breakID,
+	}
+
+	if err := history.AppendCheckIn(rt.checkInPath, checkIn); err != nil {
+		rt.logger.Warn("save comfort check-in", "error", err)
+
+		return
+	}
+
+	rt.logger.Info("comfort_checkin", "id", breakID, "rating", rating)
+	rt.refreshStatistics()
+}
+
+// comfortTrend correlates the last comfortTrendDays of ratings with breaks
+// taken. Damaged lines in either file are skipped
+func (rt *AppController) comfortTrend(now time.Time) history.ComfortTrend {
+	if rt.checkInPath == "" || rt.breakStore == nil {
+		return history.ComfortTrend{}
+	}
+
+	from := history.Day(now).From.AddDate(0, 0, 1-comfortTrendDays)
+
+	var damaged *history.DamagedError
+
+	records, err := rt.breakStore.Query(history.Query{From: from})
+	if err != nil && !errors.As(err, &damaged) {
+		rt.logger.Warn("load break history for comfort", "error", err)
+
+		return history.ComfortTrend{}
+	}
+
+	checkIns, err := history.ReadCheckIns(rt.checkInPath, from, time.Time{})
+	if err != nil && !errors.As(err, &damaged) {
+		rt.logger.Warn("load comfort check-ins", "error", err)
+
+		return history.ComfortTrend{}
+	}
+
+	return history.BuildComfortTrend(records, checkIns, now.Location())
+}
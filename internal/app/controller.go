@@ -3,11 +3,16 @@ package app
 import (
 	"context"
 	"eagleeye/internal/core/timekeeper"
+	"eagleeye/internal/goals"
+	"eagleeye/internal/history"
+	"eagleeye/internal/logging"
 	"eagleeye/internal/platform"
+	"eagleeye/internal/storage"
 	"eagleeye/internal/ui/animation"
 	"eagleeye/internal/ui/i18n"
 	"eagleeye/internal/ui/overlay"
 	"eagleeye/internal/ui/preferences"
+	"eagleeye/internal/ui/statistics"
 	"eagleeye/internal/ui/tray"
 	"log/slog"
 
@@ -21,17 +26,35 @@ type AppController struct {
 	ctx context.Context
 
 	logger        *slog.Logger
+	timekeeperLog *slog.Logger
+	overlayLog    *slog.Logger
+	platformLog   *slog.Logger
+	logLevels     *logging.Levels
+	envLogLevel   bool
 	fyneApp       fyne.App
 	desktopApp    desktop.App
 	platformSvc   platform.Service
 	settings      preferences.Settings
+	policy        storage.Policy
+	overrides     storage.Overrides
+	provenance    storage.Provenance
 	localizer     *i18n.Localizer
 	state         *appState
 	keeper        *timekeeper.TimeKeeper
 	overlayWindow *overlay.Window
+	reminderCard  *overlay.ReminderCard
+	checkInCard   *overlay.CheckInCard
 	trayManager   *tray.Manager
 	prefsWindow   *preferences.Window
+	statsWindow   *statistics.Window
 	trayLabel     *widget.Label
+	breakStore    *history.Store
+	breaks        *breakRecorder
+	goalTracker   *goals.Tracker
+	checkInPath   string
+	idleChecker   timekeeper.IdleChecker
+	activity      *history.ActivityRecorder
+	activityPath  string
 
 	activeIcon fyne.Resource
 	pausedIcon fyne.Resource
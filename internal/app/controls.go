@@ -8,6 +8,7 @@ import (
 	"eagleeye/internal/ui/preferences"
 	"fmt"
 	"os"
+	"reflect"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -35,6 +36,7 @@ func (rt *AppController) startServiceIfNeeded() {
 
 	if !rt.settings.BreakTimerStarted {
 		rt.settings.BreakTimerStarted = true
+		rt.state.SetSettings(rt.settings)
 
 		if err := storage.SaveSettings(appName, rt.settings); err != nil {
 			rt.logger.Warn("save timer start state", "error", err)
@@ -46,9 +48,9 @@ func (rt *AppController) startServiceIfNeeded() {
 
 	rt.desktopApp.SetSystemTrayIcon(rt.activeIcon)
 	rt.trayManager.SetPaused(false)
-	rt.trayManager.SetStatus(rt.localizer.T("tray.nextBreakIn", formatRemaining(rt.state.NextBreakRemaining())))
+	rt.trayManager.SetStatus(rt.localizer.T("tray.nextBreakIn", formatRemaining(rt.keeper.Snapshot().Remaining)))
 	rt.prefsWindow.SetTimerControlState(true)
-	rt.prefsWindow.SetServiceRunning(rt.state.NextBreakRemaining())
+	rt.prefsWindow.SetServiceRunning(rt.keeper.Snapshot().Remaining)
 }
 
 // setPauseState applies pause or resume across timer, state, tray, and prefs UI
@@ -73,7 +75,7 @@ func (rt *AppController) setPauseState(paused bool) {
 	rt.desktopApp.SetSystemTrayIcon(rt.activeIcon)
 	rt.trayManager.SetPaused(false)
 	rt.prefsWindow.SetTimerControlState(true)
-	rt.prefsWindow.SetServiceRunning(rt.state.NextBreakRemaining())
+	rt.prefsWindow.SetServiceRunning(rt.keeper.Snapshot().Remaining)
 }
 
 // toggleTimer starts the service or toggles pause from preferences
@@ -108,16 +110,23 @@ func (rt *AppController) forceNextBreak() {
 		rt.setPauseState(false)
 	}
 
-	rt.logger.Info("break_force_next", "remaining", rt.state.NextBreakRemaining().String())
+	rt.timekeeperLog.Info("break_force_next", "remaining", rt.keeper.Snapshot().Remaining.String())
 	rt.keeper.ForceNextBreak()
 }
 
-// pauseFor pauses breaks temporarily and schedules automatic resume
+// pauseFor pauses breaks temporarily and schedules automatic resume. During
+// an active break it postpones that break instead of freezing it on screen
 func (rt *AppController) pauseFor(duration time.Duration) {
 	if !rt.state.ServiceStarted() {
 		return
 	}
 
+	if isBreakState(rt.keeper.Snapshot().State) {
+		rt.keeper.PostponeBreak(duration, timekeeper.SkipSourceTray)
+
+		return
+	}
+
 	rt.setPauseState(true)
 	rt.state.SetPauseTimer(time.AfterFunc(duration, func() {
 		fyne.Do(func() {
@@ -126,6 +135,57 @@ func (rt *AppController) pauseFor(duration time.Duration) {
 	}))
 }
 
+// skipBreakFromOverlay hides the overlay right away and ends the break
+func (rt *AppController) skipBreakFromOverlay(source timekeeper.SkipSource) {
+	rt.overlayWindow.Hide()
+	rt.keeper.SkipBreak(source)
+}
+
+// handleSessionLock pauses the timer while the screen is locked or the
+// session is inactive, then credits the locked time as rest on unlock. A
+// timer the user had already paused is left alone
+func (rt *AppController) handleSessionLock(locked bool) {
+	if locked {
+		if !rt.state.ServiceStarted() || rt.state.IsPaused() {
+			rt.platformLog.Info("session_lock", "timer_paused", false)
+
+			return
+		}
+
+		rt.platformLog.Info("session_lock", "timer_paused", true)
+		rt.state.BeginLock(time.Now())
+
+		fyne.Do(func() {
+			rt.setPauseState(true)
+		})
+
+		return
+	}
+
+	lockedFor, ok := rt.state.EndLock(time.Now())
+	if !ok {
+		return
+	}
+
+	rt.platformLog.Info("session_unlock", "locked_for", lockedFor.String())
+
+	fyne.Do(func() {
+		if !rt.state.IsPaused() {
+			return
+		}
+
+		rt.setPauseState(false)
+		rt.keeper.CreditRest(lockedFor, timekeeper.TriggerLock)
+	})
+}
+
+// snoozeReminder delays one custom reminder from the tray
+func (rt *AppController) snoozeReminder(name string, duration time.Duration) {
+	if rt.keeper.SnoozeReminder(name, duration) {
+		rt.timekeeperLog.Info("reminder_snooze", "name", name, "duration", duration.String())
+	}
+}
+
 // forceLongBreak immediately enters a long break
 func (rt *AppController) forceLongBreak() {
 	rt.keeper.ForceBreak(timekeeper.StateLongBreak)
@@ -133,6 +193,32 @@ func (rt *AppController) forceLongBreak() {
 
 // savePreferences persists settings and applies runtime UI changes
 func (rt *AppController) savePreferences(updated preferences.Settings) {
+	rt.applySettings(updated, storage.ChangeFromPreferences)
+}
+
+// importPreferences persists settings from an imported bundle
+func (rt *AppController) importPreferences(updated preferences.Settings) {
+	rt.applySettings(updated, storage.ChangeFromImport)
+}
+
+// reloadPreferences applies settings.yaml edited outside the app. The file is
+// already on disk, so it is only journaled, not written back
+func (rt *AppController) reloadPreferences(updated preferences.Settings) {
+	updated.BreakTimerStarted = rt.settings.BreakTimerStarted
+
+	if reflect.DeepEqual(updated, rt.settings) {
+		return
+	}
+
+	rt.logger.Info("settings reloaded from disk")
+	rt.applySettings(updated, storage.ChangeFromFile)
+	rt.prefsWindow.UpdateSettings(rt.settings)
+}
+
+// applySettings pushes updated settings to every runtime component and
+// records the change under source. Changes from the file are journaled
+// without saving since they are already on disk
+func (rt *AppController) applySettings(updated preferences.Settings, source storage.ChangeSource) {
 	previousSettings := rt.settings
 	updated.BreakTimerStarted = rt.settings.BreakTimerStarted || updated.BreakTimerStarted
 	languageChanged := i18n.NormalizeLanguage(previousSettings.Language) != i18n.NormalizeLanguage(updated.Language)
@@ -154,15 +240,30 @@ func (rt *AppController) savePreferences(updated preferences.Settings) {
 		}
 	}
 
-	rt.settings = updated
+	rt.settings = rt.policy.Enforce(rt.overrides.Apply(updated))
 	rt.settings.Language = i18n.NormalizeLanguage(rt.settings.Language)
+	rt.state.SetSettings(rt.settings)
 
-	if err := storage.SaveSettings(appName, rt.settings); err != nil {
+	if source == storage.ChangeFromFile {
+		if err := storage.RecordSettingsChange(appName, source, previousSettings, rt.settings); err != nil {
+			rt.logger.Warn("record settings change", "error", err)
+		}
+	} else if err := storage.SaveSettingsFrom(appName, rt.settings, source); err != nil {
 		rt.logger.Warn("save settings", "error", err)
 	}
 
 	rt.keeper.UpdateConfig(rt.settings.TimeKeeperConfig())
 
+	if !sameLogLevels(previousSettings, rt.settings) {
+		rt.changeLogLevels(previousSettings)
+	}
+
+	if rt.breakStore != nil {
+		rt.breakStore.SetRetention(historyRetention(rt.settings.HistoryRetentionDays))
+	}
+
+	rt.trayManager.SetReminders(reminderNames(rt.settings.Reminders))
+
 	if languageChanged {
 		rt.localizer.SetLanguage(rt.settings.Language)
 		rt.trayLabel.SetText(rt.localizer.T("main.trayWindowMessage"))
@@ -173,6 +274,10 @@ func (rt *AppController) savePreferences(updated preferences.Settings) {
 
 		rt.overlayWindow.RefreshLocalization()
 		rt.prefsWindow.RefreshLocalization()
+		rt.statsWindow.RefreshLocalization()
+		rt.refreshFieldStates()
+
+		go rt.refreshGoals()
 	}
 
 	rt.overlayWindow.UpdateConfig(overlay.Config{
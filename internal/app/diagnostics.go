@@ -0,0 +1,120 @@
+package app
+
+import (
+	"eagleeye/internal/cli"
+	"eagleeye/internal/logging"
+	"eagleeye/internal/platform"
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// logLevelsFromSettings reads the default level and the subsystems that
+// have their own
+func logLevelsFromSettings(settings preferences.Settings) (slog.Level, map[string]slog.Level) {
+	base, _ := logging.ParseLevel(settings.LogLevel)
+	subsystems := make(map[string]slog.Level, len(preferences.LogSubsystems))
+
+	for _, subsystem := range preferences.LogSubsystems {
+		if level, ok := logging.ParseLevel(settings.SubsystemLogLevel(subsystem)); ok {
+			subsystems[subsystem] = level
+		}
+	}
+
+	return base, subsystems
+}
+
+// sameLogLevels reports whether two settings log at the same levels
+func sameLogLevels(left, right preferences.Settings) bool {
+	return left.LogLevelSummary() == right.LogLevelSummary()
+}
+
+// applyLogLevels makes the logger follow the level settings. A set
+// EAGLEEYE_LOG_LEVEL keeps the default level it names until that level is
+// changed while running
+func (rt *AppController) applyLogLevels() {
+	if rt.logLevels == nil {
+		return
+	}
+
+	base, subsystems := logLevelsFromSettings(rt.settings)
+	if level, ok := logging.EnvLevel(); ok && rt.envLogLevel {
+		base = level
+	}
+
+	rt.logLevels.Set(base, subsystems)
+}
+
+// changeLogLevels applies levels that differ from previous. A new default
+// level takes over from EAGLEEYE_LOG_LEVEL for the rest of the run
+func (rt *AppController) changeLogLevels(previous preferences.Settings) {
+	if previous.LogLevel != rt.settings.LogLevel {
+		rt.envLogLevel = false
+	}
+
+	rt.applyLogLevels()
+	rt.logLevelChange()
+}
+
+// logLevelChange records new log levels once they apply
+func (rt *AppController) logLevelChange() {
+	attrs := []any{"default", rt.settings.LogLevel}
+	for _, subsystem := range preferences.LogSubsystems {
+		attrs = append(attrs, subsystem, rt.settings.SubsystemLogLevel(subsystem))
+	}
+
+	rt.logger.Info("log_levels", attrs...)
+}
+
+// bindCommandHandler answers commands sent from the command line
+func (rt *AppController) bindCommandHandler(guard *platform.InstanceGuard) {
+	guard.HandleCommands(func(command string) (string, error) {
+		var (
+			reply string
+			err   error
+		)
+
+		fyne.DoAndWait(func() {
+			reply, err = rt.runCommand(command)
+		})
+
+		return reply, err
+	})
+}
+
+// runCommand runs one command line request. "log-level" alone reports the
+// levels; "log-level LEVEL [SUBSYSTEM]" changes and saves one of them
+func (rt *AppController) runCommand(command string) (string, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || fields[0] != cli.LogLevelCommand || len(fields) > 3 {
+		return "", fmt.Errorf("unknown command %q", command)
+	}
+
+	if len(fields) == 1 {
+		return rt.settings.LogLevelSummary(), nil
+	}
+
+	subsystem := ""
+	if len(fields) == 3 {
+		subsystem = fields[2]
+	}
+
+	updated := rt.settings
+	if err := updated.SetLogLevel(subsystem, fields[1]); err != nil {
+		return "", err
+	}
+
+	rt.applySettings(updated, storage.ChangeFromCLI)
+	rt.prefsWindow.UpdateSettings(rt.settings)
+
+	if !sameLogLevels(updated, rt.settings) {
+		return "", errors.New("log level is locked by policy or overridden for this process")
+	}
+
+	return rt.settings.LogLevelSummary(), nil
+}
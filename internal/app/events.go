@@ -1,6 +1,7 @@
 package app
 
 import (
+	"eagleeye/internal/core/model"
 	"eagleeye/internal/core/timekeeper"
 	"eagleeye/internal/ui/overlay"
 	"sync"
@@ -20,33 +21,75 @@ func (rt *AppController) consumeEvents(wg *sync.WaitGroup, events <-chan timekee
 			previousState := lastState
 			rt.logStateChange(previousState, event)
 			lastState = event.State
+
+			if previousState == timekeeper.StatePaused && isBreakState(event.State) {
+				rt.handleBreakResume(event)
+
+				continue
+			}
+
 			rt.handleStateChange(event)
 		case timekeeper.EventProgress:
+			rt.timekeeperLog.Debug("progress", "state", string(event.State), "remaining", event.Remaining.String())
 			rt.handleProgress(event)
+		case timekeeper.EventReminder:
+			rt.handleReminder(event)
+		case timekeeper.EventIdleReset:
+			rt.timekeeperLog.Info("idle_reset", "state", string(event.State))
+		default:
+			if event.IsBreakLifecycle() {
+				rt.logBreakLifecycle(event)
+				rt.recordBreak(event)
+				rt.promptCheckIn(event)
+			}
 		}
 	}
 }
 
-// logStateChange records state transitions and break lifecycle markers
+// logStateChange records state transitions
 func (rt *AppController) logStateChange(previousState timekeeper.State, event timekeeper.Event) {
-	rt.logger.Info("state_change",
+	rt.timekeeperLog.Info("state_change",
 		"from", string(previousState),
 		"to", string(event.State),
 		"remaining", event.Remaining.String(),
 		"strict", event.StrictMode,
 	)
+}
 
-	if event.State == timekeeper.StateShortBreak || event.State == timekeeper.StateLongBreak {
-		rt.logger.Info("break_start",
-			"type", string(event.State),
-			"remaining", event.Remaining.String(),
-			"strict", event.StrictMode,
-		)
+// breakLogMessages maps lifecycle events to their JSONL log message names
+var breakLogMessages = map[timekeeper.EventType]string{
+	timekeeper.EventBreakStarted:     "break_start",
+	timekeeper.EventBreakCompleted:   "break_complete",
+	timekeeper.EventBreakSkipped:     "break_skip",
+	timekeeper.EventBreakPostponed:   "break_postpone",
+	timekeeper.EventBreakInterrupted: "break_interrupt",
+}
+
+// logBreakLifecycle records one authoritative break lifecycle entry
+func (rt *AppController) logBreakLifecycle(event timekeeper.Event) {
+	info := event.Break
+	attrs := []any{
+		"id", info.ID,
+		"type", string(info.Type),
+		"trigger", string(info.Trigger),
+		"planned", info.Planned.String(),
 	}
 
-	if event.State == timekeeper.StateWork && (previousState == timekeeper.StateShortBreak || previousState == timekeeper.StateLongBreak) {
-		rt.logger.Info("break_complete", "from", string(previousState))
+	if event.Type != timekeeper.EventBreakStarted {
+		attrs = append(attrs, "actual", info.Actual.String())
+	} else {
+		attrs = append(attrs, "strict", event.StrictMode)
 	}
+
+	if info.SkipSource != "" {
+		attrs = append(attrs, "source", string(info.SkipSource))
+	}
+
+	if info.Postpone > 0 {
+		attrs = append(attrs, "postpone", info.Postpone.String())
+	}
+
+	rt.timekeeperLog.Info(breakLogMessages[event.Type], attrs...)
 }
 
 // handleStateChange dispatches state transitions to concrete UI reactions
@@ -66,15 +109,17 @@ func (rt *AppController) handleStateChange(event timekeeper.Event) {
 // handleShortBreak starts an exercise overlay for a short break
 func (rt *AppController) handleShortBreak(event timekeeper.Event) {
 	rt.trayManager.SetInBreak(true)
+	rt.hideCards()
 	exercise := rt.state.NextExercise(rt.exerciseCycle)
+	rt.breaks.noteExercise(exercise)
 
-	rt.logger.Info("overlay_show_called",
+	rt.overlayLog.Info("overlay_show_called",
 		"type", "short_break",
 		"remaining", event.Remaining.String(),
 		"strict", event.StrictMode,
 	)
 	fyne.Do(func() {
-		rt.logger.Info("overlay_show_done",
+		rt.overlayLog.Info("overlay_show_done",
 			"type", "short_break",
 			"remaining", event.Remaining.String(),
 			"strict", event.StrictMode,
@@ -90,15 +135,16 @@ func (rt *AppController) handleShortBreak(event timekeeper.Event) {
 // handleLongBreak starts the idle overlay for a long break
 func (rt *AppController) handleLongBreak(event timekeeper.Event) {
 	rt.trayManager.SetInBreak(true)
+	rt.hideCards()
 
-	rt.logger.Info("overlay_show_called",
+	rt.overlayLog.Info("overlay_show_called",
 		"type", "long_break",
 		"remaining", event.Remaining.String(),
 		"strict", event.StrictMode,
 	)
 
 	fyne.Do(func() {
-		rt.logger.Info("overlay_show_done",
+		rt.overlayLog.Info("overlay_show_done",
 			"type", "long_break",
 			"remaining", event.Remaining.String(),
 			"strict", event.StrictMode,
@@ -110,31 +156,50 @@ func (rt *AppController) handleLongBreak(event timekeeper.Event) {
 
 func (rt *AppController) handleWorkState() {
 	rt.trayManager.SetInBreak(false)
-	rt.logger.Info("overlay_hide_called", "reason", "state_work")
+	rt.overlayLog.Info("overlay_hide_called", "reason", "state_work")
 
 	fyne.Do(func() {
 		rt.overlayWindow.Hide()
-		rt.logger.Info("overlay_hide_done", "reason", "state_work")
+		rt.overlayLog.Info("overlay_hide_done", "reason", "state_work")
 	})
 
 	if rt.state.ServiceStarted() && !rt.state.IsPaused() {
-		rt.prefsWindow.SetServiceRunning(rt.state.NextBreakRemaining())
+		rt.prefsWindow.SetServiceRunning(rt.keeper.Snapshot().Remaining)
 		rt.prefsWindow.SetTimerControlState(true)
 	}
 }
 
-// handlePausedState mirrors pause state into tray and preferences UI
+// handlePausedState mirrors pause state into tray and preferences UI and
+// freezes the overlay when a break was paused
 func (rt *AppController) handlePausedState(event timekeeper.Event) {
 	rt.trayManager.SetPaused(true)
-	rt.state.SetNextBreakRemaining(event.Remaining)
 	rt.state.SetPaused(true)
 	rt.prefsWindow.SetServicePaused()
 	rt.prefsWindow.SetTimerControlState(false)
+
+	if !isBreakState(event.PausedFrom) {
+		return
+	}
+
+	rt.overlayLog.Info("overlay_pause", "type", string(event.PausedFrom), "remaining", event.Remaining.String())
+
+	fyne.Do(func() {
+		rt.overlayWindow.Pause(event.Remaining)
+	})
+}
+
+// handleBreakResume restarts the frozen break overlay in sync with the timer
+func (rt *AppController) handleBreakResume(event timekeeper.Event) {
+	rt.overlayLog.Info("overlay_resume", "type", string(event.State), "remaining", event.Remaining.String())
+
+	fyne.Do(func() {
+		rt.overlayWindow.Resume(event.Remaining, event.StrictMode)
+	})
 }
 
 // handleProgress updates the active work or break UI countdown
 func (rt *AppController) handleProgress(event timekeeper.Event) {
-	if event.State == timekeeper.StateShortBreak || event.State == timekeeper.StateLongBreak {
+	if isBreakState(event.State) {
 		rt.handleBreakProgress(event)
 	}
 
@@ -145,7 +210,7 @@ func (rt *AppController) handleProgress(event timekeeper.Event) {
 
 func (rt *AppController) handleBreakProgress(event timekeeper.Event) {
 	if event.Remaining <= 0 {
-		rt.logger.Info("overlay_hide_called", "reason", "progress_done")
+		rt.overlayLog.Info("overlay_hide_called", "reason", "progress_done")
 	}
 
 	fyne.Do(func() {
@@ -154,14 +219,13 @@ func (rt *AppController) handleBreakProgress(event timekeeper.Event) {
 		if event.Remaining <= 0 {
 			rt.trayManager.SetInBreak(false)
 			rt.overlayWindow.Hide()
-			rt.logger.Info("overlay_hide_done", "reason", "progress_done")
+			rt.overlayLog.Info("overlay_hide_done", "reason", "progress_done")
 		}
 	})
 }
 
-// handleWorkProgress updates cached countdown, tray text, and preferences status
+// handleWorkProgress updates tray text and preferences status countdowns
 func (rt *AppController) handleWorkProgress(event timekeeper.Event) {
-	rt.state.SetNextBreakRemaining(event.Remaining)
 	rt.trayManager.SetStatus(rt.localizer.T("tray.nextBreakIn", formatRemaining(event.Remaining)))
 
 	if rt.state.ServiceStarted() && !rt.state.IsPaused() {
@@ -169,3 +233,39 @@ func (rt *AppController) handleWorkProgress(event timekeeper.Event) {
 		rt.prefsWindow.SetTimerControlState(true)
 	}
 }
+
+// handleReminder presents a due custom reminder the way it is configured
+func (rt *AppController) handleReminder(event timekeeper.Event) {
+	reminder := event.Reminder
+	message := reminder.Message
+
+	if message == "" {
+		message = reminder.Name
+	}
+
+	rt.timekeeperLog.Info("reminder", "name", reminder.Name, "presentation", string(reminder.Presentation))
+
+	switch reminder.Presentation {
+	case model.PresentationNotification:
+		rt.fyneApp.SendNotification(fyne.NewNotification(reminder.Name, message))
+	case model.PresentationOverlay:
+		fyne.Do(func() {
+			rt.reminderCard.Show(reminder.Name, message)
+		})
+	default:
+		rt.trayManager.FlashReminder(reminder.Name, message)
+	}
+}
+
+// hideCards keeps reminder and check-in cards from overlapping an eye break
+func (rt *AppController) hideCards() {
+	fyne.Do(func() {
+		rt.reminderCard.Hide()
+		rt.checkInCard.Hide()
+	})
+}
+
+// isBreakState reports whether state is a short or long break
+func isBreakState(state timekeeper.State) bool {
+	return state == timekeeper.StateShortBreak || state == timekeeper.StateLongBreak
+}
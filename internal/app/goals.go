@@ -0,0 +1,126 @@
+package app
+
+import (
+	"eagleeye/internal/goals"
+	"eagleeye/internal/history"
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/i18n"
+	"errors"
+	"time"
+)
+
+// goalsRefreshInterval rolls streaks over at midnight between recorded breaks
+const goalsRefreshInterval = 10 * time.Minute
+
+// initializeGoals loads goal streaks and keeps the tray and overlay badges
+// current. It must run after the tray and overlay exist
+func (rt *AppController) initializeGoals() {
+	goalsPath, err := storage.ResolveGoalsPath(appName)
+	if err != nil {
+		rt.logger.Warn("resolve goals path", "error", err)
+
+		return
+	}
+
+	tracker, err := goals.Open(goalsPath, goals.DefaultGoals)
+	if err != nil {
+		rt.logger.Warn("load goals, rebuilding from break history", "path", goalsPath, "error", err)
+	}
+
+	rt.goalTracker = tracker
+
+	go func() {
+		rt.refreshGoals()
+
+		ticker := time.NewTicker(goalsRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-rt.ctx.Done():
+				return
+			case <-ticker.C:
+				rt.refreshGoals()
+			}
+		}
+	}()
+}
+
+// refreshGoals folds new break records into the streaks and updates the
+// goals submenu and overlay badge. Damaged history lines are skipped
+func (rt *AppController) refreshGoals() {
+	if rt.goalTracker == nil || rt.breakStore == nil {
+		return
+	}
+
+	now := time.Now()
+	records, err := rt.breakStore.Query(history.Query{From: rt.goalTracker.Since(now.Location())})
+
+	var damaged *history.DamagedError
+	if err != nil && !errors.As(err, &damaged) {
+		rt.logger.Warn("load break history for goals", "error", err)
+
+		return
+	}
+
+	status, err := rt.goalTracker.Update(records, now)
+	if err != nil {
+		rt.logger.Warn("save goals", "error", err)
+	}
+
+	rt.trayManager.SetGoals(goalLines(status, rt.localizer))
+	rt.overlayWindow.SetBadge(goalBadge(status, now, rt.localizer))
+}
+
+// goalLines lists each goal's streak and then every earned badge, newest first
+func goalLines(status goals.Status, localizer *i18n.Localizer) []string {
+	lines := make([]string, 0, len(status.Progress)+len(status.Earned))
+
+	for _, progress := range status.Progress {
+		line := localizer.T("goal.progress", goalName(progress.Goal.ID, localizer), progress.Current, progress.Best)
+		if progress.Today {
+			line = localizer.T("goal.doneToday", line)
+		}
+
+		lines = append(lines, line)
+	}
+
+	for index := len(status.Earned) - 1; index >= 0; index-- {
+		achievement := status.Earned[index]
+		lines = append(lines, localizer.T("goal.badge", goalName(achievement.GoalID, localizer), achievement.Days))
+	}
+
+	return lines
+}
+
+// goalBadge is the overlay note: a badge earned today, otherwise the longest
+// running streak of at least two days, otherwise nothing
+func goalBadge(status goals.Status, now time.Time, localizer *i18n.Localizer) string {
+	today := history.Day(now)
+
+	for index := len(status.Earned) - 1; index >= 0; index-- {
+		if achievement := status.Earned[index]; !achievement.At.Before(today.From) && achievement.At.Before(today.To) {
+			return localizer.T("overlay.newBadge", achievement.Days)
+		}
+	}
+
+	longest := 0
+	for _, progress := range status.Progress {
+		longest = max(longest, progress.Current)
+	}
+
+	if longest < 2 {
+		return ""
+	}
+
+	return localizer.T("overlay.streak", longest)
+}
+
+// goalName is the translated goal title, or its ID for goals without one
+func goalName(id string, localizer *i18n.Localizer) string {
+	if name := localizer.T("goal." + id); name != "" {
+		return name
+	}
+
+	return id
+}
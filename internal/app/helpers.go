@@ -1,14 +1,23 @@
 package app
 
 import (
+	"eagleeye/internal/core/model"
 	"eagleeye/internal/platform"
+	"eagleeye/internal/storage"
 	"eagleeye/internal/ui/animation"
 	"eagleeye/internal/ui/preferences"
 	"eagleeye/resources"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
+const setFlag = "--set"
+
+// errMissingSetValue reports a trailing --set without its key=value
+var errMissingSetValue = errors.New("--set needs key=value")
+
 func defaultExerciseSpec() animation.ExerciseSpec {
 	return animation.ExerciseSpec{
 		Instruction: resources.MustSprite("InstractionEagle.png"),
@@ -44,6 +53,49 @@ func formatRemaining(remaining time.Duration) string {
 	return fmt.Sprintf("%02d:%02d", minutes, seconds)
 }
 
+// reminderNames lists enabled reminders in tray menu order
+func reminderNames(reminders []model.ReminderConfig) []string {
+	names := make([]string, 0, len(reminders))
+
+	for _, reminder := range reminders {
+		if reminder.Enabled {
+			names = append(names, reminder.Name)
+		}
+	}
+
+	return names
+}
+
+// settingsIssueLines formats ignored settings entries for the preferences banner
+func settingsIssueLines(issues []storage.SettingsIssue) []string {
+	lines := make([]string, 0, len(issues))
+
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+
+	return lines
+}
+
+// refreshFieldStates tells preferences which settings the policy or
+// command-line overrides control
+func (rt *AppController) refreshFieldStates() {
+	states := make(map[string]preferences.FieldState)
+
+	for key, field := range rt.provenance {
+		switch {
+		case field.Source == storage.SourceLocked:
+			states[key] = preferences.FieldState{Disabled: true, Reason: rt.localizer.T("prefs.policyLocked")}
+		case field.Source == storage.SourceOverride:
+			states[key] = preferences.FieldState{Disabled: true, Reason: rt.localizer.T("prefs.overridden", field.Origin)}
+		case field.Bound != nil:
+			states[key] = preferences.FieldState{Reason: rt.localizer.T("prefs.policyBounded", field.Bound.String())}
+		}
+	}
+
+	rt.prefsWindow.SetFieldStates(states)
+}
+
 func opacityToAlpha(opacity float64) uint8 {
 	if opacity < 0 {
 		opacity = 0
@@ -67,6 +119,35 @@ func IsAutostartLaunch(args []string) bool {
 	return false
 }
 
+// SettingAssignments collects key=value pairs from --set key=value and
+// --set=key=value arguments, in command-line order
+func SettingAssignments(args []string) ([]string, error) {
+	var assignments []string
+
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+
+		if value, ok := strings.CutPrefix(arg, setFlag+"="); ok {
+			assignments = append(assignments, value)
+
+			continue
+		}
+
+		if arg != setFlag {
+			continue
+		}
+
+		if index+1 >= len(args) {
+			return assignments, errMissingSetValue
+		}
+
+		index++
+		assignments = append(assignments, args[index])
+	}
+
+	return assignments, nil
+}
+
 // ShouldStartTimerOnLaunch reports whether an autostart launch should resume
 // the previously started break timer
 func ShouldStartTimerOnLaunch(settings preferences.Settings, autostartLaunch bool) bool {
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/preferences"
+)
+
+// loadHistory returns the settings journal for the History view, newest
+// first. Entries whose snapshot can no longer be read are left out
+func (rt *AppController) loadHistory() ([]preferences.HistoryEntry, error) {
+	entries, err := storage.ReadJournal(appName)
+	if err != nil {
+		rt.logger.Warn("read settings journal", "error", err)
+
+		return nil, err
+	}
+
+	history := make([]preferences.HistoryEntry, 0, len(entries))
+
+	for index := len(entries) - 1; index >= 0; index-- {
+		entry := entries[index]
+
+		settings, err := entry.Settings()
+		if err != nil {
+			rt.logger.Warn("read settings snapshot", "time", entry.Time, "error", err)
+
+			continue
+		}
+
+		changes := make([]string, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, change.String())
+		}
+
+		history = append(history, preferences.HistoryEntry{
+			Time:     entry.Time,
+			Source:   string(entry.Source),
+			Changes:  changes,
+			Settings: settings,
+		})
+	}
+
+	return history, nil
+}
+
+// previewRestore describes what restoring target would change
+func (rt *AppController) previewRestore(target preferences.Settings) []string {
+	target.BreakTimerStarted = rt.settings.BreakTimerStarted
+
+	var changes []string
+	for _, change := range storage.DiffSettings(rt.settings, target) {
+		changes = append(changes, change.String())
+	}
+
+	return changes
+}
+
+// restoreSettings applies a snapshot or the defaults chosen in the History
+// view, keeping the timer's run state
+func (rt *AppController) restoreSettings(target preferences.Settings) {
+	target.BreakTimerStarted = rt.settings.BreakTimerStarted
+
+	rt.logger.Info("settings restored from history")
+	rt.applySettings(target, storage.ChangeFromHistory)
+}
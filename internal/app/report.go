@@ -0,0 +1,155 @@
+package app
+
+import (
+	"eagleeye/internal/history"
+	"eagleeye/internal/report"
+	"eagleeye/internal/storage"
+	"errors"
+	"math"
+	"net/url"
+	"os"
+	"time"
+
+	"fyne.io/fyne/v2"
+	fynestorage "fyne.io/fyne/v2/storage"
+)
+
+const (
+	// weeklyReportHour is when on Monday last week's report becomes due
+	weeklyReportHour = 8
+	// weeklyReportStartupDelay lets the desktop settle after login before a
+	// report opens
+	weeklyReportStartupDelay = time.Minute
+	// weeklyReportCheckInterval is how often a running app looks for a due report
+	weeklyReportCheckInterval = 15 * time.Minute
+)
+
+// initializeWeeklyReport opens last week's report once it is due, shortly
+// after start and periodically for machines that run over the weekend
+func (rt *AppController) initializeWeeklyReport() {
+	go func() {
+		timer := time.NewTimer(weeklyReportStartupDelay)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-rt.ctx.Done():
+				return
+			case <-timer.C:
+				rt.checkWeeklyReport(time.Now())
+				timer.Reset(weeklyReportCheckInterval)
+			}
+		}
+	}()
+}
+
+// weeklyReportDue reports whether last week's report may be opened at now:
+// from Monday morning until the end of the week
+func weeklyReportDue(now time.Time) bool {
+	return !now.Before(history.Week(now).From.Add(weeklyReportHour * time.Hour))
+}
+
+// checkWeeklyReport writes last week's report when the user asked for it on
+// Monday mornings and it has not been written yet. The report is announced
+// and opened only when last week had breaks to report
+func (rt *AppController) checkWeeklyReport(now time.Time) {
+	if !rt.state.Settings().WeeklyReport || rt.breakStore == nil || !weeklyReportDue(now) {
+		return
+	}
+
+	lastWeek := now.AddDate(0, 0, -7)
+
+	htmlPath, err := storage.ResolveReportPath(appName, report.Build(nil, lastWeek).FileName()+".html")
+	if err != nil {
+		rt.logger.Warn("resolve weekly report", "error", err)
+
+		return
+	}
+
+	if _, err := os.Stat(htmlPath); err == nil {
+		return
+	}
+
+	weekly, htmlPath, err := rt.writeWeeklyReport(lastWeek)
+	if err != nil {
+		rt.logger.Warn("write weekly report", "error", err)
+
+		return
+	}
+
+	decided := weekly.Week.Total - weekly.Week.Interrupted
+	if decided == 0 {
+		return
+	}
+
+	rt.fyneApp.SendNotification(fyne.NewNotification(
+		rt.localizer.T("report.readyTitle"),
+		rt.localizer.T("report.readyBody", weekly.Week.Completed, decided, int(math.Round(weekly.Week.Compliance()*100))),
+	))
+	rt.openReport(htmlPath)
+}
+
+// showWeeklyReport writes last week's report afresh and opens it
+func (rt *AppController) showWeeklyReport() {
+	go func() {
+		_, htmlPath, err := rt.writeWeeklyReport(time.Now().AddDate(0, 0, -7))
+		if err != nil {
+			rt.logger.Warn("write weekly report", "error", err)
+			rt.fyneApp.SendNotification(fyne.NewNotification(rt.localizer.T("tray.weeklyReport"), rt.localizer.T("report.failed", err)))
+
+			return
+		}
+
+		rt.openReport(htmlPath)
+	}()
+}
+
+// writeWeeklyReport saves the Markdown and HTML reports for the week
+// containing day and returns the HTML path. Damaged history lines are skipped
+func (rt *AppController) writeWeeklyReport(day time.Time) (report.Weekly, string, error) {
+	if rt.breakStore == nil {
+		return report.Weekly{}, "", errors.New("break history is unavailable")
+	}
+
+	week := history.Week(day)
+	records, err := rt.breakStore.Query(history.Query{From: week.From.AddDate(0, 0, -7), To: week.To})
+
+	var damaged *history.DamagedError
+	if err != nil && !errors.As(err, &damaged) {
+		return report.Weekly{}, "", err
+	}
+
+	weekly := report.Build(records, day)
+
+	page, err := report.HTML(weekly, rt.localizer)
+	if err != nil {
+		return weekly, "", err
+	}
+
+	if _, err := storage.WriteReport(appName, weekly.FileName()+".md", []byte(report.Markdown(weekly, rt.localizer))); err != nil {
+		return weekly, "", err
+	}
+
+	htmlPath, err := storage.WriteReport(appName, weekly.FileName()+".html", []byte(page))
+	if err != nil {
+		return weekly, "", err
+	}
+
+	return weekly, htmlPath, nil
+}
+
+// openReport shows a written report in the default browser
+func (rt *AppController) openReport(path string) {
+	link, err := url.Parse(fynestorage.NewFileURI(path).String())
+	if err != nil {
+		rt.logger.Warn("parse weekly report URI", "path", path, "error", err)
+
+		return
+	}
+
+	fyne.Do(func() {
+		if err := rt.fyneApp.OpenURL(link); err != nil {
+			rt.logger.Warn("open weekly report", "path", path, "error", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"eagleeye/internal/cli"
 	"eagleeye/internal/core/timekeeper"
 	"eagleeye/internal/logging"
 	"eagleeye/internal/platform"
@@ -27,28 +28,50 @@ import (
 
 const appName = "EagleEye"
 
-// Run starts the EagleEye desktop application and blocks until the UI exits
+// Run starts the EagleEye desktop application and blocks until the UI exits.
+// Arguments naming a subcommand such as export run it instead, without the
+// GUI or the single-instance lock
 func Run(ctx context.Context, args []string) error {
 	ctx, cancel := runContext(ctx)
 	defer cancel()
 
-	logger, closeLogger := logging.NewJSONLogger(appName)
-	defer closeLogger()
+	if handled, err := cli.Run(ctx, appName, args, os.Stdout, os.Stderr); handled {
+		return err
+	}
 
 	autostartLaunch := IsAutostartLaunch(args)
-	guard, err := acquireProcessGuard(logger, autostartLaunch)
+	guard, guardErr := platform.AcquireSingleInstance(appName)
 
-	if err != nil {
-		return err
+	// Only the instance holding the lock moves the log and the activation
+	// secret, since another running instance may be using them
+	var (
+		migrated   []string
+		migrateErr error
+	)
+
+	if guardErr == nil {
+		migrated, migrateErr = storage.MigrateLayout(appName)
 	}
 
-	if guard == nil {
-		return nil
+	logger, logLevels, closeLogger := logging.NewJSONLogger(appName)
+	defer closeLogger()
+
+	platformLog := logging.ForSubsystem(logger, logging.SubsystemPlatform)
+
+	if guardErr != nil {
+		return handleGuardError(platformLog, guardErr, autostartLaunch)
 	}
 
-	defer releaseGuard(logger, guard)
+	defer releaseGuard(platformLog, guard)
 
-	rt, err := newRuntime(ctx, logger)
+	reportLayoutMigration(logger, migrated, migrateErr)
+
+	overrides, err := loadRuntimeOverrides(logger, args)
+	if err != nil {
+		return err
+	}
+
+	rt, err := newRuntime(ctx, logger, logLevels, overrides)
 
 	if err != nil {
 		return err
@@ -60,12 +83,14 @@ func Run(ctx context.Context, args []string) error {
 	defer eventWG.Wait()
 
 	rt.bindActivationHandler(guard)
+	rt.bindCommandHandler(guard)
 	rt.quitWhenContextDone()
 	rt.showInitialUI(autostartLaunch)
 
 	rt.fyneApp.Run()
 	rt.keeper.Stop()
 	eventWG.Wait()
+	rt.saveActivity(time.Now(), true)
 
 	return nil
 }
@@ -79,22 +104,17 @@ func runContext(ctx context.Context) (context.Context, context.CancelFunc) {
 	return context.WithCancel(ctx)
 }
 
-// acquireProcessGuard enforces single-instance ownership for the process
-func acquireProcessGuard(logger *slog.Logger, autostartLaunch bool) (*platform.InstanceGuard, error) {
-	guard, err := platform.AcquireSingleInstance(appName)
+// handleGuardError ends a launch that did not get the single-instance lock.
+// A running instance is asked to show its UI and the launch ends quietly
+func handleGuardError(logger *slog.Logger, err error, autostartLaunch bool) error {
+	if errors.Is(err, platform.ErrAlreadyRunning) {
+		notifyRunningInstance(logger, autostartLaunch)
+		logger.Info("single instance already running")
 
-	if err != nil {
-		if errors.Is(err, platform.ErrAlreadyRunning) {
-			notifyRunningInstance(logger, autostartLaunch)
-			logger.Info("single instance already running")
-
-			return nil, nil
-		}
-
-		return nil, fmt.Errorf("acquire single instance: %w", err)
+		return nil
 	}
 
-	return guard, nil
+	return fmt.Errorf("acquire single instance: %w", err)
 }
 
 // notifyRunningInstance asks the existing process to show its UI
@@ -111,9 +131,12 @@ func notifyRunningInstance(logger *slog.Logger, autostartLaunch bool) {
 // startEventLoop consumes TimeKeeper events until the keeper stops
 func (rt *AppController) startEventLoop() *sync.WaitGroup {
 	var eventWG sync.WaitGroup
-	events := rt.keeper.Subscribe(5)
+	subscription := rt.keeper.Subscribe(timekeeper.SubscribeOptions{
+		Buffer:           8,
+		CoalesceProgress: true,
+	})
 	eventWG.Add(1)
-	go rt.consumeEvents(&eventWG, events)
+	go rt.consumeEvents(&eventWG, subscription.Events())
 
 	return &eventWG
 }
@@ -150,7 +173,7 @@ func (rt *AppController) showInitialUI(autostartLaunch bool) {
 }
 
 // newRuntime builds the controller and wires every UI/runtime dependency
-func newRuntime(ctx context.Context, logger *slog.Logger) (*AppController, error) {
+func newRuntime(ctx context.Context, logger *slog.Logger, logLevels *logging.Levels, overrides storage.Overrides) (*AppController, error) {
 	exePath := resolveExecutablePath(logger)
 	shell, err := newRuntimeShell()
 
@@ -158,17 +181,34 @@ func newRuntime(ctx context.Context, logger *slog.Logger) (*AppController, error
 		return nil, err
 	}
 
-	settings := loadRuntimeSettings(logger)
+	settings, provenance, loadErr := loadRuntimeSettings(logger)
 	rt := newAppController(ctx, logger, shell, platform.NewService(), settings)
+	rt.policy = loadRuntimePolicy(logger)
+	rt.overrides = overrides
+	rt.provenance = provenance
+	rt.logLevels = logLevels
+	_, rt.envLogLevel = logging.EnvLevel()
 
 	rt.normalizeSettingsLanguage()
+	rt.state.SetSettings(rt.settings)
+	rt.applyLogLevels()
 	rt.initializeTrayWindow()
 	rt.applyStartupAutostart(exePath)
+	rt.initializeBreakHistory()
+	rt.initializeCheckIns()
 	rt.initializeTimeKeeper()
 	rt.initializeOverlay()
 	rt.initializeBreakSpecs()
 	rt.initializePreferences()
+	rt.initializeStatistics()
+	rt.initializeWeeklyReport()
+	rt.refreshFieldStates()
 	rt.initializeTray()
+	rt.initializeGoals()
+	rt.initializeActivity()
+	rt.initializeSessionMonitor()
+	rt.initializeSettingsWatcher()
+	rt.reportSettingsLoad(loadErr)
 
 	return rt, nil
 }
@@ -231,17 +271,115 @@ func attachSystemTrayWindow(fyneApp fyne.App, desktopApp desktop.App) *widget.La
 	return trayLabel
 }
 
-// loadRuntimeSettings reads persisted preferences or falls back to defaults
-func loadRuntimeSettings(logger *slog.Logger) preferences.Settings {
-	settings, err := storage.LoadSettings(appName)
+// loadRuntimeSettings reads persisted preferences or falls back to defaults.
+// The returned error is non-fatal: a repaired corrupt file or ignored entries
+// that the user should be told about once the UI exists
+func loadRuntimeSettings(logger *slog.Logger) (preferences.Settings, storage.Provenance, error) {
+	settings, provenance, err := storage.LoadSettingsWithProvenance(appName)
+
+	var recovery *storage.SettingsRecovery
+	if errors.As(err, &recovery) {
+		logger.Warn("settings recovered",
+			"quarantine", recovery.QuarantinePath,
+			"restored_from", recovery.RestoredFrom,
+			"error", recovery.Cause,
+		)
+
+		// The repaired file is back in place; reload it for provenance only
+		_, provenance, _ = storage.LoadSettingsWithProvenance(appName)
+
+		return settings, provenance, err
+	}
+
+	var validation *storage.SettingsValidationError
+	if errors.As(err, &validation) {
+		logger.Warn("settings entries ignored", "error", err)
+
+		return settings, provenance, err
+	}
 
 	if err != nil {
 		logger.Warn("load settings", "error", err)
 
-		return preferences.DefaultSettings()
+		return preferences.DefaultSettings(), nil, nil
 	}
 
-	return settings
+	return settings, provenance, nil
+}
+
+// loadRuntimePolicy reads the administrator policy; problems are logged and
+// the usable part of the policy still applies
+func loadRuntimePolicy(logger *slog.Logger) storage.Policy {
+	policy, err := storage.LoadPolicy()
+
+	if err != nil {
+		logger.Warn("load policy", "path", policy.Path, "error", err)
+	}
+
+	if !policy.IsZero() {
+		logger.Info("policy applied", "path", policy.Path, "locked", policy.LockedKeys())
+	}
+
+	return policy
+}
+
+// reportLayoutMigration logs files moved from the pre-split config directory
+func reportLayoutMigration(logger *slog.Logger, migrated []string, err error) {
+	if len(migrated) > 0 {
+		logger.Info("storage layout migrated", "files", migrated)
+	}
+
+	if err != nil {
+		logger.Warn("migrate storage layout", "error", err)
+	}
+}
+
+// loadRuntimeOverrides reads EAGLEEYE_* variables and --set flags and
+// activates them for every settings load and save in this process. Invalid
+// entries are logged and skipped; a --set without a value fails the launch
+func loadRuntimeOverrides(logger *slog.Logger, args []string) (storage.Overrides, error) {
+	assignments, err := SettingAssignments(args)
+	if err != nil {
+		return storage.Overrides{}, err
+	}
+
+	overrides, err := storage.ParseOverrides(os.Environ(), assignments)
+	if err != nil {
+		logger.Warn("parse setting overrides", "error", err)
+	}
+
+	if !overrides.IsZero() {
+		logger.Info("settings overridden", "overrides", overrides.String())
+	}
+
+	storage.SetOverrides(overrides)
+
+	return overrides, nil
+}
+
+// reportSettingsLoad surfaces non-fatal load problems returned by
+// loadRuntimeSettings
+func (rt *AppController) reportSettingsLoad(err error) {
+	var validation *storage.SettingsValidationError
+	if errors.As(err, &validation) {
+		rt.prefsWindow.SetLoadIssues(settingsIssueLines(validation.Issues))
+	}
+
+	var recovery *storage.SettingsRecovery
+	if errors.As(err, &recovery) {
+		rt.reportSettingsRecovery(recovery)
+	}
+}
+
+// reportSettingsRecovery tells the user that a corrupt settings file was
+// replaced and where the broken copy was kept
+func (rt *AppController) reportSettingsRecovery(recovery *storage.SettingsRecovery) {
+	body := rt.localizer.T("settings.recoveredDefaults", recovery.QuarantinePath)
+	if recovery.RestoredFrom != "" {
+		body = rt.localizer.T("settings.recoveredBackup", recovery.QuarantinePath)
+	}
+
+	rt.fyneApp.SendNotification(fyne.NewNotification(rt.localizer.T("settings.recoveredTitle"), body))
 }
 
 // newAppController stores runtime dependencies before wiring components
@@ -255,12 +393,15 @@ func newAppController(
 	return &AppController{
 		ctx:           ctx,
 		logger:        logger,
+		timekeeperLog: logging.ForSubsystem(logger, logging.SubsystemTimekeeper),
+		overlayLog:    logging.ForSubsystem(logger, logging.SubsystemOverlay),
+		platformLog:   logging.ForSubsystem(logger, logging.SubsystemPlatform),
 		fyneApp:       shell.fyneApp,
 		desktopApp:    shell.desktopApp,
 		platformSvc:   platformSvc,
 		settings:      settings,
 		localizer:     i18n.New(settings.Language),
-		state:         newAppState(settings.ShortInterval),
+		state:         newAppState(),
 		trayLabel:     shell.trayLabel,
 		activeIcon:    resources.MustLogo("Logo_Bright_Gradient.png"),
 		pausedIcon:    resources.MustLogo("Logo_Dull_Gradient.png"),
@@ -281,19 +422,23 @@ func (rt *AppController) initializeTrayWindow() {
 // applyStartupAutostart synchronizes OS autostart with saved preferences
 func (rt *AppController) applyStartupAutostart(exePath string) {
 	if err := rt.applyAutostart(exePath, rt.settings.RunOnStartup); err != nil {
-		rt.logger.Warn("apply autostart on startup", "error", err)
+		rt.platformLog.Warn("apply autostart on startup", "error", err)
 	}
 }
 
-// initializeTimeKeeper creates the timer state machine and idle checker
+// initializeTimeKeeper creates the timer state machine and the idle checker
+// it shares with the activity tracker
 func (rt *AppController) initializeTimeKeeper() {
 	rt.keeper = timekeeper.New(rt.settings.TimeKeeperConfig(), timekeeper.Config{TickInterval: time.Second})
-	rt.keeper.SetIdleChecker(platform.NewIdleChecker())
+	rt.idleChecker = platform.NewIdleChecker()
+	rt.keeper.SetIdleChecker(rt.idleChecker)
 }
 
 // initializeOverlay builds the overlay window and action callbacks
 func (rt *AppController) initializeOverlay() {
 	rt.overlayWindow = overlay.New(rt.ctx, rt.fyneApp, rt.overlayConfig(), nil, rt.localizer)
+	rt.reminderCard = overlay.NewReminderCard(rt.fyneApp, rt.localizer)
+	rt.checkInCard = overlay.NewCheckInCard(rt.fyneApp, rt.localizer)
 	rt.attachAnimationEngine()
 	rt.bindOverlayActions()
 }
@@ -323,9 +468,14 @@ func (rt *AppController) attachAnimationEngine() {
 // bindOverlayActions connects overlay buttons back to the timer
 func (rt *AppController) bindOverlayActions() {
 	rt.overlayWindow.SetOnSkip(func() {
-		rt.logger.Info("break_skip", "state", "skip")
-		rt.overlayWindow.Hide()
-		rt.keeper.SkipBreak()
+		rt.skipBreakFromOverlay(timekeeper.SkipSourceOverlay)
+	})
+	rt.overlayWindow.SetOnClose(func() {
+		rt.skipBreakFromOverlay(timekeeper.SkipSourceCloseButton)
+	})
+	rt.overlayWindow.SetOnResume(func() {
+		rt.state.StopPauseTimer()
+		rt.setPauseState(false)
 	})
 }
 
@@ -347,17 +497,56 @@ func (rt *AppController) initializePreferences() {
 // preferencesCallbacks binds preferences actions to controller methods
 func (rt *AppController) preferencesCallbacks() preferences.Callbacks {
 	return preferences.Callbacks{
-		OnSave:        rt.savePreferences,
-		OnToggleTimer: rt.toggleTimer,
+		OnSave:           rt.savePreferences,
+		OnToggleTimer:    rt.toggleTimer,
+		OnStatistics:     rt.showStatistics,
+		OnExportBundle:   rt.exportBundle,
+		OnPreviewImport:  rt.previewImport,
+		OnImport:         rt.importPreferences,
+		OnLoadHistory:    rt.loadHistory,
+		OnPreviewRestore: rt.previewRestore,
+		OnRestore:        rt.restoreSettings,
 	}
 }
 
 // initializeTray creates tray menu state and icon
 func (rt *AppController) initializeTray() {
 	rt.trayManager = tray.New(rt.desktopApp, rt.trayCallbacks(), rt.localizer)
+	rt.trayManager.SetReminders(reminderNames(rt.settings.Reminders))
 	rt.desktopApp.SetSystemTrayIcon(rt.activeIcon)
 }
 
+// initializeSessionMonitor pauses the timer while the session is locked
+func (rt *AppController) initializeSessionMonitor() {
+	if err := platform.NewSessionMonitor().Start(rt.ctx, rt.handleSessionLock); err != nil {
+		rt.platformLog.Info("session monitor unavailable", "error", err)
+	}
+}
+
+// initializeSettingsWatcher applies settings.yaml edits made outside the app
+func (rt *AppController) initializeSettingsWatcher() {
+	// The banner is cleared before the reload is queued, so issues that
+	// onError reports for the same file are queued after it and stay visible
+	onChange := func(settings preferences.Settings) {
+		rt.prefsWindow.SetLoadIssues(nil)
+		fyne.Do(func() {
+			rt.reloadPreferences(settings)
+		})
+	}
+	onError := func(err error) {
+		rt.logger.Warn("reload settings", "error", err)
+
+		var validation *storage.SettingsValidationError
+		if errors.As(err, &validation) {
+			rt.prefsWindow.SetLoadIssues(settingsIssueLines(validation.Issues))
+		}
+	}
+
+	if err := storage.WatchSettings(rt.ctx, appName, onChange, onError); err != nil {
+		rt.logger.Info("settings watcher unavailable", "error", err)
+	}
+}
+
 // trayCallbacks binds tray menu actions to controller methods
 func (rt *AppController) trayCallbacks() tray.Callbacks {
 	return tray.Callbacks{
@@ -366,10 +555,12 @@ func (rt *AppController) trayCallbacks() tray.Callbacks {
 				rt.prefsWindow.Show()
 			})
 		},
+		OnStatistics:  rt.showStatistics,
+		OnReport:      rt.showWeeklyReport,
 		OnTogglePause: rt.togglePauseFromTray,
 		OnForceNext:   rt.forceNextBreak,
 		OnSkipBreak: func() {
-			rt.keeper.SkipBreak()
+			rt.keeper.SkipBreak(timekeeper.SkipSourceTray)
 		},
 		OnPauseFor:  rt.pauseFor,
 		OnForceLong: rt.forceLongBreak,
@@ -377,5 +568,6 @@ func (rt *AppController) trayCallbacks() tray.Callbacks {
 			rt.keeper.Stop()
 			rt.fyneApp.Quit()
 		},
+		OnSnoozeReminder: rt.snoozeReminder,
 	}
 }
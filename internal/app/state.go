@@ -2,6 +2,7 @@ package app
 
 import (
 	"eagleeye/internal/ui/animation"
+	"eagleeye/internal/ui/preferences"
 	"sync"
 	"time"
 )
@@ -10,16 +11,18 @@ import (
 type appState struct {
 	mu sync.Mutex
 
-	serviceStarted     bool
-	paused             bool
-	nextBreakRemaining time.Duration
-	pauseTimer         *time.Timer
-	exerciseIndex      int
+	serviceStarted bool
+	paused         bool
+	pauseTimer     *time.Timer
+	exerciseIndex  int
+	lockedAt       time.Time
+	longBreaks     int
+	settings       preferences.Settings
 }
 
-// newAppState creates state with the initial work countdown
-func newAppState(initialNextBreak time.Duration) *appState {
-	return &appState{nextBreakRemaining: initialNextBreak}
+// newAppState creates state for a service that has not started yet
+func newAppState() *appState {
+	return &appState{}
 }
 
 // ServiceStarted reports whether the break timer has been started
@@ -55,20 +58,28 @@ func (state *appState) SetPaused(paused bool) {
 	state.paused = paused
 }
 
-// SetNextBreakRemaining stores the latest countdown shown by UI surfaces
-func (state *appState) SetNextBreakRemaining(remaining time.Duration) {
+// BeginLock records that the timer was paused because the session locked
+func (state *appState) BeginLock(now time.Time) {
 	state.mu.Lock()
 	defer state.mu.Unlock()
 
-	state.nextBreakRemaining = remaining
+	state.lockedAt = now
 }
 
-// NextBreakRemaining returns the latest cached work countdown
-func (state *appState) NextBreakRemaining() time.Duration {
+// EndLock clears the lock pause and returns how long it lasted. It reports
+// false when the timer was not paused by a session lock
+func (state *appState) EndLock(now time.Time) (time.Duration, bool) {
 	state.mu.Lock()
 	defer state.mu.Unlock()
 
-	return state.nextBreakRemaining
+	if state.lockedAt.IsZero() {
+		return 0, false
+	}
+
+	lockedFor := now.Sub(state.lockedAt)
+	state.lockedAt = time.Time{}
+
+	return lockedFor, true
 }
 
 // NextExercise advances through the configured exercise cycle
@@ -86,6 +97,35 @@ func (state *appState) NextExercise(cycle []animation.ExerciseType) animation.Ex
 	return exercise
 }
 
+// CountLongBreak counts a completed long break and reports whether it is
+// the every-th since the app started, when a comfort check-in is due. It
+// never reports true when every is zero
+func (state *appState) CountLongBreak(every int) bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.longBreaks++
+
+	return every > 0 && state.longBreaks%every == 0
+}
+
+// Settings returns a copy of the settings last applied, for goroutines that
+// must not read the controller's own copy
+func (state *appState) Settings() preferences.Settings {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.settings
+}
+
+// SetSettings records the settings now in effect
+func (state *appState) SetSettings(settings preferences.Settings) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.settings = settings
+}
+
 // StopPauseTimer cancels and clears the active auto-resume timer
 func (state *appState) StopPauseTimer() {
 	timer := state.takePauseTimer()
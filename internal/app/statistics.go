@@ -0,0 +1,67 @@
+package app
+
+import (
+	"eagleeye/internal/history"
+	"eagleeye/internal/ui/statistics"
+	"errors"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// statisticsRefreshInterval keeps an open statistics window current across
+// midnight and week boundaries between recorded breaks
+const statisticsRefreshInterval = time.Minute
+
+// initializeStatistics creates the hidden statistics window and refreshes it
+// periodically while it is open
+func (rt *AppController) initializeStatistics() {
+	rt.statsWindow = statistics.New(rt.fyneApp, rt.localizer)
+
+	go func() {
+		ticker := time.NewTicker(statisticsRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-rt.ctx.Done():
+				return
+			case <-ticker.C:
+				rt.refreshStatistics()
+			}
+		}
+	}()
+}
+
+// showStatistics opens the statistics window with fresh figures
+func (rt *AppController) showStatistics() {
+	fyne.Do(func() {
+		rt.statsWindow.Show()
+		go rt.refreshStatistics()
+	})
+}
+
+// refreshStatistics reloads this week's breaks and the comfort trend into
+// the statistics window when it is open. Damaged history lines are skipped
+func (rt *AppController) refreshStatistics() {
+	if rt.breakStore == nil || !rt.statsWindow.Visible() {
+		return
+	}
+
+	now := time.Now()
+	records, err := rt.breakStore.Query(history.Week(now))
+
+	var damaged *history.DamagedError
+	if err != nil && !errors.As(err, &damaged) {
+		rt.logger.Warn("load break statistics", "error", err)
+
+		return
+	}
+
+	overview := history.BuildOverview(records, now)
+	overview.Comfort = rt.comfortTrend(now)
+	overview.ActivityToday = rt.activityToday(now)
+	overview.ActivityWeek = rt.activityWeek(now)
+
+	rt.statsWindow.Update(overview)
+}
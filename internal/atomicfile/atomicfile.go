@@ -0,0 +1,87 @@
+// Package atomicfile replaces files so readers never see a partial write.
+package atomicfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Write replaces path with data via a synced temp file in the same directory
+// and a rename, so readers see either the old or the new contents. A
+// symlinked path keeps its link: the file it points to is replaced
+func Write(path string, data []byte, perm os.FileMode) error {
+	path, err := resolveSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	tempFile, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+
+	tempPath := tempFile.Name()
+	committed := false
+
+	defer func() {
+		if !committed {
+			_ = tempFile.Close()
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	if err := tempFile.Chmod(perm); err != nil {
+		return fmt.Errorf("set temp file permissions: %w", err)
+	}
+
+	if _, err := tempFile.Write(data); err != nil {
+		return fmt.Errorf("write temp file: %w", err)
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		return fmt.Errorf("sync temp file: %w", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("replace %s: %w", filepath.Base(path), err)
+	}
+
+	committed = true
+	syncDir(dir)
+
+	return nil
+}
+
+// resolveSymlinks follows links in path to the file they point to. A path
+// that does not exist yet is written as given
+func resolveSymlinks(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return path, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", filepath.Base(path), err)
+	}
+
+	return resolved, nil
+}
+
+// syncDir flushes the directory entry after a rename. Some platforms cannot
+// open directories for syncing; the data itself is already on disk then
+func syncDir(dir string) {
+	handle, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+
+	_ = handle.Sync()
+	_ = handle.Close()
+}
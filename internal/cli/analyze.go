@@ -0,0 +1,347 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	anomalyOverlayNotHidden = "overlay_not_hidden"
+	anomalyBreakTooShort    = "break_too_short"
+	anomalyIdleReset        = "idle_reset"
+
+	// breakShortfallTolerance absorbs the timer tick before a completed break
+	// counts as shorter than planned
+	breakShortfallTolerance = 2 * time.Second
+)
+
+// logRecord is one decoded slog JSON line. Attributes are kept as decoded so
+// lines written by other app versions can still be read
+type logRecord struct {
+	Line    int
+	Time    time.Time
+	Message string
+	Attrs   map[string]any
+}
+
+// text returns a string attribute, or a number or bool written as text
+func (record logRecord) text(key string) string {
+	switch value := record.Attrs[key].(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// duration returns a duration attribute written as text such as "1m30s" or
+// as a number of nanoseconds
+func (record logRecord) duration(key string) (time.Duration, bool) {
+	switch value := record.Attrs[key].(type) {
+	case string:
+		duration, err := time.ParseDuration(value)
+
+		return duration, err == nil
+	case float64:
+		return time.Duration(value), true
+	default:
+		return 0, false
+	}
+}
+
+// flag returns a boolean attribute written as a bool or as text
+func (record logRecord) flag(key string) bool {
+	switch value := record.Attrs[key].(type) {
+	case bool:
+		return value
+	case string:
+		parsed, _ := strconv.ParseBool(value)
+
+		return parsed
+	default:
+		return false
+	}
+}
+
+// readLogRecords decodes every line of a JSON lines log. Lines that are not
+// JSON objects with a message, such as a line cut off by a crash, are
+// counted as skipped
+func readLogRecords(reader io.Reader) (records []logRecord, lines, skipped int, err error) {
+	buffered := bufio.NewReader(reader)
+
+	for number := 1; ; number++ {
+		line, readErr := buffered.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return records, lines, skipped, readErr
+		}
+
+		if text := bytes.TrimSpace(line); len(text) > 0 {
+			lines++
+
+			if record, ok := decodeLogRecord(number, text); ok {
+				records = append(records, record)
+			} else {
+				skipped++
+			}
+		}
+
+		if readErr != nil {
+			return records, lines, skipped, nil
+		}
+	}
+}
+
+func decodeLogRecord(number int, text []byte) (logRecord, bool) {
+	var attrs map[string]any
+	if err := json.Unmarshal(text, &attrs); err != nil {
+		return logRecord{}, false
+	}
+
+	message, _ := attrs["msg"].(string)
+	if message == "" {
+		return logRecord{}, false
+	}
+
+	record := logRecord{Line: number, Message: message, Attrs: attrs}
+
+	if value, ok := attrs["time"].(string); ok {
+		record.Time, _ = time.Parse(time.RFC3339Nano, value)
+	}
+
+	return record, true
+}
+
+// logTransition is one state change in the reconstructed timeline. From is
+// empty when the app had just started
+type logTransition struct {
+	Time      time.Time `json:"time"`
+	Line      int       `json:"line"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Remaining string    `json:"remaining,omitempty"`
+	Strict    bool      `json:"strict,omitempty"`
+}
+
+// logAnomaly is something in the log that needs a closer look
+type logAnomaly struct {
+	Time   time.Time `json:"time"`
+	Line   int       `json:"line"`
+	Kind   string    `json:"kind"`
+	Detail string    `json:"detail"`
+}
+
+// logAnalysis is what analyze reports about one log file
+type logAnalysis struct {
+	File         string             `json:"file"`
+	Lines        int                `json:"lines"`
+	Skipped      int                `json:"skipped"`
+	First        time.Time          `json:"first"`
+	Last         time.Time          `json:"last"`
+	Sessions     int                `json:"sessions"`
+	StateSeconds map[string]float64 `json:"state_seconds"`
+	Breaks       map[string]int     `json:"breaks"`
+	IdleResets   int                `json:"idle_resets"`
+	Timeline     []logTransition    `json:"timeline"`
+	Anomalies    []logAnomaly       `json:"anomalies"`
+}
+
+// breakCounters maps break lifecycle messages to the counts they add to
+var breakCounters = map[string]string{
+	"break_start":     "started",
+	"break_complete":  "completed",
+	"break_skip":      "skipped",
+	"break_postpone":  "postponed",
+	"break_interrupt": "interrupted",
+}
+
+// logAnalyzer walks log records in file order
+type logAnalyzer struct {
+	analysis logAnalysis
+	state    string
+	from     string
+	since    time.Time
+	previous logRecord
+	overlay  *logRecord
+}
+
+// analyzeLog reconstructs the state timeline from records and flags
+// anomalies. Messages it does not know are ignored
+func analyzeLog(records []logRecord) logAnalysis {
+	analyzer := &logAnalyzer{analysis: logAnalysis{
+		StateSeconds: map[string]float64{},
+		Breaks:       map[string]int{},
+		Timeline:     []logTransition{},
+		Anomalies:    []logAnomaly{},
+	}}
+
+	for _, record := range records {
+		if analyzer.analysis.First.IsZero() {
+			analyzer.analysis.First = record.Time
+		}
+
+		if !record.Time.IsZero() {
+			analyzer.analysis.Last = record.Time
+		}
+
+		analyzer.observe(record)
+
+		if !record.Time.IsZero() {
+			analyzer.previous = record
+		}
+	}
+
+	analyzer.closeState(analyzer.previous.Time)
+
+	if analyzer.overlay != nil && !analyzer.overlayExpected() {
+		analyzer.flagOverlay("never hidden before the log ends")
+	}
+
+	return analyzer.analysis
+}
+
+func (analyzer *logAnalyzer) observe(record logRecord) {
+	if counter, ok := breakCounters[record.Message]; ok {
+		analyzer.analysis.Breaks[counter]++
+	}
+
+	switch record.Message {
+	case "state_change":
+		analyzer.stateChange(record)
+	case "overlay_show_called":
+		if analyzer.overlay != nil {
+			analyzer.flagOverlay(fmt.Sprintf("never hidden before the next overlay at line %d", record.Line))
+		}
+
+		analyzer.overlay = &record
+	case "overlay_show_done":
+		analyzer.overlay = &record
+	case "overlay_hide_called", "overlay_hide_done":
+		analyzer.overlay = nil
+	case "break_complete":
+		analyzer.checkBreakLength(record)
+	case "idle_reset":
+		analyzer.analysis.IdleResets++
+		analyzer.flag(record, anomalyIdleReset, fmt.Sprintf("timers reset after inactivity in %s", orUnknown(record.text("state"))))
+	}
+}
+
+// stateChange extends the timeline. A change from no state is a new app
+// session, which ends the previous session's state at its last line
+func (analyzer *logAnalyzer) stateChange(record logRecord) {
+	from := record.text("from")
+
+	if from == "" || analyzer.analysis.Sessions == 0 {
+		if analyzer.analysis.Sessions > 0 {
+			analyzer.closeState(analyzer.previous.Time)
+
+			if analyzer.overlay != nil {
+				analyzer.flagOverlay(fmt.Sprintf("still shown when the app restarted at line %d", record.Line))
+			}
+		}
+
+		analyzer.analysis.Sessions++
+	} else {
+		analyzer.closeState(record.Time)
+	}
+
+	analyzer.state = record.text("to")
+	analyzer.from = from
+	analyzer.since = record.Time
+	analyzer.analysis.Timeline = append(analyzer.analysis.Timeline, logTransition{
+		Time:      record.Time,
+		Line:      record.Line,
+		From:      from,
+		To:        analyzer.state,
+		Remaining: record.text("remaining"),
+		Strict:    record.flag("strict"),
+	})
+}
+
+// overlayExpected reports whether the current state shows the overlay: a
+// break, or a pause entered from one, which keeps the overlay frozen
+func (analyzer *logAnalyzer) overlayExpected() bool {
+	return isBreakStateName(analyzer.state) || analyzer.state == "paused" && isBreakStateName(analyzer.from)
+}
+
+// closeState adds the time since the last transition to the current state
+func (analyzer *logAnalyzer) closeState(at time.Time) {
+	if analyzer.state == "" || analyzer.since.IsZero() || !at.After(analyzer.since) {
+		return
+	}
+
+	analyzer.analysis.StateSeconds[analyzer.state] += at.Sub(analyzer.since).Seconds()
+	analyzer.since = at
+}
+
+// checkBreakLength flags a scheduled or forced break that completed well
+// before its planned length. Idle and lock breaks are measured differently
+func (analyzer *logAnalyzer) checkBreakLength(record logRecord) {
+	if trigger := record.text("trigger"); trigger == "idle" || trigger == "lock" {
+		return
+	}
+
+	planned, plannedOK := record.duration("planned")
+	actual, actualOK := record.duration("actual")
+
+	if !plannedOK || !actualOK || actual+breakShortfallTolerance >= planned {
+		return
+	}
+
+	analyzer.flag(record, anomalyBreakTooShort, fmt.Sprintf("%s %s completed after %s of %s planned",
+		orUnknown(record.text("type")), record.text("id"), actual, planned))
+}
+
+func (analyzer *logAnalyzer) flagOverlay(detail string) {
+	overlay := *analyzer.overlay
+	analyzer.overlay = nil
+
+	analyzer.flag(overlay, anomalyOverlayNotHidden, fmt.Sprintf("%s overlay %s", orUnknown(overlay.text("type")), detail))
+}
+
+func (analyzer *logAnalyzer) flag(record logRecord, kind, detail string) {
+	analyzer.analysis.Anomalies = append(analyzer.analysis.Anomalies, logAnomaly{
+		Time:   record.Time,
+		Line:   record.Line,
+		Kind:   kind,
+		Detail: detail,
+	})
+}
+
+// sortedStates lists the states with recorded time, longest first
+func (analysis logAnalysis) sortedStates() []string {
+	states := make([]string, 0, len(analysis.StateSeconds))
+	for state := range analysis.StateSeconds {
+		states = append(states, state)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		if analysis.StateSeconds[states[i]] != analysis.StateSeconds[states[j]] {
+			return analysis.StateSeconds[states[i]] > analysis.StateSeconds[states[j]]
+		}
+
+		return states[i] < states[j]
+	})
+
+	return states
+}
+
+func isBreakStateName(state string) bool {
+	return state == "short_break" || state == "long_break"
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+
+	return value
+}
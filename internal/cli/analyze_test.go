@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleLog is two app sessions: the first leaves an overlay open and ends
+// a break early, the second resets after idling and ends with a truncated line
+var sampleLog = []string{
+	`{"time":"2026-03-02T09:00:00Z","level":"INFO","msg":"state_change","from":"","to":"work","remaining":"15m0s","strict":false}`,
+	`{"time":"2026-03-02T09:15:00Z","level":"INFO","msg":"state_change","from":"work","to":"short_break","remaining":"20s","strict":false}`,
+	`{"time":"2026-03-02T09:15:00Z","level":"INFO","msg":"break_start","id":"b1","type":"short_break","trigger":"scheduled","planned":"20s","strict":false}`,
+	`{"time":"2026-03-02T09:15:00Z","level":"INFO","msg":"overlay_show_called","type":"short_break","remaining":"20s","strict":false}`,
+	`{"time":"2026-03-02T09:15:01Z","level":"INFO","msg":"overlay_show_done","type":"short_break","remaining":"20s","strict":false}`,
+	`{"time":"2026-03-02T09:15:05Z","level":"INFO","msg":"break_complete","id":"b1","type":"short_break","trigger":"scheduled","planned":"20s","actual":"5s"}`,
+	`{"time":"2026-03-02T09:15:05Z","level":"INFO","msg":"state_change","from":"short_break","to":"work","remaining":"15m0s","strict":false}`,
+	`{"time":"2026-03-02T09:20:00Z","level":"INFO","msg":"settings_saved"}`,
+	`not json at all`,
+	`{"time":"2026-03-02T10:00:00Z","level":"INFO","msg":"state_change","from":"","to":"work","remaining":"15m0s","strict":false}`,
+	`{"time":"2026-03-02T10:10:00Z","level":"INFO","msg":"idle_reset","state":"work"}`,
+	`{"time":"2026-03-02T10:10:00Z","level":"INFO","msg":"break_complete","id":"b2","type":"long_break","trigger":"idle","planned":"5m0s","actual":"1m0s"}`,
+	`{"time":"2026-03-02T10:30:00Z","level":"INFO","msg":"state_ch`,
+}
+
+// TestAnalyzeLogRebuildsTimeline verifies sessions, state time and break counts
+func TestAnalyzeLogRebuildsTimeline(t *testing.T) {
+	records, lines, skipped, err := readLogRecords(strings.NewReader(strings.Join(sampleLog, "\n")))
+	if err != nil {
+		t.Fatalf("readLogRecords() error = %v", err)
+	}
+
+	if lines != len(sampleLog) || skipped != 2 {
+		t.Fatalf("readLogRecords() lines, skipped = %d, %d, want %d, 2", lines, skipped, len(sampleLog))
+	}
+
+	analysis := analyzeLog(records)
+
+	if analysis.Sessions != 2 || len(analysis.Timeline) != 4 {
+		t.Fatalf("sessions, timeline = %d, %d, want 2, 4", analysis.Sessions, len(analysis.Timeline))
+	}
+
+	wantSeconds := map[string]float64{
+		"work":        (15*time.Minute + 4*time.Minute + 55*time.Second + 10*time.Minute).Seconds(),
+		"short_break": 5,
+	}
+	for state, want := range wantSeconds {
+		if got := analysis.StateSeconds[state]; got != want {
+			t.Fatalf("StateSeconds[%s] = %v, want %v", state, got, want)
+		}
+	}
+
+	if analysis.Breaks["started"] != 1 || analysis.Breaks["completed"] != 2 || analysis.IdleResets != 1 {
+		t.Fatalf("breaks, idle resets = %v, %d, want 1 started, 2 completed, 1 reset", analysis.Breaks, analysis.IdleResets)
+	}
+}
+
+// TestAnalyzeLogFlagsAnomalies verifies each anomaly kind is reported once
+func TestAnalyzeLogFlagsAnomalies(t *testing.T) {
+	records, _, _, err := readLogRecords(strings.NewReader(strings.Join(sampleLog, "\n")))
+	if err != nil {
+		t.Fatalf("readLogRecords() error = %v", err)
+	}
+
+	kinds := map[string]int{}
+	for _, anomaly := range analyzeLog(records).Anomalies {
+		kinds[anomaly.Kind] = anomaly.Line
+	}
+
+	want := map[string]int{
+		anomalyBreakTooShort:    6,
+		anomalyOverlayNotHidden: 5,
+		anomalyIdleReset:        11,
+	}
+	if len(kinds) != len(want) {
+		t.Fatalf("anomalies = %v, want %v", kinds, want)
+	}
+
+	for kind, line := range want {
+		if kinds[kind] != line {
+			t.Fatalf("anomaly %s at line %d, want %d", kind, kinds[kind], line)
+		}
+	}
+}
+
+// TestAnalyzeLogAcceptsOtherVersions verifies numeric durations and missing attributes
+func TestAnalyzeLogAcceptsOtherVersions(t *testing.T) {
+	log := strings.Join([]string{
+		`{"time":"2026-03-02T09:00:00Z","msg":"state_change","to":"work"}`,
+		`{"time":"2026-03-02T09:15:00Z","msg":"overlay_show_called"}`,
+		`{"time":"2026-03-02T09:15:00Z","msg":"overlay_hide_called","reason":"state_work"}`,
+		`{"time":"2026-03-02T09:15:20Z","msg":"break_complete","planned":20000000000,"actual":19000000000}`,
+		`{"msg":"state_change","from":"work","to":"paused","strict":"true"}`,
+	}, "\n")
+
+	records, _, skipped, err := readLogRecords(strings.NewReader(log))
+	if err != nil || skipped != 0 {
+		t.Fatalf("readLogRecords() skipped, error = %d, %v, want 0, nil", skipped, err)
+	}
+
+	analysis := analyzeLog(records)
+	if len(analysis.Anomalies) != 0 {
+		t.Fatalf("Anomalies = %+v, want none", analysis.Anomalies)
+	}
+
+	if len(analysis.Timeline) != 2 || !analysis.Timeline[1].Strict {
+		t.Fatalf("Timeline = %+v, want a strict change to paused", analysis.Timeline)
+	}
+}
+
+// TestAnalyzeLogAcceptsOverlayPausedDuringBreak verifies a log that ends
+// while a break is paused, with its overlay frozen on screen, is not flagged
+func TestAnalyzeLogAcceptsOverlayPausedDuringBreak(t *testing.T) {
+	log := strings.Join([]string{
+		`{"time":"2026-03-02T09:00:00Z","msg":"state_change","from":"","to":"work"}`,
+		`{"time":"2026-03-02T09:15:00Z","msg":"state_change","from":"work","to":"long_break"}`,
+		`{"time":"2026-03-02T09:15:00Z","msg":"overlay_show_called","type":"long_break"}`,
+		`{"time":"2026-03-02T09:15:01Z","msg":"overlay_show_done","type":"long_break"}`,
+		`{"time":"2026-03-02T09:16:00Z","msg":"state_change","from":"long_break","to":"paused"}`,
+	}, "\n")
+
+	records, _, _, err := readLogRecords(strings.NewReader(log))
+	if err != nil {
+		t.Fatalf("readLogRecords() error = %v", err)
+	}
+
+	if anomalies := analyzeLog(records).Anomalies; len(anomalies) != 0 {
+		t.Fatalf("Anomalies = %+v, want none", anomalies)
+	}
+
+	paused := strings.Replace(log, `"from":"long_break","to":"paused"`, `"from":"work","to":"paused"`, 1)
+
+	records, _, _, err = readLogRecords(strings.NewReader(paused))
+	if err != nil {
+		t.Fatalf("readLogRecords() error = %v", err)
+	}
+
+	if anomalies := analyzeLog(records).Anomalies; len(anomalies) != 1 || anomalies[0].Kind != anomalyOverlayNotHidden {
+		t.Fatalf("Anomalies = %+v, want the overlay flagged when paused from work", anomalies)
+	}
+}
+
+// TestRunLogsAnalyzeWritesJSON verifies the subcommand reads a given file
+func TestRunLogsAnalyzeWritesJSON(t *testing.T) {
+	path := writeHistory(t, sampleLog...)
+
+	var stdout bytes.Buffer
+
+	handled, err := Run(context.Background(), "EagleEye", []string{"logs", "analyze", "--format", "json", path}, &stdout, &bytes.Buffer{})
+	if !handled || err != nil {
+		t.Fatalf("Run() = %v, %v, want true, nil", handled, err)
+	}
+
+	var analysis logAnalysis
+	if err := json.Unmarshal(stdout.Bytes(), &analysis); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if analysis.File != path || analysis.Skipped != 2 || len(analysis.Anomalies) != 3 {
+		t.Fatalf("analysis = %+v, want %s with 2 skipped lines and 3 anomalies", analysis, path)
+	}
+}
+
+// TestRunLogsRejectsUnknownCommand verifies only analyze is accepted
+func TestRunLogsRejectsUnknownCommand(t *testing.T) {
+	for _, args := range [][]string{{"logs"}, {"logs", "tail"}} {
+		if _, err := Run(context.Background(), "EagleEye", args, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Fatalf("Run(%q) error = nil, want unknown command", args)
+		}
+	}
+}
+
+// TestWriteAnalysisListsAnomalies verifies the text summary
+func TestWriteAnalysisListsAnomalies(t *testing.T) {
+	records, _, _, err := readLogRecords(strings.NewReader(strings.Join(sampleLog, "\n")))
+	if err != nil {
+		t.Fatalf("readLogRecords() error = %v", err)
+	}
+
+	var output bytes.Buffer
+	if err := writeAnalysis(&output, analyzeLog(records), true, time.UTC); err != nil {
+		t.Fatalf("writeAnalysis() error = %v", err)
+	}
+
+	for _, want := range []string{"Sessions:    2", "Anomalies (3):", "(start) → work", "short_break 5s"} {
+		if !strings.Contains(output.String(), want) {
+			t.Fatalf("writeAnalysis() = %q, want %q", output.String(), want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package cli implements EagleEye's command-line subcommands.
+//
+// Subcommands run before the GUI starts and never take the single-instance
+// lock, so they work alongside a running EagleEye. They read local files
+// through storage and history, or the JSON log, and write their results to
+// stdout or a file. "logs level" is the exception: it asks a running
+// EagleEye to change its log levels over the single-instance socket.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// command runs one subcommand with the arguments that follow its name
+type command func(ctx context.Context, appName string, args []string, stdout, stderr io.Writer) error
+
+var commands = map[string]command{
+	"export": runExport,
+	"logs":   runLogs,
+}
+
+// Run executes the subcommand named by args[0]. It reports false, without
+// doing anything, when args do not start with a known subcommand so the
+// caller can start the GUI instead
+func Run(ctx context.Context, appName string, args []string, stdout, stderr io.Writer) (bool, error) {
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	run, ok := commands[args[0]]
+	if !ok {
+		return false, nil
+	}
+
+	if err := run(ctx, appName, args[1:], stdout, stderr); err != nil {
+		return true, fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"context"
+	"eagleeye/internal/history"
+	"eagleeye/internal/storage"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+// exportOptions selects which breaks to export and where. From and To are
+// local midnights; To is exclusive so the --to day itself is included
+type exportOptions struct {
+	From        time.Time
+	To          time.Time
+	Format      string
+	Output      string
+	HistoryPath string
+	Location    *time.Location
+}
+
+// breakRow is one exported break with durations in seconds
+type breakRow struct {
+	ID              string  `json:"id"`
+	Type            string  `json:"type"`
+	Trigger         string  `json:"trigger"`
+	Profile         string  `json:"profile"`
+	ScheduledAt     string  `json:"scheduled_at"`
+	StartedAt       string  `json:"started_at"`
+	PlannedSeconds  float64 `json:"planned_seconds"`
+	ActualSeconds   float64 `json:"actual_seconds"`
+	Outcome         string  `json:"outcome"`
+	SkipSource      string  `json:"skip_source"`
+	PostponeSeconds float64 `json:"postpone_seconds"`
+	Exercise        string  `json:"exercise"`
+}
+
+// dayRow is one exported daily aggregate with durations in seconds
+type dayRow struct {
+	Date           string  `json:"date"`
+	Total          int     `json:"total"`
+	Completed      int     `json:"completed"`
+	Skipped        int     `json:"skipped"`
+	Postponed      int     `json:"postponed"`
+	Interrupted    int     `json:"interrupted"`
+	Compliance     float64 `json:"compliance"`
+	PlannedSeconds float64 `json:"planned_seconds"`
+	ActualSeconds  float64 `json:"actual_seconds"`
+}
+
+// exportDocument is the JSON export
+type exportDocument struct {
+	Breaks []breakRow `json:"breaks"`
+	Days   []dayRow   `json:"days"`
+}
+
+var (
+	breakColumns = []string{
+		"id", "type", "trigger", "profile", "scheduled_at", "started_at", "planned_seconds",
+		"actual_seconds", "outcome", "skip_source", "postpone_seconds", "exercise",
+	}
+	dayColumns = []string{
+		"date", "total", "completed", "skipped", "postponed", "interrupted", "compliance",
+		"planned_seconds", "actual_seconds",
+	}
+)
+
+// runExport writes recorded breaks and their daily aggregates as CSV or JSON
+func runExport(_ context.Context, appName string, args []string, stdout, stderr io.Writer) error {
+	options, err := parseExportArgs(args, stderr, time.Local)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if options.HistoryPath == "" {
+		options.HistoryPath, err = storage.ResolveHistoryPath(appName)
+		if err != nil {
+			return fmt.Errorf("resolve break history: %w", err)
+		}
+	}
+
+	return exportBreaks(options, stdout, stderr)
+}
+
+// parseExportArgs reads the export flags, interpreting dates in location
+func parseExportArgs(args []string, stderr io.Writer, location *time.Location) (exportOptions, error) {
+	options := exportOptions{Location: location}
+
+	var from, to string
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&from, "from", "", "first day to export, YYYY-MM-DD (default: oldest record)")
+	flags.StringVar(&to, "to", "", "last day to export, YYYY-MM-DD, inclusive (default: newest record)")
+	flags.StringVar(&options.Format, "format", formatCSV, "output format: csv or json")
+	flags.StringVar(&options.Output, "output", "", "write to this file instead of stdout")
+	flags.StringVar(&options.HistoryPath, "history", "", "read this break history file instead of the local one")
+
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if flags.NArg() > 0 {
+		return options, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	if options.Format != formatCSV && options.Format != formatJSON {
+		return options, fmt.Errorf("unknown format %q, want %s or %s", options.Format, formatCSV, formatJSON)
+	}
+
+	var err error
+
+	if options.From, err = parseDate("from", from, location); err != nil {
+		return options, err
+	}
+
+	if options.To, err = parseDate("to", to, location); err != nil {
+		return options, err
+	}
+
+	if !options.To.IsZero() {
+		options.To = options.To.AddDate(0, 0, 1)
+	}
+
+	if !options.From.IsZero() && !options.To.IsZero() && !options.From.Before(options.To) {
+		return options, fmt.Errorf("--from %s is after --to %s", from, to)
+	}
+
+	return options, nil
+}
+
+// parseDate reads an optional YYYY-MM-DD flag value as midnight in location
+func parseDate(name, value string, location *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	day, err := time.ParseInLocation(dateLayout, value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s %q, want YYYY-MM-DD", name, value)
+	}
+
+	return day, nil
+}
+
+// exportBreaks reads the selected breaks and writes them out. Damaged
+// history lines are skipped with a warning on stderr; a missing history
+// exports no breaks
+func exportBreaks(options exportOptions, stdout, stderr io.Writer) error {
+	records, err := history.Read(options.HistoryPath, history.Query{From: options.From, To: options.To})
+
+	var damaged *history.DamagedError
+	if errors.As(err, &damaged) {
+		fmt.Fprintf(stderr, "warning: %v\n", damaged)
+	} else if err != nil {
+		return err
+	}
+
+	if options.Output == "" || options.Output == "-" {
+		return writeExport(stdout, options.Format, records, options.Location)
+	}
+
+	file, err := os.OpenFile(options.Output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create export file: %w", err)
+	}
+
+	if err := writeExport(file, options.Format, records, options.Location); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close export file: %w", err)
+	}
+
+	return nil
+}
+
+// writeExport writes records and their daily aggregates in format. CSV holds
+// the breaks table, an empty line and the days table, each with a header
+func writeExport(output io.Writer, format string, records []history.Record, location *time.Location) error {
+	breaks := make([]breakRow, 0, len(records))
+	for _, record := range records {
+		breaks = append(breaks, newBreakRow(record, location))
+	}
+
+	summaries := history.SummarizeByDay(records, location)
+	days := make([]dayRow, 0, len(summaries))
+
+	for _, summary := range summaries {
+		days = append(days, newDayRow(summary))
+	}
+
+	if format == formatJSON {
+		encoder := json.NewEncoder(output)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(exportDocument{Breaks: breaks, Days: days}); err != nil {
+			return fmt.Errorf("write export: %w", err)
+		}
+
+		return nil
+	}
+
+	return writeCSV(output, breaks, days)
+}
+
+func writeCSV(output io.Writer, breaks []breakRow, days []dayRow) error {
+	writer := csv.NewWriter(output)
+	rows := [][]string{breakColumns}
+
+	for _, row := range breaks {
+		rows = append(rows, []string{
+			row.ID, row.Type, row.Trigger, row.Profile, row.ScheduledAt, row.StartedAt,
+			formatSeconds(row.PlannedSeconds), formatSeconds(row.ActualSeconds), row.Outcome,
+			row.SkipSource, formatSeconds(row.PostponeSeconds), row.Exercise,
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+
+	// encoding/csv cannot write an empty record, so the separator goes around it
+	if _, err := io.WriteString(output, "\n"); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+
+	rows = [][]string{dayColumns}
+
+	for _, row := range days {
+		rows = append(rows, []string{
+			row.Date, strconv.Itoa(row.Total), strconv.Itoa(row.Completed), strconv.Itoa(row.Skipped),
+			strconv.Itoa(row.Postponed), strconv.Itoa(row.Interrupted), strconv.FormatFloat(row.Compliance, 'f', 3, 64),
+			formatSeconds(row.PlannedSeconds), formatSeconds(row.ActualSeconds),
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+
+	return nil
+}
+
+func newBreakRow(record history.Record, location *time.Location) breakRow {
+	return breakRow{
+		ID:              record.ID,
+		Type:            string(record.Type),
+		Trigger:         record.Trigger,
+		Profile:         record.Profile,
+		ScheduledAt:     formatTime(record.ScheduledAt, location),
+		StartedAt:       formatTime(record.StartedAt, location),
+		PlannedSeconds:  record.Planned.Seconds(),
+		ActualSeconds:   record.Actual.Seconds(),
+		Outcome:         string(record.Outcome),
+		SkipSource:      record.SkipSource,
+		PostponeSeconds: record.Postpone.Seconds(),
+		Exercise:        record.Exercise,
+	}
+}
+
+func newDayRow(summary history.DaySummary) dayRow {
+	return dayRow{
+		Date:           summary.Day.Format(dateLayout),
+		Total:          summary.Total,
+		Completed:      summary.Completed,
+		Skipped:        summary.Skipped,
+		Postponed:      summary.Postponed,
+		Interrupted:    summary.Interrupted,
+		Compliance:     summary.Compliance(),
+		PlannedSeconds: summary.Planned.Seconds(),
+		ActualSeconds:  summary.Actual.Seconds(),
+	}
+}
+
+func formatTime(moment time.Time, location *time.Location) string {
+	if moment.IsZero() {
+		return ""
+	}
+
+	return moment.In(location).Format(time.RFC3339)
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', -1, 64)
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"eagleeye/internal/history"
+	"eagleeye/internal/history/historytest"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeHistory(t *testing.T, lines ...string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return path
+}
+
+func historyLine(t *testing.T, id string, startedAt time.Time, outcome history.Outcome) string {
+	t.Helper()
+
+	data, err := json.Marshal(historytest.Record(id, history.BreakShort, startedAt, outcome))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	return string(data)
+}
+
+// TestRunIgnoresUnknownCommands verifies GUI arguments are left to the caller
+func TestRunIgnoresUnknownCommands(t *testing.T) {
+	for _, args := range [][]string{nil, {"--autostart"}, {"--set", "language=en"}} {
+		handled, err := Run(context.Background(), "EagleEye", args, &bytes.Buffer{}, &bytes.Buffer{})
+		if handled || err != nil {
+			t.Fatalf("Run(%q) = %v, %v, want false, nil", args, handled, err)
+		}
+	}
+}
+
+// TestParseExportArgsMakesToInclusive verifies dates become a half-open local range
+func TestParseExportArgsMakesToInclusive(t *testing.T) {
+	options, err := parseExportArgs([]string{"--from", "2026-01-01", "--to", "2026-01-31", "--format", "json"}, &bytes.Buffer{}, time.UTC)
+	if err != nil {
+		t.Fatalf("parseExportArgs() error = %v", err)
+	}
+
+	if want := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC); !options.From.Equal(want) {
+		t.Fatalf("From = %v, want %v", options.From, want)
+	}
+
+	if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC); !options.To.Equal(want) {
+		t.Fatalf("To = %v, want %v", options.To, want)
+	}
+
+	for _, args := range [][]string{
+		{"--format", "xml"},
+		{"--from", "01/02/2026"},
+		{"--from", "2026-02-01", "--to", "2026-01-31"},
+		{"extra"},
+	} {
+		if _, err := parseExportArgs(args, &bytes.Buffer{}, time.UTC); err == nil {
+			t.Fatalf("parseExportArgs(%q) error = nil, want error", args)
+		}
+	}
+}
+
+// TestExportCSVSkipsDamagedLines verifies a partially corrupted history still exports
+func TestExportCSVSkipsDamagedLines(t *testing.T) {
+	day := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	path := writeHistory(t,
+		historyLine(t, "a", day, history.OutcomeCompleted),
+		`{"id":"broken",`,
+		historyLine(t, "b", day.Add(time.Hour), history.OutcomeSkipped),
+		historyLine(t, "outside", day.AddDate(0, 1, 0), history.OutcomeCompleted),
+	)
+
+	var stdout, stderr bytes.Buffer
+
+	options := exportOptions{
+		From:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		Format:      formatCSV,
+		HistoryPath: path,
+		Location:    time.UTC,
+	}
+	if err := exportBreaks(options, &stdout, &stderr); err != nil {
+		t.Fatalf("exportBreaks() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"id,type,trigger,profile,scheduled_at,started_at,planned_seconds,actual_seconds,outcome,skip_source,postpone_seconds,exercise",
+		"a,short_break,scheduled,,2026-01-05T09:00:00Z,2026-01-05T09:00:00Z,20,20,completed,,0,",
+		"b,short_break,scheduled,,2026-01-05T10:00:00Z,2026-01-05T10:00:00Z,20,20,skipped,,0,",
+		"",
+		"date,total,completed,skipped,postponed,interrupted,compliance,planned_seconds,actual_seconds",
+		"2026-01-05,2,1,1,0,0,0.500,40,40",
+		"",
+	}, "\n")
+	if stdout.String() != want {
+		t.Fatalf("CSV export =\n%s\nwant\n%s", stdout.String(), want)
+	}
+
+	if !strings.Contains(stderr.String(), "lines skipped: 2") {
+		t.Fatalf("stderr = %q, want a damaged line warning", stderr.String())
+	}
+}
+
+// TestExportSkipsOverlongLines verifies a history line beyond the size limit
+// is warned about while the readable breaks still export
+func TestExportSkipsOverlongLines(t *testing.T) {
+	day := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	path := writeHistory(t,
+		historyLine(t, "a", day, history.OutcomeCompleted),
+		strings.Repeat("\x00", 128*1024),
+		historyLine(t, "b", day.Add(time.Hour), history.OutcomeSkipped),
+	)
+
+	var stdout, stderr bytes.Buffer
+
+	options := exportOptions{Format: formatJSON, HistoryPath: path, Location: time.UTC}
+	if err := exportBreaks(options, &stdout, &stderr); err != nil {
+		t.Fatalf("exportBreaks() error = %v", err)
+	}
+
+	var document exportDocument
+	if err := json.Unmarshal(stdout.Bytes(), &document); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(document.Breaks) != 2 {
+		t.Fatalf("exported breaks = %d, want 2", len(document.Breaks))
+	}
+
+	if !strings.Contains(stderr.String(), "lines skipped: 2") {
+		t.Fatalf("stderr = %q, want a damaged line warning", stderr.String())
+	}
+}
+
+// TestExportJSONToFile verifies a missing history exports empty lists to a file
+func TestExportJSONToFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "export.json")
+	options := exportOptions{
+		Format:      formatJSON,
+		Output:      output,
+		HistoryPath: filepath.Join(t.TempDir(), "missing.jsonl"),
+		Location:    time.UTC,
+	}
+
+	if err := exportBreaks(options, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("exportBreaks() error = %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var document exportDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if document.Breaks == nil || document.Days == nil || len(document.Breaks)+len(document.Days) != 0 {
+		t.Fatalf("export = %s, want empty breaks and days lists", data)
+	}
+}
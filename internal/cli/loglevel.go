@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"eagleeye/internal/platform"
+	"eagleeye/internal/storage"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LogLevelCommand is the command a running instance answers for logs level:
+// alone it reports the levels, "log-level LEVEL [SUBSYSTEM]" changes one
+const LogLevelCommand = "log-level"
+
+// runLogLevel shows or changes the log levels. A running EagleEye applies
+// and saves the change itself; otherwise settings.yaml is updated and the
+// levels apply on the next start
+func runLogLevel(appName string, args []string, stdout, stderr io.Writer) error {
+	subsystem, level, err := parseLogLevelArgs(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	reply, err := platform.SendCommand(appName, logLevelCommand(subsystem, level))
+	if err == nil {
+		_, err = fmt.Fprintln(stdout, strings.TrimRight(reply, "\n"))
+
+		return err
+	}
+
+	if !errors.Is(err, platform.ErrNotRunning) {
+		return err
+	}
+
+	return saveLogLevel(appName, subsystem, level, stdout, stderr)
+}
+
+// parseLogLevelArgs reads the --subsystem flag and the optional level
+func parseLogLevelArgs(args []string, stderr io.Writer) (string, string, error) {
+	var subsystem string
+
+	flags := flag.NewFlagSet("logs level", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&subsystem, "subsystem", "", "subsystem to change: "+strings.Join(preferences.LogSubsystems, ", "))
+
+	if err := flags.Parse(args); err != nil {
+		return "", "", err
+	}
+
+	if flags.NArg() > 1 {
+		return "", "", fmt.Errorf("unexpected argument %q", flags.Arg(1))
+	}
+
+	level := flags.Arg(0)
+	if level == "" {
+		return subsystem, "", nil
+	}
+
+	// Checked here so a typo is reported the same way whether or not
+	// EagleEye is running
+	var probe preferences.Settings
+	if err := probe.SetLogLevel(subsystem, level); err != nil {
+		return "", "", err
+	}
+
+	return subsystem, level, nil
+}
+
+// logLevelCommand builds the LogLevelCommand for a change, or a query when
+// level is empty
+func logLevelCommand(subsystem, level string) string {
+	if level == "" {
+		return LogLevelCommand
+	}
+
+	return strings.TrimSpace(strings.Join([]string{LogLevelCommand, level, subsystem}, " "))
+}
+
+// saveLogLevel changes the level in settings.yaml while EagleEye is not
+// running
+func saveLogLevel(appName, subsystem, level string, stdout, stderr io.Writer) error {
+	settings, err := storage.LoadSettings(appName)
+
+	var (
+		recovery   *storage.SettingsRecovery
+		validation *storage.SettingsValidationError
+	)
+
+	if errors.As(err, &recovery) || errors.As(err, &validation) {
+		fmt.Fprintf(stderr, "warning: %v\n", err)
+	} else if err != nil {
+		return fmt.Errorf("load settings: %w", err)
+	}
+
+	if level != "" {
+		if err := settings.SetLogLevel(subsystem, level); err != nil {
+			return err
+		}
+
+		if err := storage.SaveSettingsFrom(appName, settings, storage.ChangeFromCLI); err != nil {
+			return fmt.Errorf("save settings: %w", err)
+		}
+
+		fmt.Fprintln(stderr, "EagleEye is not running; the levels apply when it starts")
+	}
+
+	_, err = fmt.Fprint(stdout, settings.LogLevelSummary())
+
+	return err
+}
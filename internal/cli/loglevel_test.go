@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestParseLogLevelArgs verifies levels are checked before any instance is contacted
+func TestParseLogLevelArgs(t *testing.T) {
+	subsystem, level, err := parseLogLevelArgs([]string{"--subsystem", "overlay", "debug"}, &bytes.Buffer{})
+	if err != nil || subsystem != "overlay" || level != "debug" {
+		t.Fatalf("parseLogLevelArgs() = %q, %q, %v, want overlay, debug, nil", subsystem, level, err)
+	}
+
+	if got, want := logLevelCommand(subsystem, level), "log-level debug overlay"; got != want {
+		t.Fatalf("logLevelCommand() = %q, want %q", got, want)
+	}
+
+	if got, want := logLevelCommand("", ""), LogLevelCommand; got != want {
+		t.Fatalf("logLevelCommand() = %q, want %q", got, want)
+	}
+
+	for _, args := range [][]string{{"verbose"}, {"--subsystem", "network", "debug"}, {"debug", "info"}} {
+		if _, _, err := parseLogLevelArgs(args, &bytes.Buffer{}); err == nil {
+			t.Fatalf("parseLogLevelArgs(%q) error = nil, want an error", args)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"compress/gzip"
+	"context"
+	"eagleeye/internal/storage"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	formatText = "text"
+	timeLayout = "2006-01-02 15:04:05"
+)
+
+// logsOptions selects the log file to analyze and how to report on it
+type logsOptions struct {
+	Format   string
+	Timeline bool
+	LogPath  string
+	Location *time.Location
+}
+
+// runLogs dispatches the logs subcommands
+func runLogs(_ context.Context, appName string, args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "level" {
+		return runLogLevel(appName, args[1:], stdout, stderr)
+	}
+
+	if len(args) == 0 || args[0] != "analyze" {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		return fmt.Errorf("unknown logs command %q, want analyze or level", name)
+	}
+
+	options, err := parseAnalyzeArgs(args[1:], stderr, time.Local)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if options.LogPath == "" {
+		options.LogPath, err = storage.ResolveLogPath(appName)
+		if err != nil {
+			return fmt.Errorf("resolve log path: %w", err)
+		}
+	}
+
+	return analyzeLogFile(options, stdout)
+}
+
+// parseAnalyzeArgs reads the logs analyze flags and the optional log file
+func parseAnalyzeArgs(args []string, stderr io.Writer, location *time.Location) (logsOptions, error) {
+	options := logsOptions{Location: location}
+
+	flags := flag.NewFlagSet("logs analyze", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&options.Format, "format", formatText, "output format: text or json")
+	flags.BoolVar(&options.Timeline, "timeline", false, "list every state change in text output")
+
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if flags.NArg() > 1 {
+		return options, fmt.Errorf("unexpected argument %q", flags.Arg(1))
+	}
+
+	options.LogPath = flags.Arg(0)
+
+	if options.Format != formatText && options.Format != formatJSON {
+		return options, fmt.Errorf("unknown format %q, want %s or %s", options.Format, formatText, formatJSON)
+	}
+
+	return options, nil
+}
+
+// analyzeLogFile reads the log at options.LogPath, gunzipping a rotated
+// .gz log, and writes the analysis
+func analyzeLogFile(options logsOptions, stdout io.Writer) error {
+	file, err := os.Open(options.LogPath)
+	if err != nil {
+		return fmt.Errorf("open log: %w", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+
+	if strings.HasSuffix(options.LogPath, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("open compressed log: %w", err)
+		}
+		defer gzipReader.Close()
+
+		reader = gzipReader
+	}
+
+	records, lines, skipped, err := readLogRecords(reader)
+	if err != nil {
+		return fmt.Errorf("read log: %w", err)
+	}
+
+	analysis := analyzeLog(records)
+	analysis.File = options.LogPath
+	analysis.Lines = lines
+	analysis.Skipped = skipped
+
+	if options.Format == formatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(analysis); err != nil {
+			return fmt.Errorf("write analysis: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := writeAnalysis(stdout, analysis, options.Timeline, options.Location); err != nil {
+		return fmt.Errorf("write analysis: %w", err)
+	}
+
+	return nil
+}
+
+// writeAnalysis prints a human summary of analysis, with the full timeline
+// when asked for
+func writeAnalysis(output io.Writer, analysis logAnalysis, timeline bool, location *time.Location) error {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "File:        %s\n", analysis.File)
+	fmt.Fprintf(&text, "Lines:       %d", analysis.Lines)
+
+	if analysis.Skipped > 0 {
+		fmt.Fprintf(&text, " (%d unreadable, skipped)", analysis.Skipped)
+	}
+
+	text.WriteString("\n")
+
+	if !analysis.First.IsZero() {
+		fmt.Fprintf(&text, "Period:      %s – %s\n", formatLogTime(analysis.First, location), formatLogTime(analysis.Last, location))
+	}
+
+	fmt.Fprintf(&text, "Sessions:    %d\n", analysis.Sessions)
+
+	states := make([]string, 0, len(analysis.StateSeconds))
+	for _, state := range analysis.sortedStates() {
+		spent := time.Duration(analysis.StateSeconds[state] * float64(time.Second)).Round(time.Second)
+		states = append(states, fmt.Sprintf("%s %s", state, spent))
+	}
+
+	fmt.Fprintf(&text, "States:      %s\n", orNone(strings.Join(states, " · ")))
+
+	breaks := make([]string, 0, len(breakCounters))
+	for _, counter := range []string{"started", "completed", "skipped", "postponed", "interrupted"} {
+		if count := analysis.Breaks[counter]; count > 0 {
+			breaks = append(breaks, fmt.Sprintf("%d %s", count, counter))
+		}
+	}
+
+	fmt.Fprintf(&text, "Breaks:      %s\n", orNone(strings.Join(breaks, " · ")))
+	fmt.Fprintf(&text, "Idle resets: %d\n", analysis.IdleResets)
+
+	if len(analysis.Anomalies) == 0 {
+		text.WriteString("\nNo anomalies found.\n")
+	} else {
+		fmt.Fprintf(&text, "\nAnomalies (%d):\n", len(analysis.Anomalies))
+
+		for _, anomaly := range analysis.Anomalies {
+			fmt.Fprintf(&text, "  line %-6d %s  %-18s %s\n",
+				anomaly.Line, formatLogTime(anomaly.Time, location), anomaly.Kind, anomaly.Detail)
+		}
+	}
+
+	if timeline {
+		fmt.Fprintf(&text, "\nTimeline (%d changes):\n", len(analysis.Timeline))
+
+		for _, transition := range analysis.Timeline {
+			from := transition.From
+			if from == "" {
+				from = "(start)"
+			}
+
+			fmt.Fprintf(&text, "  line %-6d %s  %s → %s", transition.Line, formatLogTime(transition.Time, location), from, transition.To)
+
+			if transition.Remaining != "" {
+				fmt.Fprintf(&text, "  remaining %s", transition.Remaining)
+			}
+
+			if transition.Strict {
+				text.WriteString("  strict")
+			}
+
+			text.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(output, text.String())
+
+	return err
+}
+
+func formatLogTime(moment time.Time, location *time.Location) string {
+	if moment.IsZero() {
+		return strings.Repeat("?", len(timeLayout))
+	}
+
+	return moment.In(location).Format(timeLayout)
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
@@ -23,4 +23,25 @@ type TimeKeeperConfig struct {
 	IdleResetEnabled  bool
 	IdleResetAfter    time.Duration
 	IdleCheckInterval time.Duration
+
+	Reminders []ReminderConfig
+}
+
+// ReminderPresentation selects how a custom reminder is shown
+type ReminderPresentation string
+
+const (
+	PresentationTray         ReminderPresentation = "tray"
+	PresentationNotification ReminderPresentation = "notification"
+	PresentationOverlay      ReminderPresentation = "overlay"
+)
+
+// ReminderConfig defines a lightweight recurring reminder such as "hydrate".
+// Name identifies the reminder and must be unique within a configuration
+type ReminderConfig struct {
+	Name         string
+	Interval     time.Duration
+	Message      string
+	Presentation ReminderPresentation
+	Enabled      bool
 }
@@ -20,15 +20,71 @@ const (
 	EventProgress    EventType = "progress"
 	EventIdleReset   EventType = "idle_reset"
 	EventIdleError   EventType = "idle_error"
+	EventSnapshot    EventType = "snapshot"
+	EventReminder    EventType = "reminder"
+
+	EventBreakStarted     EventType = "break_started"
+	EventBreakCompleted   EventType = "break_completed"
+	EventBreakSkipped     EventType = "break_skipped"
+	EventBreakPostponed   EventType = "break_postponed"
+	EventBreakInterrupted EventType = "break_interrupted"
 )
 
-// Event represents a TimeKeeper update for observers
+// BreakTrigger explains why a break started
+type BreakTrigger string
+
+const (
+	TriggerScheduled BreakTrigger = "scheduled"
+	TriggerForced    BreakTrigger = "forced"
+	TriggerIdle      BreakTrigger = "idle"
+	TriggerLock      BreakTrigger = "lock"
+)
+
+// SkipSource identifies the control that ended or postponed a break early
+type SkipSource string
+
+const (
+	SkipSourceOverlay     SkipSource = "overlay"
+	SkipSourceTray        SkipSource = "tray"
+	SkipSourceCloseButton SkipSource = "close_button"
+)
+
+// BreakInfo is the authoritative record of a single break. Lifecycle events
+// for the same break share its ID. Actual counts elapsed break time only, so
+// time spent paused is excluded
+type BreakInfo struct {
+	ID         string
+	Type       State
+	Trigger    BreakTrigger
+	SkipSource SkipSource
+	Planned    time.Duration
+	Actual     time.Duration
+	Postpone   time.Duration
+	StartedAt  time.Time
+}
+
+// Event represents a TimeKeeper update for observers. PausedFrom is set on
+// StatePaused changes to the state that the pause froze; Reminder is set on
+// EventReminder
 type Event struct {
 	Type       EventType
 	State      State
+	PausedFrom State
 	Remaining  time.Duration
 	Progress   float64
 	StrictMode bool
 	Message    string
+	Break      BreakInfo
+	Reminder   ReminderInfo
 	At         time.Time
 }
+
+// IsBreakLifecycle reports whether the event carries a BreakInfo record
+func (event Event) IsBreakLifecycle() bool {
+	switch event.Type {
+	case EventBreakStarted, EventBreakCompleted, EventBreakSkipped, EventBreakPostponed, EventBreakInterrupted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,106 @@
+package timekeeper
+
+import (
+	"eagleeye/internal/core/model"
+	"time"
+)
+
+// ReminderInfo describes a custom reminder that fell due
+type ReminderInfo struct {
+	Name         string
+	Message      string
+	Presentation model.ReminderPresentation
+}
+
+// reminderTimer tracks the countdown of one configured reminder
+type reminderTimer struct {
+	config model.ReminderConfig
+	next   time.Duration
+}
+
+// SnoozeReminder delays the named reminder by delay. It reports false when no
+// enabled reminder has that name
+func (keeper *TimeKeeper) SnoozeReminder(name string, delay time.Duration) bool {
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
+
+	if delay <= 0 {
+		return false
+	}
+
+	for index := range keeper.reminders {
+		if keeper.reminders[index].config.Name == name {
+			keeper.reminders[index].next = delay
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// resetRemindersLocked restarts every enabled reminder from its full interval
+func (keeper *TimeKeeper) resetRemindersLocked() {
+	keeper.reminders = keeper.reminders[:0]
+
+	for _, config := range keeper.config.Reminders {
+		if !config.Enabled || config.Interval <= 0 || config.Name == "" {
+			continue
+		}
+
+		keeper.reminders = append(keeper.reminders, reminderTimer{config: config, next: config.Interval})
+	}
+}
+
+// reloadRemindersLocked applies a new reminder list while keeping the
+// countdown of reminders whose name and interval did not change
+func (keeper *TimeKeeper) reloadRemindersLocked() {
+	previous := make(map[string]reminderTimer, len(keeper.reminders))
+
+	for _, timer := range keeper.reminders {
+		previous[timer.config.Name] = timer
+	}
+
+	keeper.reminders = nil
+	keeper.resetRemindersLocked()
+
+	for index, timer := range keeper.reminders {
+		old, ok := previous[timer.config.Name]
+
+		if ok && old.config.Interval == timer.config.Interval {
+			keeper.reminders[index].next = old.next
+		}
+	}
+}
+
+// advanceRemindersLocked counts reminders down during work time and emits
+// EventReminder for each one that falls due. Reminders never fire outside
+// StateWork; one that comes due while a break is starting waits for the
+// first work tick after the break
+func (keeper *TimeKeeper) advanceRemindersLocked(delta time.Duration, now time.Time) {
+	if keeper.state != StateWork {
+		return
+	}
+
+	for index := range keeper.reminders {
+		timer := &keeper.reminders[index]
+		timer.next -= delta
+
+		if timer.next > 0 {
+			continue
+		}
+
+		timer.next = timer.config.Interval
+
+		keeper.emitLocked(Event{
+			Type:  EventReminder,
+			State: keeper.state,
+			Reminder: ReminderInfo{
+				Name:         timer.config.Name,
+				Message:      timer.config.Message,
+				Presentation: timer.config.Presentation,
+			},
+			At: now,
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package timekeeper
+
+import "time"
+
+// SubscribeOptions configures a TimeKeeper subscription
+type SubscribeOptions struct {
+	// Buffer is the channel capacity. Non-positive values are normalized to 1
+	Buffer int
+
+	// Types limits delivery to the listed event types. An empty list accepts
+	// every event type
+	Types []EventType
+
+	// CoalesceProgress keeps at most one queued progress event per subscriber,
+	// replacing a stale queued value with the latest one
+	CoalesceProgress bool
+
+	// Replay enqueues an EventSnapshot with the current state immediately after
+	// registration, so late subscribers do not wait for the next tick
+	Replay bool
+}
+
+// Subscription is a registered TimeKeeper observer. The event channel is
+// closed by Close or by TimeKeeper.Stop, whichever happens first
+type Subscription struct {
+	keeper           *TimeKeeper
+	ch               chan Event
+	types            map[EventType]struct{}
+	coalesceProgress bool
+	closed           bool
+}
+
+// Snapshot describes the TimeKeeper state at a single point in time
+type Snapshot struct {
+	State      State
+	PausedFrom State
+	NextBreak  State
+	Running    bool
+	Paused     bool
+	Remaining  time.Duration
+	Progress   float64
+	StrictMode bool
+	At         time.Time
+}
+
+// Subscribe registers a new observer and returns its subscription handle
+func (keeper *TimeKeeper) Subscribe(opts SubscribeOptions) *Subscription {
+	if opts.Buffer <= 0 {
+		opts.Buffer = 1
+	}
+
+	subscription := &Subscription{
+		keeper:           keeper,
+		ch:               make(chan Event, opts.Buffer),
+		coalesceProgress: opts.CoalesceProgress,
+	}
+
+	if len(opts.Types) > 0 {
+		subscription.types = make(map[EventType]struct{}, len(opts.Types))
+
+		for _, eventType := range opts.Types {
+			subscription.types[eventType] = struct{}{}
+		}
+	}
+
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
+
+	keeper.subscribers = append(keeper.subscribers, subscription)
+
+	if opts.Replay {
+		snapshot := keeper.snapshotLocked(time.Now())
+
+		subscription.deliverLocked(Event{
+			Type:       EventSnapshot,
+			State:      snapshot.State,
+			PausedFrom: snapshot.PausedFrom,
+			Remaining:  snapshot.Remaining,
+			Progress:   snapshot.Progress,
+			StrictMode: snapshot.StrictMode,
+			At:         snapshot.At,
+		})
+	}
+
+	return subscription
+}
+
+// Snapshot returns the current TimeKeeper state without waiting for an event
+func (keeper *TimeKeeper) Snapshot() Snapshot {
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
+
+	return keeper.snapshotLocked(time.Now())
+}
+
+// Events returns the receive side of the subscription
+func (subscription *Subscription) Events() <-chan Event {
+	return subscription.ch
+}
+
+// Close unregisters the subscription and closes its channel. Calling Close
+// more than once, or after TimeKeeper.Stop, is a no-op
+func (subscription *Subscription) Close() {
+	keeper := subscription.keeper
+
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
+
+	if subscription.closed {
+		return
+	}
+
+	for index, candidate := range keeper.subscribers {
+		if candidate == subscription {
+			keeper.subscribers = append(keeper.subscribers[:index], keeper.subscribers[index+1:]...)
+
+			break
+		}
+	}
+
+	subscription.closeLocked()
+}
+
+// accepts reports whether the subscription wants events of the given type
+func (subscription *Subscription) accepts(eventType EventType) bool {
+	if subscription.types == nil {
+		return true
+	}
+
+	_, ok := subscription.types[eventType]
+
+	return ok
+}
+
+// closeLocked closes the channel once; callers must hold keeper.mu
+func (subscription *Subscription) closeLocked() {
+	if subscription.closed {
+		return
+	}
+
+	subscription.closed = true
+	close(subscription.ch)
+}
+
+// deliverLocked enqueues an event according to the subscription policy.
+// Callers must hold keeper.mu, which serializes every send on the channel
+func (subscription *Subscription) deliverLocked(event Event) {
+	if subscription.closed || !subscription.accepts(event.Type) {
+		return
+	}
+
+	if event.Type == EventProgress && subscription.coalesceProgress && len(subscription.ch) > 0 {
+		subscription.requeueLocked(event, true)
+
+		return
+	}
+
+	select {
+	case subscription.ch <- event:
+		return
+	default:
+	}
+
+	// Progress без объединения доставляется по мере возможности и просто отбрасывается
+	if event.Type == EventProgress && !subscription.coalesceProgress {
+		return
+	}
+
+	subscription.requeueLocked(event, false)
+}
+
+// requeueLocked drains queued events, drops stale progress (and the oldest
+// remaining events if still full), then refills the channel followed by event
+func (subscription *Subscription) requeueLocked(event Event, dropProgressOnly bool) {
+	queued := make([]Event, 0, cap(subscription.ch))
+
+drain:
+	for {
+		select {
+		case queuedEvent := <-subscription.ch:
+			queued = append(queued, queuedEvent)
+		default:
+			break drain
+		}
+	}
+
+	kept := queued[:0]
+
+	for _, queuedEvent := range queued {
+		if queuedEvent.Type == EventProgress && (dropProgressOnly || len(queued) >= cap(subscription.ch)) {
+			continue
+		}
+
+		kept = append(kept, queuedEvent)
+	}
+
+	for len(kept) >= cap(subscription.ch) {
+		kept = kept[1:]
+	}
+
+	for _, queuedEvent := range kept {
+		subscription.ch <- queuedEvent
+	}
+
+	subscription.ch <- event
+}
@@ -1,8 +1,11 @@
 package timekeeper
 
 import (
+	"crypto/rand"
 	"eagleeye/internal/core/model"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -23,8 +26,9 @@ type Config struct {
 // TimeKeeper is a state machine that manages break scheduling!
 //
 // Callers own the lifecycle: Start begins the ticker loop, Stop synchronously
-// shuts it down, and Subscribe exposes state/progress events. Progress events
-// are best-effort and may be dropped when a subscriber is full; non-progress
+// shuts it down, Subscribe exposes state/progress events and Snapshot answers
+// state queries. Progress events are best-effort and may be dropped (or
+// coalesced to the latest value) when a subscriber is full; non-progress
 // events are delivered by discarding stale queued events until space is
 // available
 type TimeKeeper struct {
@@ -38,12 +42,16 @@ type TimeKeeper struct {
 	nextLong         time.Duration
 	idleChecker      IdleChecker
 	lastIdleCheck    time.Time
-	events           []chan Event
+	idleSince        time.Time
+	subscribers      []*Subscription
 	stopCh           chan struct{}
 	doneCh           chan struct{}
 	running          bool
 	paused           bool
 	lastProgressSent time.Time
+	currentBreak     BreakInfo
+	breakSequence    uint64
+	reminders        []reminderTimer
 }
 
 // New creates a TimeKeeper with the provided configuration
@@ -64,6 +72,7 @@ func New(config model.TimeKeeperConfig, options Config) *TimeKeeper {
 	}
 
 	keeper.resetWorkTimersLocked()
+	keeper.resetRemindersLocked()
 
 	return keeper
 }
@@ -77,23 +86,6 @@ func (keeper *TimeKeeper) SetIdleChecker(checker IdleChecker) {
 	keeper.idleChecker = checker
 }
 
-// Subscribe registers a new observer channel. Non-positive buffers are
-// normalized to 1. Subscriber channels are closed by Stop and are not replayed
-// or reused after they have been closed.
-func (keeper *TimeKeeper) Subscribe(buffer int) <-chan Event {
-	if buffer <= 0 {
-		buffer = 1
-	}
-
-	ch := make(chan Event, buffer)
-
-	keeper.mu.Lock()
-	keeper.events = append(keeper.events, ch)
-	keeper.mu.Unlock()
-
-	return ch
-}
-
 // Start launches the ticking loop. Calling Start while the keeper is already
 // running is a no-op. Calling Start after Stop is supported; it creates a fresh
 // stop/done channel pair and emits a StateWork event for current subscribers
@@ -117,6 +109,7 @@ func (keeper *TimeKeeper) Start() {
 	keeper.previousState = StateWork
 	keeper.remaining = 0
 	keeper.lastIdleCheck = time.Time{}
+	keeper.idleSince = time.Time{}
 
 	keeper.mu.Unlock()
 
@@ -136,14 +129,9 @@ func (keeper *TimeKeeper) Stop() {
 	keeper.mu.Lock()
 
 	if !keeper.running {
-		events := keeper.events
-		keeper.events = nil
+		keeper.closeSubscribersLocked()
 		keeper.mu.Unlock()
 
-		for _, ch := range events {
-			close(ch)
-		}
-
 		return
 	}
 
@@ -151,17 +139,29 @@ func (keeper *TimeKeeper) Stop() {
 	doneCh := keeper.doneCh
 	close(stopCh)
 	keeper.running = false
-	events := keeper.events
-	keeper.events = nil
+	keeper.interruptBreakLocked(time.Now())
+	subscribers := keeper.subscribers
+	keeper.subscribers = nil
 	keeper.mu.Unlock()
 
 	if doneCh != nil {
 		<-doneCh
 	}
 
-	for _, ch := range events {
-		close(ch)
+	keeper.mu.Lock()
+	for _, subscription := range subscribers {
+		subscription.closeLocked()
 	}
+	keeper.mu.Unlock()
+}
+
+// closeSubscribersLocked closes and forgets every registered subscription
+func (keeper *TimeKeeper) closeSubscribersLocked() {
+	for _, subscription := range keeper.subscribers {
+		subscription.closeLocked()
+	}
+
+	keeper.subscribers = nil
 }
 
 // Pause freezes the timer
@@ -183,18 +183,22 @@ func (keeper *TimeKeeper) Pause() {
 
 	keeper.previousState = keeper.state
 	keeper.state = StatePaused
+	pausedFrom := keeper.previousState
 
 	keeper.mu.Unlock()
 
 	keeper.emit(Event{
-		Type:      EventStateChange,
-		State:     StatePaused,
-		Remaining: remaining,
-		At:        time.Now(),
+		Type:       EventStateChange,
+		State:      StatePaused,
+		PausedFrom: pausedFrom,
+		Remaining:  remaining,
+		At:         time.Now(),
 	})
 }
 
-// Resume unfreezes the timer
+// Resume unfreezes the timer. Resuming a paused break re-emits the break
+// state with its frozen remaining time, progress, and strict mode so
+// observers can resynchronise the break UI
 func (keeper *TimeKeeper) Resume() {
 	keeper.mu.Lock()
 
@@ -206,26 +210,29 @@ func (keeper *TimeKeeper) Resume() {
 
 	keeper.paused = false
 	keeper.state = keeper.previousState
-	currentState := keeper.state
-	remaining := keeper.remaining
+	event := Event{
+		Type:      EventStateChange,
+		State:     keeper.state,
+		Remaining: keeper.remaining,
+		At:        time.Now(),
+	}
 
-	if currentState == StateWork {
-		remaining = keeper.nextBreakRemainingLocked()
+	if event.State == StateWork {
+		event.Remaining = keeper.nextBreakRemainingLocked()
+	} else {
+		event.Progress = keeper.breakProgressLocked()
+		event.StrictMode = keeper.config.Long.StrictMode
 	}
 
 	keeper.mu.Unlock()
 
-	keeper.emit(Event{
-		Type:      EventStateChange,
-		State:     currentState,
-		Remaining: remaining,
-		At:        time.Now(),
-	})
+	keeper.emit(event)
 }
 
 // UpdateConfig updates runtime configuration and resets work timers. If the
 // keeper is currently in a break, the active break duration is not recomputed;
-// the new work timers take effect when the keeper returns to StateWork
+// the new work timers take effect when the keeper returns to StateWork.
+// Unchanged reminders keep their countdown
 func (keeper *TimeKeeper) UpdateConfig(config model.TimeKeeperConfig) {
 	keeper.mu.Lock()
 
@@ -235,30 +242,81 @@ func (keeper *TimeKeeper) UpdateConfig(config model.TimeKeeperConfig) {
 
 	keeper.config = config
 	keeper.resetWorkTimersLocked()
+	keeper.reloadRemindersLocked()
 
 	keeper.mu.Unlock()
 }
 
-// SkipBreak ends the current break and returns to work state
-func (keeper *TimeKeeper) SkipBreak() {
+// SkipBreak ends the current break and returns to work state. The source is
+// recorded on the emitted EventBreakSkipped
+func (keeper *TimeKeeper) SkipBreak(source SkipSource) {
 	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
 
 	if keeper.state != StateShortBreak && keeper.state != StateLongBreak {
-		keeper.mu.Unlock()
-
 		return
 	}
 
+	now := time.Now()
+	info := keeper.finishBreakLocked()
+	info.SkipSource = source
+
 	keeper.state = StateWork
 	keeper.remaining = 0
+	keeper.resetWorkTimersLocked()
+
+	keeper.emitLocked(Event{
+		Type:  EventStateChange,
+		State: StateWork,
+		At:    now,
+	})
+	keeper.emitLocked(Event{
+		Type:  EventBreakSkipped,
+		State: StateWork,
+		Break: info,
+		At:    now,
+	})
+}
+
+// PostponeBreak ends the current break and schedules the same break type to
+// start again after delay. No other break is scheduled earlier than delay; if
+// a long break falls due at the same moment, the long break wins
+func (keeper *TimeKeeper) PostponeBreak(delay time.Duration, source SkipSource) {
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
 
+	if delay <= 0 || (keeper.state != StateShortBreak && keeper.state != StateLongBreak) {
+		return
+	}
+
+	now := time.Now()
+	postponed := keeper.state
+	info := keeper.finishBreakLocked()
+	info.SkipSource = source
+	info.Postpone = delay
+
+	keeper.state = StateWork
+	keeper.remaining = 0
 	keeper.resetWorkTimersLocked()
-	keeper.mu.Unlock()
 
-	keeper.emit(Event{
+	if postponed == StateShortBreak || keeper.nextShort < delay {
+		keeper.nextShort = delay
+	}
+
+	if postponed == StateLongBreak || keeper.nextLong < delay {
+		keeper.nextLong = delay
+	}
+
+	keeper.emitLocked(Event{
 		Type:  EventStateChange,
 		State: StateWork,
-		At:    time.Now(),
+		At:    now,
+	})
+	keeper.emitLocked(Event{
+		Type:  EventBreakPostponed,
+		State: StateWork,
+		Break: info,
+		At:    now,
 	})
 }
 
@@ -276,7 +334,7 @@ func (keeper *TimeKeeper) ForceBreak(state State) {
 		return
 	}
 
-	keeper.enterBreakLocked(state)
+	keeper.enterBreakLocked(state, TriggerForced)
 
 	keeper.mu.Unlock()
 }
@@ -299,7 +357,7 @@ func (keeper *TimeKeeper) ForceNextBreak() {
 		return
 	}
 
-	keeper.enterBreakLocked(state)
+	keeper.enterBreakLocked(state, TriggerForced)
 	keeper.mu.Unlock()
 }
 
@@ -310,6 +368,63 @@ func (keeper *TimeKeeper) ResetForIdle() {
 	keeper.mu.Unlock()
 }
 
+// CreditRest counts time spent away from the screen, e.g. while it was
+// locked, as rest. An active break completes when rest covers what was left;
+// during work the longest break type whose duration fits in rest is credited
+// and its timers restart. Credited breaks emit EventBreakCompleted with trigger.
+// Rest that began after an idle stretch was credited is the same time away
+// and is not credited again
+func (keeper *TimeKeeper) CreditRest(rest time.Duration, trigger BreakTrigger) {
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
+
+	if !keeper.running || keeper.paused || rest <= 0 {
+		return
+	}
+
+	now := time.Now()
+
+	if !keeper.idleSince.IsZero() && !keeper.idleSince.After(now.Add(-rest)) {
+		return
+	}
+
+	if keeper.state != StateWork {
+		if rest >= keeper.remaining {
+			keeper.advanceBreakLocked(keeper.remaining, now)
+		}
+
+		return
+	}
+
+	var credited State
+	var planned time.Duration
+
+	switch {
+	case keeper.config.Long.Enabled && rest >= keeper.config.Long.Duration:
+		credited, planned = StateLongBreak, keeper.config.Long.Duration
+		keeper.resetWorkTimersLocked()
+	case keeper.config.Short.Enabled && rest >= keeper.config.Short.Duration:
+		credited, planned = StateShortBreak, keeper.config.Short.Duration
+		keeper.nextShort = keeper.config.Short.Interval
+	default:
+		return
+	}
+
+	keeper.emitLocked(Event{
+		Type:  EventBreakCompleted,
+		State: keeper.state,
+		Break: BreakInfo{
+			ID:        keeper.nextBreakIDLocked(now),
+			Type:      credited,
+			Trigger:   trigger,
+			Planned:   planned,
+			Actual:    rest,
+			StartedAt: now.Add(-rest),
+		},
+		At: now,
+	})
+}
+
 func (keeper *TimeKeeper) run(stopCh <-chan struct{}, doneCh chan<- struct{}) {
 	defer close(doneCh)
 	ticker := time.NewTicker(keeper.options.TickInterval)
@@ -337,6 +452,7 @@ func (keeper *TimeKeeper) tick(tickTime time.Time) {
 	if keeper.state == StateWork {
 		keeper.handleIdleCheckLocked(tickTime)
 		keeper.advanceWorkLocked(keeper.options.TickInterval)
+		keeper.advanceRemindersLocked(keeper.options.TickInterval, tickTime)
 		keeper.maybeEmitProgressLocked(tickTime)
 	} else {
 		keeper.advanceBreakLocked(keeper.options.TickInterval, tickTime)
@@ -379,13 +495,43 @@ func (keeper *TimeKeeper) handleIdleCheckLocked(now time.Time) {
 		return
 	}
 
-	if idleDuration >= keeper.config.IdleResetAfter {
-		keeper.resetWorkTimersLocked()
+	if idleDuration < keeper.config.IdleResetAfter {
+		keeper.idleSince = time.Time{}
+
+		return
+	}
+
+	// One idle stretch is credited once; later checks only keep the timers
+	// at their start until the user is back. The credited break is chosen
+	// before the timers restart so it is the one that was due
+	credit := keeper.idleSince.IsZero()
+
+	var info BreakInfo
+	if credit {
+		keeper.idleSince = now.Add(-idleDuration)
+		info = keeper.idleBreakLocked(now, idleDuration)
+	}
+
+	keeper.resetWorkTimersLocked()
+	keeper.resetRemindersLocked()
+
+	if !credit {
+		return
+	}
+
+	keeper.emitLocked(Event{
+		Type:    EventIdleReset,
+		State:   keeper.state,
+		Message: "idle reset",
+		At:      now,
+	})
+
+	if info.Type != "" {
 		keeper.emitLocked(Event{
-			Type:    EventIdleReset,
-			State:   keeper.state,
-			Message: "idle reset",
-			At:      now,
+			Type:  EventBreakCompleted,
+			State: keeper.state,
+			Break: info,
+			At:    now,
 		})
 	}
 }
@@ -395,7 +541,7 @@ func (keeper *TimeKeeper) advanceWorkLocked(delta time.Duration) {
 		keeper.nextLong -= delta
 
 		if keeper.nextLong <= 0 {
-			keeper.enterBreakLocked(StateLongBreak)
+			keeper.enterBreakLocked(StateLongBreak, TriggerScheduled)
 
 			return
 		}
@@ -404,7 +550,7 @@ func (keeper *TimeKeeper) advanceWorkLocked(delta time.Duration) {
 		keeper.nextShort -= delta
 
 		if keeper.nextShort <= 0 {
-			keeper.enterBreakLocked(StateShortBreak)
+			keeper.enterBreakLocked(StateShortBreak, TriggerScheduled)
 
 			return
 		}
@@ -426,8 +572,9 @@ func (keeper *TimeKeeper) advanceBreakLocked(delta time.Duration, now time.Time)
 		return
 	}
 
-	keeper.state = StateWork
 	keeper.remaining = 0
+	info := keeper.finishBreakLocked()
+	keeper.state = StateWork
 	keeper.resetWorkTimersLocked()
 
 	keeper.emitLocked(Event{
@@ -435,9 +582,18 @@ func (keeper *TimeKeeper) advanceBreakLocked(delta time.Duration, now time.Time)
 		State: StateWork,
 		At:    now,
 	})
+	keeper.emitLocked(Event{
+		Type:  EventBreakCompleted,
+		State: StateWork,
+		Break: info,
+		At:    now,
+	})
 }
 
-func (keeper *TimeKeeper) enterBreakLocked(state State) {
+func (keeper *TimeKeeper) enterBreakLocked(state State, trigger BreakTrigger) {
+	now := time.Now()
+
+	keeper.interruptBreakLocked(now)
 	keeper.state = state
 
 	if state == StateLongBreak {
@@ -448,15 +604,150 @@ func (keeper *TimeKeeper) enterBreakLocked(state State) {
 		keeper.nextShort = keeper.config.Short.Interval
 	}
 
+	keeper.currentBreak = BreakInfo{
+		ID:        keeper.nextBreakIDLocked(now),
+		Type:      state,
+		Trigger:   trigger,
+		Planned:   keeper.remaining,
+		StartedAt: now,
+	}
+
 	keeper.emitLocked(Event{
 		Type:       EventStateChange,
 		State:      state,
 		Remaining:  keeper.remaining,
 		StrictMode: keeper.config.Long.StrictMode,
-		At:         time.Now(),
+		At:         now,
+	})
+	keeper.emitLocked(Event{
+		Type:       EventBreakStarted,
+		State:      state,
+		Remaining:  keeper.remaining,
+		StrictMode: keeper.config.Long.StrictMode,
+		Break:      keeper.currentBreak,
+		At:         now,
+	})
+}
+
+// finishBreakLocked closes the active break record and fills its actual
+// duration from the elapsed (unpaused) break time
+func (keeper *TimeKeeper) finishBreakLocked() BreakInfo {
+	info := keeper.currentBreak
+	keeper.currentBreak = BreakInfo{}
+
+	if info.ID == "" {
+		return info
+	}
+
+	info.Actual = info.Planned - keeper.remaining
+
+	if info.Actual < 0 {
+		info.Actual = 0
+	}
+
+	if info.Actual > info.Planned {
+		info.Actual = info.Planned
+	}
+
+	return info
+}
+
+// interruptBreakLocked reports an active break that ends without completing,
+// skipping, or postponing, e.g. when the keeper stops or a new break replaces it
+func (keeper *TimeKeeper) interruptBreakLocked(now time.Time) {
+	if keeper.currentBreak.ID == "" {
+		return
+	}
+
+	info := keeper.finishBreakLocked()
+
+	keeper.emitLocked(Event{
+		Type:  EventBreakInterrupted,
+		State: keeper.state,
+		Break: info,
+		At:    now,
 	})
 }
 
+// idleBreakLocked credits user inactivity as rest for the break that was due
+// next. The record has no matching EventBreakStarted
+func (keeper *TimeKeeper) idleBreakLocked(now time.Time, idleDuration time.Duration) BreakInfo {
+	state, ok := keeper.nextBreakStateLocked()
+
+	if !ok {
+		return BreakInfo{}
+	}
+
+	planned := keeper.config.Short.Duration
+	if state == StateLongBreak {
+		planned = keeper.config.Long.Duration
+	}
+
+	return BreakInfo{
+		ID:        keeper.nextBreakIDLocked(now),
+		Type:      state,
+		Trigger:   TriggerIdle,
+		Planned:   planned,
+		Actual:    idleDuration,
+		StartedAt: now.Add(-idleDuration),
+	}
+}
+
+// nextBreakIDLocked returns a unique break identifier. The random suffix keeps
+// IDs distinct across restarts; the sequence is a fallback if rand fails
+func (keeper *TimeKeeper) nextBreakIDLocked(now time.Time) string {
+	keeper.breakSequence++
+
+	suffix := make([]byte, 4)
+
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s-%d", now.UTC().Format("20060102T150405"), keeper.breakSequence)
+	}
+
+	return fmt.Sprintf("%s-%s", now.UTC().Format("20060102T150405"), hex.EncodeToString(suffix))
+}
+
+// activeStateLocked returns the state that pause is freezing, or the current
+// state when the keeper is not paused
+func (keeper *TimeKeeper) activeStateLocked() State {
+	if keeper.paused {
+		return keeper.previousState
+	}
+
+	return keeper.state
+}
+
+// snapshotLocked captures the current state for queries and replays
+func (keeper *TimeKeeper) snapshotLocked(now time.Time) Snapshot {
+	snapshot := Snapshot{
+		State:   keeper.state,
+		Running: keeper.running,
+		Paused:  keeper.paused,
+		At:      now,
+	}
+
+	if keeper.paused {
+		snapshot.PausedFrom = keeper.previousState
+	}
+
+	if nextBreak, ok := keeper.nextBreakStateLocked(); ok {
+		snapshot.NextBreak = nextBreak
+	}
+
+	if keeper.activeStateLocked() == StateWork {
+		snapshot.Remaining = keeper.nextBreakRemainingLocked()
+		snapshot.Progress = keeper.workProgressLocked()
+
+		return snapshot
+	}
+
+	snapshot.Remaining = keeper.remaining
+	snapshot.Progress = keeper.breakProgressLocked()
+	snapshot.StrictMode = keeper.config.Long.StrictMode
+
+	return snapshot
+}
+
 func (keeper *TimeKeeper) resetWorkTimersLocked() {
 	keeper.nextShort = keeper.config.Short.Interval
 	keeper.nextLong = keeper.config.Long.Interval
@@ -464,7 +755,7 @@ func (keeper *TimeKeeper) resetWorkTimersLocked() {
 
 func (keeper *TimeKeeper) breakProgressLocked() float64 {
 	var total time.Duration
-	switch keeper.state {
+	switch keeper.activeStateLocked() {
 	case StateShortBreak:
 		total = keeper.config.Short.Duration
 	case StateLongBreak:
@@ -551,34 +842,7 @@ func (keeper *TimeKeeper) emit(event Event) {
 }
 
 func (keeper *TimeKeeper) emitLocked(event Event) {
-	events := append([]chan Event(nil), keeper.events...)
-
-	for _, ch := range events {
-		keeper.enqueueEventLocked(ch, event)
-	}
-}
-
-func (keeper *TimeKeeper) enqueueEventLocked(ch chan Event, event Event) {
-	// Операции Progress выполняются по принципу - по мере возможности изменения состояния должны быть переданы
-	if event.Type == EventProgress {
-		select {
-		case ch <- event:
-		default:
-		}
-
-		return
-	}
-
-	for {
-		select {
-		case ch <- event:
-			return
-		default:
-			// Отмена одного события из очереди (как правило - устаревшее событие прогресса) и повтор попытки
-			select {
-			case <-ch:
-			default:
-			}
-		}
+	for _, subscription := range keeper.subscribers {
+		subscription.deliverLocked(event)
 	}
 }
@@ -86,7 +86,7 @@ func TestForceNextBreakDoesNothingWhenPaused(t *testing.T) {
 
 func TestStopClosesSubscribers(t *testing.T) {
 	keeper := newTestKeeper(model.TimeKeeperConfig{})
-	events := keeper.Subscribe(2)
+	events := keeper.Subscribe(SubscribeOptions{Buffer: 2}).Events()
 
 	keeper.Start()
 	keeper.Stop()
@@ -106,7 +106,7 @@ func TestStartAfterStopRestartsLoop(t *testing.T) {
 	keeper.Start()
 	keeper.Stop()
 
-	events := keeper.Subscribe(4)
+	events := keeper.Subscribe(SubscribeOptions{Buffer: 4}).Events()
 	keeper.Start()
 	keeper.ForceNextBreak()
 	keeper.Stop()
@@ -128,7 +128,7 @@ func TestStopWithoutStartAllowsFutureStart(t *testing.T) {
 
 func TestStopWithoutStartClosesSubscribers(t *testing.T) {
 	keeper := newTestKeeper(model.TimeKeeperConfig{})
-	events := keeper.Subscribe(1)
+	events := keeper.Subscribe(SubscribeOptions{Buffer: 1}).Events()
 
 	keeper.Stop()
 
@@ -159,6 +159,603 @@ func TestNilIdleCheckerIsNoOp(t *testing.T) {
 	}
 }
 
+func TestSubscriptionCloseUnregistersAndClosesChannel(t *testing.T) {
+	keeper := newTestKeeper(model.TimeKeeperConfig{})
+	subscription := keeper.Subscribe(SubscribeOptions{Buffer: 1})
+
+	subscription.Close()
+	subscription.Close()
+
+	assertChannelClosed(t, subscription.Events())
+
+	keeper.mu.Lock()
+	remaining := len(keeper.subscribers)
+	keeper.mu.Unlock()
+
+	if remaining != 0 {
+		t.Fatalf("subscribers = %d, want 0 after Close", remaining)
+	}
+
+	keeper.Start()
+	keeper.Stop()
+}
+
+func TestSubscriptionFiltersEventTypes(t *testing.T) {
+	keeper := newTestKeeper(model.TimeKeeperConfig{})
+	subscription := keeper.Subscribe(SubscribeOptions{Buffer: 4, Types: []EventType{EventIdleReset}})
+
+	keeper.mu.Lock()
+	keeper.emitLocked(Event{Type: EventStateChange, State: StateWork})
+	keeper.emitLocked(Event{Type: EventProgress, State: StateWork})
+	keeper.emitLocked(Event{Type: EventIdleReset, State: StateWork})
+	keeper.mu.Unlock()
+
+	event := <-subscription.Events()
+	if event.Type != EventIdleReset {
+		t.Fatalf("event type = %s, want %s", event.Type, EventIdleReset)
+	}
+
+	if queued := len(subscription.Events()); queued != 0 {
+		t.Fatalf("queued events = %d, want 0", queued)
+	}
+}
+
+func TestSubscriptionCoalescesProgress(t *testing.T) {
+	keeper := newTestKeeper(model.TimeKeeperConfig{})
+	subscription := keeper.Subscribe(SubscribeOptions{Buffer: 4, CoalesceProgress: true})
+
+	keeper.mu.Lock()
+	keeper.emitLocked(Event{Type: EventProgress, Remaining: 3 * time.Second})
+	keeper.emitLocked(Event{Type: EventStateChange, State: StateShortBreak})
+	keeper.emitLocked(Event{Type: EventProgress, Remaining: 2 * time.Second})
+	keeper.emitLocked(Event{Type: EventProgress, Remaining: time.Second})
+	keeper.mu.Unlock()
+
+	first := <-subscription.Events()
+	if first.Type != EventStateChange {
+		t.Fatalf("first event = %s, want %s", first.Type, EventStateChange)
+	}
+
+	second := <-subscription.Events()
+	if second.Type != EventProgress || second.Remaining != time.Second {
+		t.Fatalf("second event = %+v, want latest progress", second)
+	}
+
+	if queued := len(subscription.Events()); queued != 0 {
+		t.Fatalf("queued events = %d, want 0", queued)
+	}
+}
+
+func TestSubscriptionReplaysSnapshot(t *testing.T) {
+	keeper := newTestKeeper(model.TimeKeeperConfig{
+		Short: model.BreakConfig{
+			Interval: 10 * time.Minute,
+			Duration: 15 * time.Second,
+			Enabled:  true,
+		},
+	})
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.ForceNextBreak()
+
+	subscription := keeper.Subscribe(SubscribeOptions{Buffer: 1, Replay: true})
+	event := <-subscription.Events()
+
+	if event.Type != EventSnapshot || event.State != StateShortBreak {
+		t.Fatalf("replayed event = %+v, want short break snapshot", event)
+	}
+
+	if event.Remaining != 15*time.Second {
+		t.Fatalf("replayed remaining = %s, want 15s", event.Remaining)
+	}
+}
+
+func TestSnapshotReportsNextBreakWhilePaused(t *testing.T) {
+	keeper := newTestKeeper(model.TimeKeeperConfig{
+		Short: model.BreakConfig{
+			Interval: 10 * time.Minute,
+			Duration: 15 * time.Second,
+			Enabled:  true,
+		},
+	})
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.Pause()
+
+	snapshot := keeper.Snapshot()
+
+	if snapshot.State != StatePaused || !snapshot.Paused || !snapshot.Running {
+		t.Fatalf("snapshot = %+v, want running paused keeper", snapshot)
+	}
+
+	if snapshot.NextBreak != StateShortBreak || snapshot.Remaining != 10*time.Minute {
+		t.Fatalf("snapshot next break = %s in %s, want short_break in 10m", snapshot.NextBreak, snapshot.Remaining)
+	}
+}
+
+func TestBreakLifecycleSkipSharesBreakID(t *testing.T) {
+	keeper := newTestKeeper(shortOnlyConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventBreakStarted, EventBreakSkipped},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.ForceNextBreak()
+	keeper.SkipBreak(SkipSourceTray)
+
+	started := <-subscription.Events()
+	skipped := <-subscription.Events()
+
+	if started.Type != EventBreakStarted || started.Break.Trigger != TriggerForced {
+		t.Fatalf("started event = %+v, want forced break start", started)
+	}
+
+	if started.Break.ID == "" || skipped.Break.ID != started.Break.ID {
+		t.Fatalf("break IDs = %q/%q, want matching non-empty IDs", started.Break.ID, skipped.Break.ID)
+	}
+
+	if skipped.Type != EventBreakSkipped || skipped.Break.SkipSource != SkipSourceTray {
+		t.Fatalf("skipped event = %+v, want tray skip", skipped)
+	}
+
+	if skipped.Break.Planned != 15*time.Second || skipped.Break.Actual != 0 {
+		t.Fatalf("skipped durations = %s/%s, want 15s planned and 0s actual", skipped.Break.Planned, skipped.Break.Actual)
+	}
+}
+
+func TestBreakLifecycleCompletesScheduledBreak(t *testing.T) {
+	keeper := newTestKeeper(shortOnlyConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventBreakStarted, EventBreakCompleted},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.mu.Lock()
+	keeper.advanceWorkLocked(10 * time.Minute)
+	keeper.advanceBreakLocked(15*time.Second, time.Now())
+	keeper.mu.Unlock()
+
+	started := <-subscription.Events()
+	completed := <-subscription.Events()
+
+	if started.Break.Trigger != TriggerScheduled {
+		t.Fatalf("trigger = %s, want %s", started.Break.Trigger, TriggerScheduled)
+	}
+
+	if completed.Type != EventBreakCompleted || completed.Break.ID != started.Break.ID {
+		t.Fatalf("completed event = %+v, want completion of %q", completed, started.Break.ID)
+	}
+
+	if completed.Break.Actual != completed.Break.Planned {
+		t.Fatalf("actual = %s, want planned %s", completed.Break.Actual, completed.Break.Planned)
+	}
+}
+
+func TestBreakLifecycleStopInterruptsActiveBreak(t *testing.T) {
+	keeper := newTestKeeper(shortOnlyConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventBreakInterrupted},
+	})
+
+	keeper.Start()
+	keeper.ForceNextBreak()
+	keeper.Stop()
+
+	event, ok := <-subscription.Events()
+	if !ok || event.Type != EventBreakInterrupted || event.Break.Type != StateShortBreak {
+		t.Fatalf("event = %+v (ok=%t), want short break interruption", event, ok)
+	}
+}
+
+func TestBreakLifecyclePostponeReschedulesSameBreak(t *testing.T) {
+	keeper := newTestKeeper(shortOnlyConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventBreakPostponed},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.ForceNextBreak()
+	keeper.PostponeBreak(5*time.Minute, SkipSourceTray)
+
+	event := <-subscription.Events()
+	if event.Break.Postpone != 5*time.Minute || event.Break.SkipSource != SkipSourceTray {
+		t.Fatalf("postponed event = %+v, want 5m tray postpone", event)
+	}
+
+	snapshot := keeper.Snapshot()
+	if snapshot.State != StateWork || snapshot.NextBreak != StateShortBreak || snapshot.Remaining != 5*time.Minute {
+		t.Fatalf("snapshot = %+v, want short break in 5m", snapshot)
+	}
+}
+
+func TestIdleResetEmitsIdleCompletedBreak(t *testing.T) {
+	config := shortOnlyConfig()
+	config.IdleResetEnabled = true
+	config.IdleResetAfter = time.Minute
+	config.IdleCheckInterval = time.Nanosecond
+
+	keeper := newTestKeeper(config)
+	keeper.SetIdleChecker(fixedIdleChecker(2 * time.Minute))
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 2,
+		Types:  []EventType{EventBreakCompleted},
+	})
+
+	keeper.mu.Lock()
+	keeper.handleIdleCheckLocked(time.Now())
+	keeper.mu.Unlock()
+
+	event := <-subscription.Events()
+	if event.Break.Trigger != TriggerIdle || event.Break.Actual != 2*time.Minute {
+		t.Fatalf("idle break = %+v, want 2m idle-triggered completion", event.Break)
+	}
+}
+
+// TestIdleResetCreditsTheBreakThatWasDue verifies idle time completes the
+// long break when it was due before the short one, at its configured length
+func TestIdleResetCreditsTheBreakThatWasDue(t *testing.T) {
+	config := shortOnlyConfig()
+	config.Long = model.LongBreakConfig{
+		BreakConfig: model.BreakConfig{
+			Interval: time.Hour,
+			Duration: 5 * time.Minute,
+			Enabled:  true,
+		},
+	}
+	config.IdleResetEnabled = true
+	config.IdleResetAfter = time.Minute
+	config.IdleCheckInterval = time.Nanosecond
+
+	keeper := newTestKeeper(config)
+	keeper.SetIdleChecker(fixedIdleChecker(2 * time.Hour))
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 2,
+		Types:  []EventType{EventBreakCompleted},
+	})
+
+	keeper.mu.Lock()
+	keeper.nextShort = 5 * time.Minute
+	keeper.nextLong = time.Minute
+	keeper.handleIdleCheckLocked(time.Now())
+	keeper.mu.Unlock()
+
+	event := <-subscription.Events()
+	if event.Break.Type != StateLongBreak || event.Break.Planned != 5*time.Minute || event.Break.Actual != 2*time.Hour {
+		t.Fatalf("idle break = %+v, want 5m long break with 2h actual", event.Break)
+	}
+}
+
+// TestIdleStretchIsCreditedOnce verifies repeated idle checks during one
+// stretch away complete a single break, and a new stretch credits again
+func TestIdleStretchIsCreditedOnce(t *testing.T) {
+	config := shortOnlyConfig()
+	config.IdleResetEnabled = true
+	config.IdleResetAfter = time.Minute
+	config.IdleCheckInterval = time.Nanosecond
+
+	keeper := newTestKeeper(config)
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 16,
+		Types:  []EventType{EventBreakCompleted},
+	})
+
+	now := time.Now()
+	check := func(idle time.Duration) {
+		keeper.SetIdleChecker(fixedIdleChecker(idle))
+		now = now.Add(20 * time.Second)
+
+		keeper.mu.Lock()
+		keeper.handleIdleCheckLocked(now)
+		keeper.mu.Unlock()
+	}
+
+	for idle := time.Minute; idle <= 3*time.Minute; idle += 20 * time.Second {
+		check(idle)
+	}
+
+	if got := len(subscription.Events()); got != 1 {
+		t.Fatalf("completed breaks = %d, want 1", got)
+	}
+
+	check(time.Second)
+	check(2 * time.Minute)
+
+	if got := len(subscription.Events()); got != 2 {
+		t.Fatalf("completed breaks = %d, want 2", got)
+	}
+}
+
+func TestPauseDuringBreakFreezesAndResumesRemaining(t *testing.T) {
+	keeper := newTestKeeper(shortOnlyConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventStateChange},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.ForceNextBreak()
+
+	for event := range subscription.Events() {
+		if event.State == StateShortBreak {
+			break
+		}
+	}
+
+	keeper.mu.Lock()
+	keeper.advanceBreakLocked(5*time.Second, time.Now())
+	keeper.mu.Unlock()
+
+	keeper.Pause()
+
+	paused := <-subscription.Events()
+	if paused.State != StatePaused || paused.PausedFrom != StateShortBreak || paused.Remaining != 10*time.Second {
+		t.Fatalf("paused event = %+v, want short break paused with 10s left", paused)
+	}
+
+	keeper.Resume()
+
+	resumed := <-subscription.Events()
+	if resumed.State != StateShortBreak || resumed.Remaining != 10*time.Second {
+		t.Fatalf("resumed event = %+v, want short break with 10s left", resumed)
+	}
+
+	if resumed.Progress <= 0 || resumed.Progress >= 1 {
+		t.Fatalf("resumed progress = %v, want value inside (0, 1)", resumed.Progress)
+	}
+}
+
+func TestReminderFiresDuringWorkAndRestarts(t *testing.T) {
+	keeper := newTestKeeper(reminderConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventReminder},
+	})
+
+	keeper.mu.Lock()
+	keeper.advanceRemindersLocked(3*time.Minute, time.Now())
+	keeper.mu.Unlock()
+
+	event := <-subscription.Events()
+	if event.Reminder.Name != "hydrate" || event.Reminder.Presentation != model.PresentationNotification {
+		t.Fatalf("reminder = %+v, want hydrate notification", event.Reminder)
+	}
+
+	keeper.mu.Lock()
+	next := keeper.reminders[0].next
+	keeper.mu.Unlock()
+
+	if next != 3*time.Minute {
+		t.Fatalf("next = %s, want full interval 3m", next)
+	}
+}
+
+func TestReminderDoesNotFireDuringBreak(t *testing.T) {
+	keeper := newTestKeeper(reminderConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventReminder},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.ForceNextBreak()
+
+	keeper.mu.Lock()
+	keeper.advanceRemindersLocked(5*time.Minute, time.Now())
+	keeper.mu.Unlock()
+
+	select {
+	case event := <-subscription.Events():
+		t.Fatalf("unexpected reminder during break: %+v", event)
+	default:
+	}
+}
+
+func TestSnoozeReminderDelaysNamedReminder(t *testing.T) {
+	keeper := newTestKeeper(reminderConfig())
+
+	if keeper.SnoozeReminder("unknown", time.Minute) {
+		t.Fatalf("SnoozeReminder(unknown) = true, want false")
+	}
+
+	if !keeper.SnoozeReminder("hydrate", 30*time.Minute) {
+		t.Fatalf("SnoozeReminder(hydrate) = false, want true")
+	}
+
+	keeper.mu.Lock()
+	next := keeper.reminders[0].next
+	keeper.mu.Unlock()
+
+	if next != 30*time.Minute {
+		t.Fatalf("next = %s, want 30m", next)
+	}
+}
+
+func TestUpdateConfigKeepsUnchangedReminderCountdown(t *testing.T) {
+	config := reminderConfig()
+	keeper := newTestKeeper(config)
+
+	keeper.mu.Lock()
+	keeper.advanceRemindersLocked(time.Minute, time.Now())
+	keeper.mu.Unlock()
+
+	config.Reminders = append(config.Reminders, model.ReminderConfig{
+		Name:     "posture",
+		Interval: 10 * time.Minute,
+		Enabled:  true,
+	})
+	keeper.UpdateConfig(config)
+
+	keeper.mu.Lock()
+	defer keeper.mu.Unlock()
+
+	if len(keeper.reminders) != 2 || keeper.reminders[0].next != 2*time.Minute || keeper.reminders[1].next != 10*time.Minute {
+		t.Fatalf("reminders = %+v, want hydrate at 2m and posture at 10m", keeper.reminders)
+	}
+}
+
+func TestCreditRestCreditsLongestFittingBreak(t *testing.T) {
+	config := shortOnlyConfig()
+	config.Long = model.LongBreakConfig{
+		BreakConfig: model.BreakConfig{
+			Interval: time.Hour,
+			Duration: 5 * time.Minute,
+			Enabled:  true,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		rest     time.Duration
+		wantType State
+	}{
+		{name: "too short", rest: 10 * time.Second},
+		{name: "short break", rest: time.Minute, wantType: StateShortBreak},
+		{name: "long break", rest: 6 * time.Minute, wantType: StateLongBreak},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keeper := newTestKeeper(config)
+			subscription := keeper.Subscribe(SubscribeOptions{
+				Buffer: 2,
+				Types:  []EventType{EventBreakCompleted},
+			})
+
+			keeper.Start()
+			defer keeper.Stop()
+
+			keeper.mu.Lock()
+			keeper.nextShort = time.Minute
+			keeper.nextLong = 10 * time.Minute
+			keeper.mu.Unlock()
+
+			keeper.CreditRest(test.rest, TriggerLock)
+
+			select {
+			case event := <-subscription.Events():
+				if event.Break.Type != test.wantType || event.Break.Trigger != TriggerLock || event.Break.Actual != test.rest {
+					t.Fatalf("credited break = %+v, want %s lock credit", event.Break, test.wantType)
+				}
+			default:
+				if test.wantType != "" {
+					t.Fatalf("no credited break, want %s", test.wantType)
+				}
+			}
+
+			keeper.mu.Lock()
+			nextShort := keeper.nextShort
+			keeper.mu.Unlock()
+
+			if test.wantType != "" && nextShort != config.Short.Interval {
+				t.Fatalf("nextShort = %s, want reset to %s", nextShort, config.Short.Interval)
+			}
+		})
+	}
+}
+
+func TestCreditRestCompletesActiveBreak(t *testing.T) {
+	keeper := newTestKeeper(shortOnlyConfig())
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventBreakCompleted},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.ForceNextBreak()
+	keeper.CreditRest(time.Minute, TriggerLock)
+
+	event := <-subscription.Events()
+	if event.Break.Type != StateShortBreak || currentState(keeper) != StateWork {
+		t.Fatalf("event = %+v, state = %s, want completed short break and work state", event.Break, currentState(keeper))
+	}
+}
+
+// TestCreditRestSkipsTimeAlreadyCreditedAsIdle verifies a lock that follows
+// a credited idle stretch does not credit the same time away twice
+func TestCreditRestSkipsTimeAlreadyCreditedAsIdle(t *testing.T) {
+	config := shortOnlyConfig()
+	config.IdleResetEnabled = true
+	config.IdleResetAfter = time.Minute
+	config.IdleCheckInterval = time.Nanosecond
+
+	keeper := newTestKeeper(config)
+	keeper.SetIdleChecker(fixedIdleChecker(2 * time.Minute))
+	subscription := keeper.Subscribe(SubscribeOptions{
+		Buffer: 4,
+		Types:  []EventType{EventBreakCompleted},
+	})
+
+	keeper.Start()
+	defer keeper.Stop()
+
+	keeper.mu.Lock()
+	keeper.handleIdleCheckLocked(time.Now())
+	keeper.mu.Unlock()
+
+	keeper.CreditRest(time.Minute, TriggerLock)
+
+	if got := len(subscription.Events()); got != 1 {
+		t.Fatalf("completed breaks = %d, want 1", got)
+	}
+
+	if event := <-subscription.Events(); event.Break.Trigger != TriggerIdle {
+		t.Fatalf("completed break trigger = %s, want %s", event.Break.Trigger, TriggerIdle)
+	}
+}
+
+type fixedIdleChecker time.Duration
+
+func (checker fixedIdleChecker) IdleDuration() (time.Duration, error) {
+	return time.Duration(checker), nil
+}
+
+func shortOnlyConfig() model.TimeKeeperConfig {
+	return model.TimeKeeperConfig{
+		Short: model.BreakConfig{
+			Interval: 10 * time.Minute,
+			Duration: 15 * time.Second,
+			Enabled:  true,
+		},
+	}
+}
+
+func reminderConfig() model.TimeKeeperConfig {
+	config := shortOnlyConfig()
+	config.Reminders = []model.ReminderConfig{
+		{
+			Name:         "hydrate",
+			Interval:     3 * time.Minute,
+			Message:      "Drink some water",
+			Presentation: model.PresentationNotification,
+			Enabled:      true,
+		},
+		{
+			Name:     "disabled",
+			Interval: time.Minute,
+		},
+	}
+
+	return config
+}
+
 func newTestKeeper(config model.TimeKeeperConfig) *TimeKeeper {
 	return New(config, Config{TickInterval: time.Hour})
 }
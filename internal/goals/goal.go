@@ -0,0 +1,94 @@
+// Package goals turns recorded breaks into daily goals, streaks and
+// achievement badges.
+//
+// Goals are plain data: a metric over one day's breaks, a comparison and a
+// target. New goals are added to DefaultGoals without touching the
+// TimeKeeper or the UI. A Tracker evaluates the goals day by day from
+// history records and keeps streaks and earned badges in a small JSON file
+// so they survive restarts and history retention.
+package goals
+
+import (
+	"eagleeye/internal/history"
+	"slices"
+)
+
+// Metric is what a goal measures over one day's breaks
+type Metric string
+
+const (
+	// MetricCompliance is the share of decided breaks that were taken
+	MetricCompliance Metric = "compliance"
+	// MetricCompleted counts breaks taken
+	MetricCompleted Metric = "completed"
+	// MetricSkipped counts breaks skipped or postponed
+	MetricSkipped Metric = "skipped"
+)
+
+// Comparison is how a day's metric is held against the target
+type Comparison string
+
+const (
+	AtLeast Comparison = "at_least"
+	AtMost  Comparison = "at_most"
+)
+
+// Goal is one daily target. Only breaks of Types count, all types when
+// empty. Days with fewer than MinBreaks taken, skipped or postponed breaks
+// are left out, so days off neither extend nor break a streak
+type Goal struct {
+	ID        string              `json:"id"`
+	Metric    Metric              `json:"metric"`
+	Compare   Comparison          `json:"compare"`
+	Target    float64             `json:"target"`
+	Types     []history.BreakType `json:"types,omitempty"`
+	MinBreaks int                 `json:"min_breaks,omitempty"`
+}
+
+// DefaultGoals are the goals EagleEye tracks
+var DefaultGoals = []Goal{
+	{ID: "honour_90", Metric: MetricCompliance, Compare: AtLeast, Target: 0.9, MinBreaks: 1},
+	{ID: "no_skipped_long", Metric: MetricSkipped, Compare: AtMost, Target: 0, Types: []history.BreakType{history.BreakLong}, MinBreaks: 1},
+	{ID: "ten_breaks", Metric: MetricCompleted, Compare: AtLeast, Target: 10, MinBreaks: 1},
+}
+
+// Milestones are the streak lengths in days that earn a badge for any goal
+var Milestones = []int{3, 7, 30, 100}
+
+// Evaluate reports whether one day's records meet the goal and whether the
+// day counts for it at all
+func (goal Goal) Evaluate(records []history.Record) (met, counted bool) {
+	matching := make([]history.Record, 0, len(records))
+
+	for _, record := range records {
+		if len(goal.Types) == 0 || slices.Contains(goal.Types, record.Type) {
+			matching = append(matching, record)
+		}
+	}
+
+	summary := history.Summarize(matching)
+	decided := summary.Completed + summary.Skipped + summary.Postponed
+
+	if decided < goal.MinBreaks {
+		return false, false
+	}
+
+	var value float64
+
+	switch goal.Metric {
+	case MetricCompliance:
+		value = summary.Compliance()
+	case MetricCompleted:
+		value = float64(summary.Completed)
+	case MetricSkipped:
+		value = float64(summary.Skipped + summary.Postponed)
+	default:
+		return false, false
+	}
+
+	if goal.Compare == AtMost {
+		return value <= goal.Target, true
+	}
+
+	return value >= goal.Target, true
+}
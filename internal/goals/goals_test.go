@@ -0,0 +1,119 @@
+package goals
+
+import (
+	"eagleeye/internal/history"
+	"eagleeye/internal/history/historytest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var firstDay = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func breakOn(day int, hour int, breakType history.BreakType, outcome history.Outcome) history.Record {
+	startedAt := firstDay.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+
+	return historytest.Record(startedAt.Format(time.RFC3339)+string(outcome), breakType, startedAt, outcome)
+}
+
+// TestGoalEvaluateFiltersTypesAndMinimum verifies the metric, type filter and day minimum
+func TestGoalEvaluateFiltersTypesAndMinimum(t *testing.T) {
+	noSkippedLong := DefaultGoals[1]
+	shortSkip := []history.Record{
+		breakOn(0, 9, history.BreakShort, history.OutcomeSkipped),
+		breakOn(0, 10, history.BreakLong, history.OutcomeCompleted),
+	}
+
+	if met, counted := noSkippedLong.Evaluate(shortSkip); !met || !counted {
+		t.Fatalf("Evaluate(short skipped) = %v, %v, want met and counted", met, counted)
+	}
+
+	if met, counted := noSkippedLong.Evaluate(shortSkip[:1]); met || counted {
+		t.Fatalf("Evaluate(no long breaks) = %v, %v, want not counted", met, counted)
+	}
+
+	longSkip := append(shortSkip, breakOn(0, 11, history.BreakLong, history.OutcomePostponed))
+	if met, _ := noSkippedLong.Evaluate(longSkip); met {
+		t.Fatal("Evaluate(long postponed) met = true, want false")
+	}
+
+	honour := DefaultGoals[0]
+	if met, _ := honour.Evaluate(longSkip); met {
+		t.Fatal("honour_90 Evaluate(1 of 3) met = true, want false")
+	}
+}
+
+// TestTrackerCountsStreaksAcrossRestarts verifies streaks skip days off, reset on misses and persist
+func TestTrackerCountsStreaksAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goals.json")
+	honour := []Goal{DefaultGoals[0]}
+
+	tracker, err := Open(path, honour)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	records := []history.Record{
+		breakOn(0, 9, history.BreakShort, history.OutcomeSkipped),
+		breakOn(1, 9, history.BreakShort, history.OutcomeCompleted),
+		breakOn(2, 9, history.BreakShort, history.OutcomeCompleted),
+		breakOn(4, 9, history.BreakShort, history.OutcomeCompleted),
+	}
+
+	status, err := tracker.Update(records, firstDay.AddDate(0, 0, 4).Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	progress := status.Progress[0]
+	if progress.Current != 3 || progress.Best != 3 || !progress.Today {
+		t.Fatalf("Progress = %+v, want a 3-day streak met today", progress)
+	}
+
+	if len(status.Earned) != 1 || status.Earned[0].ID() != "honour_90_3" {
+		t.Fatalf("Earned = %+v, want honour_90_3", status.Earned)
+	}
+
+	reopened, err := Open(path, honour)
+	if err != nil {
+		t.Fatalf("Open(saved) error = %v", err)
+	}
+
+	now := firstDay.AddDate(0, 0, 5).Add(8 * time.Hour)
+	if since := reopened.Since(time.UTC); !since.Equal(firstDay.AddDate(0, 0, 4)) {
+		t.Fatalf("Since() = %v, want %v", since, firstDay.AddDate(0, 0, 4))
+	}
+
+	later := []history.Record{
+		records[3],
+		breakOn(5, 7, history.BreakShort, history.OutcomeSkipped),
+	}
+
+	status, err = reopened.Update(later, now)
+	if err != nil {
+		t.Fatalf("Update(reopened) error = %v", err)
+	}
+
+	progress = status.Progress[0]
+	if progress.Current != 3 || progress.Today || progress.Best != 3 || len(status.Earned) != 1 {
+		t.Fatalf("Progress = %+v, earned %d, want a 3-day streak not met today and one badge", progress, len(status.Earned))
+	}
+}
+
+// TestOpenReportsDamagedState verifies a corrupt state file yields a usable fresh tracker
+func TestOpenReportsDamagedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goals.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tracker, err := Open(path, DefaultGoals)
+	if err == nil {
+		t.Fatal("Open(corrupt) error = nil, want error")
+	}
+
+	if since := tracker.Since(time.UTC); !since.IsZero() {
+		t.Fatalf("Since() = %v, want zero for a fresh tracker", since)
+	}
+}
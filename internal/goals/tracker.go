@@ -0,0 +1,233 @@
+package goals
+
+import (
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/history"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Streak is a goal's run of met days. Through is the last whole day already
+// folded in, as YYYY-MM-DD; today is never stored since it is not over
+type Streak struct {
+	Current int    `json:"current"`
+	Best    int    `json:"best"`
+	Through string `json:"through,omitempty"`
+}
+
+// Achievement is a badge earned for reaching a streak milestone
+type Achievement struct {
+	GoalID string    `json:"goal"`
+	Days   int       `json:"days"`
+	At     time.Time `json:"at"`
+}
+
+// ID identifies the achievement, for example "honour_90_7"
+func (achievement Achievement) ID() string {
+	return fmt.Sprintf("%s_%d", achievement.GoalID, achievement.Days)
+}
+
+// Progress is a goal's streak as of now. Current includes today once
+// today's breaks already meet the goal
+type Progress struct {
+	Goal    Goal
+	Current int
+	Best    int
+	Today   bool
+}
+
+// Status is what Update reports: progress per goal, in goal order, and every
+// earned badge, oldest first
+type Status struct {
+	Progress []Progress
+	Earned   []Achievement
+}
+
+// state is the on-disk form of a tracker
+type state struct {
+	Streaks map[string]Streak `json:"streaks"`
+	Earned  []Achievement     `json:"earned,omitempty"`
+}
+
+// Tracker evaluates goals from break records and persists their streaks
+type Tracker struct {
+	mu    sync.Mutex
+	path  string
+	goals []Goal
+	state state
+}
+
+// Open loads the tracker state at path. A missing file starts fresh; an
+// unreadable one is reported alongside a fresh tracker, which rebuilds
+// streaks from the break history on the next Update
+func Open(path string, goals []Goal) (*Tracker, error) {
+	tracker := &Tracker{
+		path:  path,
+		goals: goals,
+		state: state{Streaks: map[string]Streak{}},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tracker, nil
+	}
+
+	if err != nil {
+		return tracker, fmt.Errorf("read goals: %w", err)
+	}
+
+	var loaded state
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return tracker, fmt.Errorf("decode goals: %w", err)
+	}
+
+	if loaded.Streaks != nil {
+		tracker.state = loaded
+	}
+
+	return tracker, nil
+}
+
+// Since is the earliest start time Update needs records from: the day after
+// the oldest evaluated day, or zero when some goal was never evaluated
+func (tracker *Tracker) Since(location *time.Location) time.Time {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	var since time.Time
+
+	for _, goal := range tracker.goals {
+		through, ok := parseDay(tracker.state.Streaks[goal.ID].Through, location)
+		if !ok {
+			return time.Time{}
+		}
+
+		if next := through.AddDate(0, 0, 1); since.IsZero() || next.Before(since) {
+			since = next
+		}
+	}
+
+	return since
+}
+
+// Update folds the whole days before now into each streak, adds today's
+// breaks so far, awards badges for milestones reached and saves the state.
+// records must cover at least Since(now.Location()) up to now
+func (tracker *Tracker) Update(records []history.Record, now time.Time) (Status, error) {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	location := now.Location()
+	today := history.Day(now).From
+	days := map[time.Time][]history.Record{}
+	first := today
+
+	for _, record := range records {
+		day := history.Day(record.StartedAt.In(location)).From
+		days[day] = append(days[day], record)
+
+		if day.Before(first) {
+			first = day
+		}
+	}
+
+	status := Status{Progress: make([]Progress, 0, len(tracker.goals))}
+	earned := map[string]bool{}
+
+	for _, achievement := range tracker.state.Earned {
+		earned[achievement.ID()] = true
+	}
+
+	for _, goal := range tracker.goals {
+		streak := tracker.state.Streaks[goal.ID]
+
+		day := first
+		if through, ok := parseDay(streak.Through, location); ok {
+			day = through.AddDate(0, 0, 1)
+		}
+
+		for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+			if met, counted := goal.Evaluate(days[day]); counted {
+				streak = advance(streak, met)
+			}
+		}
+
+		// A clock moved backwards leaves Through ahead of today; keep it so no
+		// day is folded in twice
+		if day.Equal(today) {
+			streak.Through = today.AddDate(0, 0, -1).Format(dateLayout)
+		}
+
+		tracker.state.Streaks[goal.ID] = streak
+
+		progress := Progress{Goal: goal, Current: streak.Current, Best: streak.Best}
+		if met, counted := goal.Evaluate(days[today]); counted && met {
+			progress.Current++
+			progress.Today = true
+			progress.Best = max(progress.Best, progress.Current)
+		}
+
+		for _, milestone := range Milestones {
+			achievement := Achievement{GoalID: goal.ID, Days: milestone, At: now}
+			if progress.Current >= milestone && !earned[achievement.ID()] {
+				earned[achievement.ID()] = true
+				tracker.state.Earned = append(tracker.state.Earned, achievement)
+			}
+		}
+
+		status.Progress = append(status.Progress, progress)
+	}
+
+	status.Earned = append([]Achievement(nil), tracker.state.Earned...)
+
+	return status, tracker.save()
+}
+
+// advance folds one counted day into a streak
+func advance(streak Streak, met bool) Streak {
+	if !met {
+		streak.Current = 0
+
+		return streak
+	}
+
+	streak.Current++
+	streak.Best = max(streak.Best, streak.Current)
+
+	return streak
+}
+
+func parseDay(value string, location *time.Location) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	day, err := time.ParseInLocation(dateLayout, value, location)
+
+	return day, err == nil
+}
+
+// save writes the state file through a synced temp file and a rename
+func (tracker *Tracker) save() error {
+	data, err := json.MarshalIndent(tracker.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode goals: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(tracker.path), 0o700); err != nil {
+		return fmt.Errorf("create goals directory: %w", err)
+	}
+
+	if err := atomicfile.Write(tracker.path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("save goals: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,371 @@
+package history
+
+import (
+	"bytes"
+	"eagleeye/internal/atomicfile"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ActivityMinute is the screen time observed during one wall-clock minute.
+// Active and Idle never add up to more than a minute
+type ActivityMinute struct {
+	Start  time.Time
+	Active time.Duration
+	Idle   time.Duration
+}
+
+// activityLine is the compact on-disk form of an ActivityMinute, in seconds
+type activityLine struct {
+	Start  time.Time `json:"t"`
+	Active float64   `json:"a"`
+	Idle   float64   `json:"i"`
+}
+
+// MarshalJSON writes the minute with durations in seconds
+func (minute ActivityMinute) MarshalJSON() ([]byte, error) {
+	return json.Marshal(activityLine{
+		Start:  minute.Start,
+		Active: minute.Active.Round(time.Second).Seconds(),
+		Idle:   minute.Idle.Round(time.Second).Seconds(),
+	})
+}
+
+// UnmarshalJSON reads a minute written by MarshalJSON
+func (minute *ActivityMinute) UnmarshalJSON(data []byte) error {
+	var line activityLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		return err
+	}
+
+	*minute = ActivityMinute{
+		Start:  line.Start,
+		Active: time.Duration(line.Active * float64(time.Second)),
+		Idle:   time.Duration(line.Idle * float64(time.Second)),
+	}
+
+	return nil
+}
+
+// valid reports whether a decoded line is a minute with sensible durations
+func (minute ActivityMinute) valid() bool {
+	return !minute.Start.IsZero() && minute.Active >= 0 && minute.Idle >= 0 && minute.Active+minute.Idle <= time.Minute
+}
+
+// ActivityRecorder turns idle samples into minute buckets. Every sample
+// covers the time since the previous one: the last Idle of it counts as idle
+// once it reaches the idle threshold, the rest as active. Gaps longer than
+// maxGap, such as suspend, are not counted at all. It is safe for concurrent
+// use
+type ActivityRecorder struct {
+	mu        sync.Mutex
+	idleAfter time.Duration
+	maxGap    time.Duration
+	last      time.Time
+	minutes   []ActivityMinute
+	saved     int
+}
+
+// NewActivityRecorder creates a recorder that counts inactivity of at least
+// idleAfter as idle and ignores samples more than maxGap apart
+func NewActivityRecorder(idleAfter, maxGap time.Duration) *ActivityRecorder {
+	return &ActivityRecorder{idleAfter: idleAfter, maxGap: maxGap}
+}
+
+// Seed starts the recorder with minutes loaded from the store, which are not
+// saved again. It must be called before the first Observe
+func (recorder *ActivityRecorder) Seed(minutes []ActivityMinute) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.minutes = mergeMinutes(append([]ActivityMinute(nil), minutes...))
+	recorder.saved = len(recorder.minutes)
+}
+
+// Observe records a sample taken at now reporting idle of inactivity
+func (recorder *ActivityRecorder) Observe(now time.Time, idle time.Duration) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	last := recorder.last
+	recorder.last = now
+
+	if last.IsZero() || !now.After(last) || now.Sub(last) > recorder.maxGap {
+		return
+	}
+
+	idleFrom := now
+	if idle >= recorder.idleAfter {
+		idleFrom = now.Add(-idle)
+	}
+
+	if idleFrom.Before(last) {
+		idleFrom = last
+	}
+
+	recorder.addSpanLocked(last, idleFrom, false)
+	recorder.addSpanLocked(idleFrom, now, true)
+}
+
+// Skip forgets the previous sample so the time until the next one is not
+// counted, for example when a sample failed
+func (recorder *ActivityRecorder) Skip() {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.last = time.Time{}
+}
+
+// addSpanLocked splits [from, to) at minute boundaries into the buckets
+func (recorder *ActivityRecorder) addSpanLocked(from, to time.Time, idle bool) {
+	for from.Before(to) {
+		start := from.Truncate(time.Minute)
+		end := start.Add(time.Minute)
+
+		if to.Before(end) {
+			end = to
+		}
+
+		minute := recorder.minuteLocked(start)
+		if idle {
+			minute.Idle += end.Sub(from)
+		} else {
+			minute.Active += end.Sub(from)
+		}
+
+		from = end
+	}
+}
+
+// minuteLocked returns the bucket starting at start, adding it when missing.
+// Samples move forward in time, so only the newest bucket is ever reused
+func (recorder *ActivityRecorder) minuteLocked(start time.Time) *ActivityMinute {
+	if count := len(recorder.minutes); count > recorder.saved && recorder.minutes[count-1].Start.Equal(start) {
+		return &recorder.minutes[count-1]
+	}
+
+	recorder.minutes = append(recorder.minutes, ActivityMinute{Start: start})
+
+	return &recorder.minutes[len(recorder.minutes)-1]
+}
+
+// Flush returns the minutes that ended by now and were not returned before,
+// for the caller to save. With all set it returns the current minute too,
+// for shutdown
+func (recorder *ActivityRecorder) Flush(now time.Time, all bool) []ActivityMinute {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	end := recorder.saved
+	for end < len(recorder.minutes) && (all || !recorder.minutes[end].Start.Add(time.Minute).After(now)) {
+		end++
+	}
+
+	flushed := append([]ActivityMinute(nil), recorder.minutes[recorder.saved:end]...)
+	recorder.saved = end
+
+	return flushed
+}
+
+// Trim drops saved minutes that started before from
+func (recorder *ActivityRecorder) Trim(from time.Time) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	drop := 0
+	for drop < recorder.saved && recorder.minutes[drop].Start.Before(from) {
+		drop++
+	}
+
+	recorder.minutes = append([]ActivityMinute(nil), recorder.minutes[drop:]...)
+	recorder.saved -= drop
+}
+
+// Minutes returns every minute the recorder holds, oldest first
+func (recorder *ActivityRecorder) Minutes() []ActivityMinute {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return append([]ActivityMinute(nil), recorder.minutes...)
+}
+
+// ActivityDay is the screen time of one calendar day. LongestActive is the
+// longest run of consecutive, mostly active minutes
+type ActivityDay struct {
+	Day           time.Time
+	Active        time.Duration
+	Idle          time.Duration
+	LongestActive time.Duration
+}
+
+// SummarizeActivity groups minutes by the calendar day they started on in
+// location, oldest day first
+func SummarizeActivity(minutes []ActivityMinute, location *time.Location) []ActivityDay {
+	sorted := mergeMinutes(append([]ActivityMinute(nil), minutes...))
+
+	var (
+		days    []ActivityDay
+		stretch time.Duration
+		last    time.Time
+	)
+
+	for _, minute := range sorted {
+		dayStart := startOfDay(minute.Start.In(location))
+		if len(days) == 0 || !days[len(days)-1].Day.Equal(dayStart) {
+			days = append(days, ActivityDay{Day: dayStart})
+			stretch = 0
+		}
+
+		day := &days[len(days)-1]
+		day.Active += minute.Active
+		day.Idle += minute.Idle
+
+		if minute.Active <= minute.Idle || (stretch > 0 && !minute.Start.Equal(last.Add(time.Minute))) {
+			stretch = 0
+		}
+
+		if minute.Active > minute.Idle {
+			stretch += minute.Active
+			day.LongestActive = max(day.LongestActive, stretch)
+		}
+
+		last = minute.Start
+	}
+
+	return days
+}
+
+// SummarizeActivityPeriod totals the days of minutes starting in query's
+// time range: active and idle time add up and the longest stretch is the
+// longest of any day. Day is query.From
+func SummarizeActivityPeriod(minutes []ActivityMinute, query Query) ActivityDay {
+	total := ActivityDay{Day: query.From}
+
+	for _, day := range SummarizeActivity(minutes, query.From.Location()) {
+		if !query.covers(day.Day) {
+			continue
+		}
+
+		total.Active += day.Active
+		total.Idle += day.Idle
+		total.LongestActive = max(total.LongestActive, day.LongestActive)
+	}
+
+	return total
+}
+
+// AppendActivity adds minutes as lines to the activity file at path,
+// creating the file and its directory when missing
+func AppendActivity(path string, minutes []ActivityMinute) error {
+	if len(minutes) == 0 {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	for _, minute := range minutes {
+		if err := encoder.Encode(minute); err != nil {
+			return fmt.Errorf("encode activity minute: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+
+	if err := appendLine(path, bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))); err != nil {
+		return fmt.Errorf("write screen activity: %w", err)
+	}
+
+	return nil
+}
+
+// ReadActivity returns the minutes starting in [from, to), oldest first,
+// merging minutes saved more than once. Zero bounds are open. Damaged lines
+// are reported through *DamagedError alongside the readable minutes
+func ReadActivity(path string, from, to time.Time) ([]ActivityMinute, error) {
+	minutes, _, err := readLines(path, ActivityMinute.valid)
+	if err != nil && !isDamaged(err) {
+		return nil, fmt.Errorf("read screen activity: %w", err)
+	}
+
+	query := Query{From: from, To: to}
+	matched := make([]ActivityMinute, 0, len(minutes))
+
+	for _, minute := range minutes {
+		if query.covers(minute.Start) {
+			matched = append(matched, minute)
+		}
+	}
+
+	return mergeMinutes(matched), err
+}
+
+// CompactActivity rewrites the activity file without minutes that started
+// before cutoff, damaged lines or repeated minutes. An already compact file
+// is left alone
+func CompactActivity(path string, cutoff time.Time) error {
+	minutes, lines, err := readLines(path, ActivityMinute.valid)
+	if err != nil && !isDamaged(err) {
+		return fmt.Errorf("read screen activity: %w", err)
+	}
+
+	kept := make([]ActivityMinute, 0, len(minutes))
+
+	for _, minute := range minutes {
+		if !minute.Start.Before(cutoff) {
+			kept = append(kept, minute)
+		}
+	}
+
+	kept = mergeMinutes(kept)
+	if len(kept) == lines {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	for _, minute := range kept {
+		if err := encoder.Encode(minute); err != nil {
+			return fmt.Errorf("encode activity minute: %w", err)
+		}
+	}
+
+	if err := atomicfile.Write(path, buffer.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("compact screen activity: %w", err)
+	}
+
+	return nil
+}
+
+// mergeMinutes sorts minutes and folds repeated starts into one, capping the
+// sum at a minute. A minute saved in part before a restart shows up twice
+func mergeMinutes(minutes []ActivityMinute) []ActivityMinute {
+	sort.SliceStable(minutes, func(i, j int) bool {
+		return minutes[i].Start.Before(minutes[j].Start)
+	})
+
+	merged := minutes[:0]
+
+	for _, minute := range minutes {
+		count := len(merged)
+		if count == 0 || !merged[count-1].Start.Equal(minute.Start) {
+			merged = append(merged, minute)
+
+			continue
+		}
+
+		previous := &merged[count-1]
+		previous.Active = min(previous.Active+minute.Active, time.Minute)
+		previous.Idle = min(previous.Idle+minute.Idle, time.Minute-previous.Active)
+	}
+
+	return merged
+}
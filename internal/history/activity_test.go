@@ -0,0 +1,107 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestActivityRecorderSplitsSamplesIntoMinutes verifies active and idle spans, minute boundaries and gaps
+func TestActivityRecorderSplitsSamplesIntoMinutes(t *testing.T) {
+	recorder := NewActivityRecorder(time.Minute, time.Minute)
+
+	start := testStart.Add(30 * time.Second)
+	recorder.Observe(start, 0)
+	recorder.Observe(start.Add(20*time.Second), 5*time.Second)
+	recorder.Observe(start.Add(40*time.Second), 0)
+	recorder.Observe(start.Add(100*time.Second), 90*time.Second)
+	recorder.Observe(start.Add(30*time.Minute), 0)
+
+	minutes := recorder.Flush(start.Add(30*time.Minute), false)
+	if len(minutes) != 3 {
+		t.Fatalf("Flush() = %+v, want three minutes", minutes)
+	}
+
+	if first := minutes[0]; !first.Start.Equal(testStart) || first.Active != 30*time.Second || first.Idle != 0 {
+		t.Fatalf("minutes[0] = %+v, want 30s active from %v", first, testStart)
+	}
+
+	if second := minutes[1]; second.Active != 10*time.Second || second.Idle != 50*time.Second {
+		t.Fatalf("minutes[1] = %+v, want 10s active then 50s idle", second)
+	}
+
+	if third := minutes[2]; third.Active != 0 || third.Idle != 10*time.Second {
+		t.Fatalf("minutes[2] = %+v, want 10s idle", third)
+	}
+
+	if again := recorder.Flush(start.Add(time.Hour), true); len(again) != 0 {
+		t.Fatalf("Flush() after the gap = %+v, want nothing counted", again)
+	}
+}
+
+// TestSummarizeActivityFindsLongestStretch verifies daily totals and that idle or missing minutes end a stretch
+func TestSummarizeActivityFindsLongestStretch(t *testing.T) {
+	minute := func(offset int, active, idle time.Duration) ActivityMinute {
+		return ActivityMinute{Start: testStart.Add(time.Duration(offset) * time.Minute), Active: active, Idle: idle}
+	}
+
+	minutes := []ActivityMinute{
+		minute(0, time.Minute, 0),
+		minute(1, 50*time.Second, 10*time.Second),
+		minute(2, 10*time.Second, 50*time.Second),
+		minute(3, time.Minute, 0),
+		minute(4, time.Minute, 0),
+		minute(5, time.Minute, 0),
+		minute(7, time.Minute, 0),
+		minute(24*60, time.Minute, 0),
+	}
+
+	days := SummarizeActivity(minutes, time.UTC)
+	if len(days) != 2 {
+		t.Fatalf("len(days) = %d, want 2", len(days))
+	}
+
+	if day := days[0]; day.Active != 6*time.Minute || day.Idle != time.Minute || day.LongestActive != 3*time.Minute {
+		t.Fatalf("days[0] = %+v, want 6m active, 1m idle, 3m longest", day)
+	}
+
+	week := SummarizeActivityPeriod(minutes, Week(testStart))
+	if week.Active != 7*time.Minute || week.LongestActive != 3*time.Minute {
+		t.Fatalf("SummarizeActivityPeriod() = %+v, want 7m active, 3m longest", week)
+	}
+}
+
+// TestActivityFileMergesAndCompacts verifies repeated minutes merge and compaction drops old ones
+func TestActivityFileMergesAndCompacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "activity.jsonl")
+
+	first := []ActivityMinute{
+		{Start: testStart.AddDate(0, 0, -10), Active: time.Minute},
+		{Start: testStart, Active: 20 * time.Second},
+	}
+	resumed := []ActivityMinute{{Start: testStart, Active: 15 * time.Second, Idle: 25 * time.Second}}
+
+	for _, minutes := range [][]ActivityMinute{first, resumed} {
+		if err := AppendActivity(path, minutes); err != nil {
+			t.Fatalf("AppendActivity() error = %v", err)
+		}
+	}
+
+	minutes, err := ReadActivity(path, testStart.AddDate(0, 0, -1), time.Time{})
+	if err != nil {
+		t.Fatalf("ReadActivity() error = %v", err)
+	}
+
+	if len(minutes) != 1 || minutes[0].Active != 35*time.Second || minutes[0].Idle != 25*time.Second {
+		t.Fatalf("ReadActivity() = %+v, want one merged minute", minutes)
+	}
+
+	if err := CompactActivity(path, testStart.AddDate(0, 0, -1)); err != nil {
+		t.Fatalf("CompactActivity() error = %v", err)
+	}
+
+	all, err := ReadActivity(path, time.Time{}, time.Time{})
+	if err != nil || len(all) != 1 {
+		t.Fatalf("ReadActivity(all) = %+v, %v, want the one recent minute", all, err)
+	}
+}
@@ -0,0 +1,197 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// MinRating and MaxRating bound a comfort rating
+	MinRating = 1
+	MaxRating = 5
+)
+
+// CheckIn is how the user rated their eye comfort after a break. BreakID
+// links it to the break record it followed, when known
+type CheckIn struct {
+	Time    time.Time `json:"time"`
+	Rating  int       `json:"rating"`
+	Note    string    `json:"note,omitempty"`
+	BreakID string    `json:"break_id,omitempty"`
+}
+
+// valid reports whether a decoded line carries a time and an in-range rating
+func (checkIn CheckIn) valid() bool {
+	return !checkIn.Time.IsZero() && checkIn.Rating >= MinRating && checkIn.Rating <= MaxRating
+}
+
+// AppendCheckIn adds checkIn as one line to the check-in file at path,
+// creating the file and its directory when missing
+func AppendCheckIn(path string, checkIn CheckIn) error {
+	if !checkIn.valid() {
+		return fmt.Errorf("append comfort check-in: rating %d outside %d-%d or missing time", checkIn.Rating, MinRating, MaxRating)
+	}
+
+	line, err := json.Marshal(checkIn)
+	if err != nil {
+		return fmt.Errorf("encode comfort check-in: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+
+	if err := appendLine(path, line); err != nil {
+		return fmt.Errorf("write comfort check-ins: %w", err)
+	}
+
+	return nil
+}
+
+// ReadCheckIns returns the check-ins in [from, to), oldest first. Zero bounds
+// are open. Damaged lines are reported through *DamagedError alongside the
+// readable check-ins; a missing file has none
+func ReadCheckIns(path string, from, to time.Time) ([]CheckIn, error) {
+	checkIns, _, err := readLines(path, CheckIn.valid)
+	if err != nil && !isDamaged(err) {
+		return nil, fmt.Errorf("read comfort check-ins: %w", err)
+	}
+
+	matched := make([]CheckIn, 0, len(checkIns))
+
+	for _, checkIn := range checkIns {
+		if !from.IsZero() && checkIn.Time.Before(from) {
+			continue
+		}
+
+		if !to.IsZero() && !checkIn.Time.Before(to) {
+			continue
+		}
+
+		matched = append(matched, checkIn)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Time.Before(matched[j].Time)
+	})
+
+	return matched, err
+}
+
+// ComfortDay pairs one day's break compliance with its mean comfort rating.
+// Rating is zero on days without check-ins
+type ComfortDay struct {
+	Day        time.Time
+	Compliance float64
+	Decided    int
+	Rating     float64
+	Ratings    int
+}
+
+// ComfortTrend is the daily comfort and compliance series, oldest day first.
+// Correlation is Pearson's r between compliance and rating over the days
+// that have both, valid only when Correlated is set
+type ComfortTrend struct {
+	Days        []ComfortDay
+	Correlation float64
+	Correlated  bool
+}
+
+// minCorrelatedDays is how many days with both a decided break and a rating
+// the correlation needs before it means anything
+const minCorrelatedDays = 3
+
+// BuildComfortTrend groups records and check-ins by calendar day in
+// location. Days with neither decided breaks nor check-ins are left out
+func BuildComfortTrend(records []Record, checkIns []CheckIn, location *time.Location) ComfortTrend {
+	days := map[time.Time]*ComfortDay{}
+
+	dayOf := func(at time.Time) *ComfortDay {
+		day := startOfDay(at.In(location))
+		if days[day] == nil {
+			days[day] = &ComfortDay{Day: day}
+		}
+
+		return days[day]
+	}
+
+	for _, summary := range SummarizeByDay(records, location) {
+		decided := summary.Completed + summary.Skipped + summary.Postponed
+		if decided == 0 {
+			continue
+		}
+
+		day := dayOf(summary.Day)
+		day.Decided = decided
+		day.Compliance = summary.Compliance()
+	}
+
+	for _, checkIn := range checkIns {
+		day := dayOf(checkIn.Time)
+		day.Rating += float64(checkIn.Rating)
+		day.Ratings++
+	}
+
+	var (
+		trend               ComfortTrend
+		compliance, ratings []float64
+	)
+
+	for _, day := range days {
+		if day.Ratings > 0 {
+			day.Rating /= float64(day.Ratings)
+		}
+
+		if day.Ratings > 0 && day.Decided > 0 {
+			compliance = append(compliance, day.Compliance)
+			ratings = append(ratings, day.Rating)
+		}
+
+		trend.Days = append(trend.Days, *day)
+	}
+
+	sort.Slice(trend.Days, func(i, j int) bool {
+		return trend.Days[i].Day.Before(trend.Days[j].Day)
+	})
+
+	if len(compliance) >= minCorrelatedDays {
+		trend.Correlation, trend.Correlated = pearson(compliance, ratings)
+	}
+
+	return trend
+}
+
+// pearson is the correlation coefficient of two equally long series. It is
+// undefined when either series is constant
+func pearson(left, right []float64) (float64, bool) {
+	count := float64(len(left))
+
+	var leftMean, rightMean float64
+	for index := range left {
+		leftMean += left[index]
+		rightMean += right[index]
+	}
+
+	leftMean /= count
+	rightMean /= count
+
+	var covariance, leftVariance, rightVariance float64
+	for index := range left {
+		leftDelta := left[index] - leftMean
+		rightDelta := right[index] - rightMean
+		covariance += leftDelta * rightDelta
+		leftVariance += leftDelta * leftDelta
+		rightVariance += rightDelta * rightDelta
+	}
+
+	if leftVariance == 0 || rightVariance == 0 {
+		return 0, false
+	}
+
+	return covariance / math.Sqrt(leftVariance*rightVariance), true
+}
@@ -0,0 +1,121 @@
+package history
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCheckInsAppendAndReadRange verifies check-ins read back in time order within the range
+func TestCheckInsAppendAndReadRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "comfort.jsonl")
+
+	later := CheckIn{Time: testStart.Add(2 * time.Hour), Rating: 4, BreakID: "b2"}
+	earlier := CheckIn{Time: testStart, Rating: 2, Note: "dry"}
+	outside := CheckIn{Time: testStart.AddDate(0, 0, 1), Rating: 5}
+
+	for _, checkIn := range []CheckIn{later, earlier, outside} {
+		if err := AppendCheckIn(path, checkIn); err != nil {
+			t.Fatalf("AppendCheckIn() error = %v", err)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Fatalf("mode = %v, want 0600", mode)
+	}
+
+	checkIns, err := ReadCheckIns(path, testStart, testStart.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("ReadCheckIns() error = %v", err)
+	}
+
+	if len(checkIns) != 2 || checkIns[0].Note != "dry" || checkIns[1].BreakID != "b2" {
+		t.Fatalf("ReadCheckIns() = %+v, want earlier then later", checkIns)
+	}
+
+	if err := AppendCheckIn(path, CheckIn{Time: testStart, Rating: 6}); err == nil {
+		t.Fatal("AppendCheckIn(rating 6) error = nil, want error")
+	}
+}
+
+// TestReadCheckInsReportsDamagedLines verifies bad lines are skipped and reported
+func TestReadCheckInsReportsDamagedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "comfort.jsonl")
+	content := `{"time":"2026-03-02T09:00:00Z","rating":3}
+{"time":"2026-03-02T10:00:00Z","rating":0}
+{"time":"2026-03-02T11:00`
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	checkIns, err := ReadCheckIns(path, time.Time{}, time.Time{})
+
+	var damaged *DamagedError
+	if !errors.As(err, &damaged) || len(damaged.Lines) != 2 {
+		t.Fatalf("ReadCheckIns() error = %v, want two damaged lines", err)
+	}
+
+	if len(checkIns) != 1 || checkIns[0].Rating != 3 {
+		t.Fatalf("ReadCheckIns() = %+v, want the one valid check-in", checkIns)
+	}
+}
+
+// TestBuildComfortTrendCorrelatesDays verifies daily averages and the compliance correlation
+func TestBuildComfortTrendCorrelatesDays(t *testing.T) {
+	var (
+		records  []Record
+		checkIns []CheckIn
+	)
+
+	// Day n completes n of three breaks and is rated n+2, twice on the last day
+	for day := 0; day < 3; day++ {
+		start := testStart.AddDate(0, 0, day)
+
+		for index := 0; index < 3; index++ {
+			outcome := OutcomeSkipped
+			if index < day+1 {
+				outcome = OutcomeCompleted
+			}
+
+			records = append(records, testRecord(start.Add(time.Duration(index)*time.Hour).Format(time.RFC3339), start.Add(time.Duration(index)*time.Hour), outcome))
+		}
+
+		checkIns = append(checkIns, CheckIn{Time: start.Add(4 * time.Hour), Rating: day + 2})
+	}
+
+	checkIns = append(checkIns,
+		CheckIn{Time: testStart.AddDate(0, 0, 2).Add(5 * time.Hour), Rating: 4},
+		CheckIn{Time: testStart.AddDate(0, 0, 5), Rating: 1},
+	)
+
+	trend := BuildComfortTrend(records, checkIns, time.UTC)
+	if len(trend.Days) != 4 {
+		t.Fatalf("len(Days) = %d, want 4", len(trend.Days))
+	}
+
+	last := trend.Days[2]
+	if last.Decided != 3 || last.Compliance != 1 || last.Rating != 4 || last.Ratings != 2 {
+		t.Fatalf("Days[2] = %+v, want full compliance rated 4 twice", last)
+	}
+
+	if ratedOnly := trend.Days[3]; ratedOnly.Decided != 0 || ratedOnly.Rating != 1 {
+		t.Fatalf("Days[3] = %+v, want a rating without breaks", ratedOnly)
+	}
+
+	if !trend.Correlated || trend.Correlation < 0.9 || math.IsNaN(trend.Correlation) {
+		t.Fatalf("Correlation = %v, %v, want strongly positive", trend.Correlation, trend.Correlated)
+	}
+
+	if short := BuildComfortTrend(records[:3], checkIns[:1], time.UTC); short.Correlated {
+		t.Fatal("BuildComfortTrend(one day) Correlated = true, want false")
+	}
+}
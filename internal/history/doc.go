@@ -0,0 +1,11 @@
+// Package history keeps a local record of break outcomes.
+//
+// Each finished break is appended as one JSON line to a store file that is
+// compacted on open and after a number of appends, dropping records older
+// than the retention window along with damaged or duplicate lines. Query and
+// the summary helpers read records back by day, week, type or outcome for the
+// statistics UI and command-line export. Comfort check-ins and per-minute
+// screen activity are kept the same way in files of their own. The package
+// has no Fyne or TimeKeeper dependency; internal/app converts TimeKeeper
+// events to records and idle samples to activity minutes.
+package history
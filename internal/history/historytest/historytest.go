@@ -0,0 +1,23 @@
+// Package historytest builds break records for tests of packages that read
+// break history.
+package historytest
+
+import (
+	"eagleeye/internal/history"
+	"time"
+)
+
+// Record is a scheduled break of breakType that started on time at startedAt
+// and ran its planned 20 seconds
+func Record(id string, breakType history.BreakType, startedAt time.Time, outcome history.Outcome) history.Record {
+	return history.Record{
+		ID:          id,
+		Type:        breakType,
+		Trigger:     "scheduled",
+		ScheduledAt: startedAt,
+		StartedAt:   startedAt,
+		Planned:     20 * time.Second,
+		Actual:      20 * time.Second,
+		Outcome:     outcome,
+	}
+}
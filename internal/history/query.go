@@ -0,0 +1,137 @@
+package history
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// Query selects records by start time, type and outcome. From is inclusive
+// and To exclusive; zero bounds and empty lists match everything
+type Query struct {
+	From     time.Time
+	To       time.Time
+	Types    []BreakType
+	Outcomes []Outcome
+}
+
+// Matches reports whether record falls inside the query
+func (query Query) Matches(record Record) bool {
+	if !query.covers(record.StartedAt) {
+		return false
+	}
+
+	if len(query.Types) > 0 && !slices.Contains(query.Types, record.Type) {
+		return false
+	}
+
+	if len(query.Outcomes) > 0 && !slices.Contains(query.Outcomes, record.Outcome) {
+		return false
+	}
+
+	return true
+}
+
+// covers reports whether at falls inside the query's time range
+func (query Query) covers(at time.Time) bool {
+	if !query.From.IsZero() && at.Before(query.From) {
+		return false
+	}
+
+	return query.To.IsZero() || at.Before(query.To)
+}
+
+// Day selects the calendar day containing day, in day's location
+func Day(day time.Time) Query {
+	start := startOfDay(day)
+
+	return Query{From: start, To: start.AddDate(0, 0, 1)}
+}
+
+// Week selects the Monday-to-Sunday week containing day, in day's location
+func Week(day time.Time) Query {
+	start := startOfDay(day)
+	start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+
+	return Query{From: start, To: start.AddDate(0, 0, 7)}
+}
+
+func startOfDay(day time.Time) time.Time {
+	year, month, date := day.Date()
+
+	return time.Date(year, month, date, 0, 0, 0, 0, day.Location())
+}
+
+// Summary aggregates a set of records
+type Summary struct {
+	Total       int
+	Completed   int
+	Skipped     int
+	Postponed   int
+	Interrupted int
+	Planned     time.Duration
+	Actual      time.Duration
+}
+
+// Compliance is the share of breaks the user let finish, ignoring breaks the
+// app interrupted itself. It is zero when no break was decided by the user
+func (summary Summary) Compliance() float64 {
+	decided := summary.Completed + summary.Skipped + summary.Postponed
+	if decided == 0 {
+		return 0
+	}
+
+	return float64(summary.Completed) / float64(decided)
+}
+
+// Summarize counts records by outcome and totals their durations
+func Summarize(records []Record) Summary {
+	var summary Summary
+
+	for _, record := range records {
+		summary.Total++
+		summary.Planned += record.Planned
+		summary.Actual += record.Actual
+
+		switch record.Outcome {
+		case OutcomeCompleted:
+			summary.Completed++
+		case OutcomeSkipped:
+			summary.Skipped++
+		case OutcomePostponed:
+			summary.Postponed++
+		case OutcomeInterrupted:
+			summary.Interrupted++
+		}
+	}
+
+	return summary
+}
+
+// DaySummary is the Summary of one calendar day
+type DaySummary struct {
+	Day time.Time
+	Summary
+}
+
+// SummarizeByDay groups records by the calendar day they started on in
+// location, oldest day first. Days without records are left out
+func SummarizeByDay(records []Record, location *time.Location) []DaySummary {
+	groups := map[time.Time][]Record{}
+
+	for _, record := range records {
+		day := startOfDay(record.StartedAt.In(location))
+		groups[day] = append(groups[day], record)
+	}
+
+	days := make([]DaySummary, 0, len(groups))
+	for day, group := range groups {
+		days = append(days, DaySummary{Day: day, Summary: Summarize(group)})
+	}
+
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Day.Before(days[j].Day)
+	})
+
+	return days
+}
@@ -0,0 +1,71 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDayAndWeekRanges verifies calendar ranges start at local midnight and on Monday
+func TestDayAndWeekRanges(t *testing.T) {
+	location := time.FixedZone("UTC+3", 3*60*60)
+	wednesday := time.Date(2026, 3, 4, 23, 30, 0, 0, location)
+
+	day := Day(wednesday)
+	if want := time.Date(2026, 3, 4, 0, 0, 0, 0, location); !day.From.Equal(want) || !day.To.Equal(want.AddDate(0, 0, 1)) {
+		t.Fatalf("Day() = %v..%v, want %v..+1d", day.From, day.To, want)
+	}
+
+	week := Week(wednesday)
+	if want := time.Date(2026, 3, 2, 0, 0, 0, 0, location); !week.From.Equal(want) || !week.To.Equal(want.AddDate(0, 0, 7)) {
+		t.Fatalf("Week() = %v..%v, want %v..+7d", week.From, week.To, want)
+	}
+
+	sunday := Week(time.Date(2026, 3, 8, 12, 0, 0, 0, location))
+	if !sunday.From.Equal(week.From) {
+		t.Fatalf("Week(sunday).From = %v, want %v", sunday.From, week.From)
+	}
+}
+
+// TestSummarizeCountsOutcomes verifies aggregates and compliance
+func TestSummarizeCountsOutcomes(t *testing.T) {
+	records := []Record{
+		testRecord("a", testStart, OutcomeCompleted),
+		testRecord("b", testStart, OutcomeCompleted),
+		testRecord("c", testStart, OutcomeSkipped),
+		testRecord("d", testStart, OutcomePostponed),
+		testRecord("e", testStart, OutcomeInterrupted),
+	}
+
+	summary := Summarize(records)
+
+	if summary.Total != 5 || summary.Completed != 2 || summary.Skipped != 1 || summary.Postponed != 1 || summary.Interrupted != 1 {
+		t.Fatalf("Summarize() = %+v, want 5 total split 2/1/1/1", summary)
+	}
+
+	if got := summary.Compliance(); got != 0.5 {
+		t.Fatalf("Compliance() = %v, want 0.5", got)
+	}
+
+	if got := (Summary{Interrupted: 2}).Compliance(); got != 0 {
+		t.Fatalf("Compliance() without decided breaks = %v, want 0", got)
+	}
+}
+
+// TestSummarizeByDayGroupsInLocation verifies days follow the requested location
+func TestSummarizeByDayGroupsInLocation(t *testing.T) {
+	location := time.FixedZone("UTC-5", -5*60*60)
+	records := []Record{
+		testRecord("a", time.Date(2026, 3, 3, 2, 0, 0, 0, time.UTC), OutcomeCompleted),
+		testRecord("b", time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC), OutcomeSkipped),
+	}
+
+	days := SummarizeByDay(records, location)
+
+	if len(days) != 2 {
+		t.Fatalf("len(days) = %d, want 2", len(days))
+	}
+
+	if days[0].Day.Day() != 2 || days[0].Completed != 1 || days[1].Day.Day() != 3 || days[1].Skipped != 1 {
+		t.Fatalf("SummarizeByDay() = %+v, want Mar 2 completed then Mar 3 skipped", days)
+	}
+}
@@ -0,0 +1,44 @@
+package history
+
+import "time"
+
+// BreakType is the kind of break a record describes
+type BreakType string
+
+const (
+	BreakShort BreakType = "short_break"
+	BreakLong  BreakType = "long_break"
+)
+
+// Outcome is how a break ended
+type Outcome string
+
+const (
+	OutcomeCompleted   Outcome = "completed"
+	OutcomeSkipped     Outcome = "skipped"
+	OutcomePostponed   Outcome = "postponed"
+	OutcomeInterrupted Outcome = "interrupted"
+)
+
+// Record is one finished break. ScheduledAt is when the break first fell due,
+// which precedes StartedAt when it was postponed. Durations are stored in
+// nanoseconds
+type Record struct {
+	ID          string        `json:"id"`
+	Type        BreakType     `json:"type"`
+	Trigger     string        `json:"trigger,omitempty"`
+	ScheduledAt time.Time     `json:"scheduled_at"`
+	StartedAt   time.Time     `json:"started_at"`
+	Planned     time.Duration `json:"planned"`
+	Actual      time.Duration `json:"actual"`
+	Outcome     Outcome       `json:"outcome"`
+	SkipSource  string        `json:"skip_source,omitempty"`
+	Postpone    time.Duration `json:"postpone,omitempty"`
+	Exercise    string        `json:"exercise,omitempty"`
+	Profile     string        `json:"profile,omitempty"`
+}
+
+// valid reports whether a decoded line carries the fields every record has
+func (record Record) valid() bool {
+	return record.ID != "" && record.Type != "" && record.Outcome != "" && !record.StartedAt.IsZero()
+}
@@ -0,0 +1,143 @@
+package history
+
+import "time"
+
+// Heatmap counts completed breaks per weekday, Monday first, and hour of day
+type Heatmap [7][24]int
+
+// Max returns the largest cell count
+func (heatmap Heatmap) Max() int {
+	largest := 0
+
+	for _, hours := range heatmap {
+		for _, count := range hours {
+			largest = max(largest, count)
+		}
+	}
+
+	return largest
+}
+
+// Overview is what the statistics window shows for one moment: today, the
+// week so far, and where in the day the week's breaks were taken. Screen
+// activity and comfort come from their own files and are left for callers
+// to fill
+type Overview struct {
+	Today          Summary
+	Week           Summary
+	AverageStretch time.Duration
+	Heatmap        Heatmap
+	Comfort        ComfortTrend
+	ActivityToday  ActivityDay
+	ActivityWeek   ActivityDay
+}
+
+// BuildOverview summarizes the records of the week containing now, in now's
+// location. Records outside that week are ignored
+func BuildOverview(records []Record, now time.Time) Overview {
+	today := Day(now)
+	week := Week(now)
+
+	var todays, weeks []Record
+
+	for _, record := range records {
+		if week.Matches(record) {
+			weeks = append(weeks, record)
+		}
+
+		if today.Matches(record) {
+			todays = append(todays, record)
+		}
+	}
+
+	return Overview{
+		Today:          Summarize(todays),
+		Week:           Summarize(weeks),
+		AverageStretch: AverageStretch(weeks, now.Location()),
+		Heatmap:        HourHeatmap(weeks, now.Location()),
+	}
+}
+
+// AverageStretch is the mean work time between the end of one break and the
+// start of the next on the same day. Interrupted breaks are ignored since
+// the app ended them, not the user
+func AverageStretch(records []Record, location *time.Location) time.Duration {
+	var (
+		total    time.Duration
+		count    int
+		previous *Record
+	)
+
+	sorted := append([]Record(nil), records...)
+	sortRecords(sorted)
+
+	for index := range sorted {
+		record := &sorted[index]
+		if record.Outcome == OutcomeInterrupted {
+			continue
+		}
+
+		if previous != nil && sameDay(previous.StartedAt, record.StartedAt, location) {
+			if gap := record.StartedAt.Sub(previous.StartedAt.Add(previous.Actual)); gap > 0 {
+				total += gap
+				count++
+			}
+		}
+
+		previous = record
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return total / time.Duration(count)
+}
+
+// LongestStretch is the longest same-day screen time between the end of one
+// completed break and the start of the next. Skipped and postponed breaks do
+// not end a stretch since the user kept working through them
+func LongestStretch(records []Record, location *time.Location) time.Duration {
+	var (
+		longest  time.Duration
+		previous *Record
+	)
+
+	sorted := append([]Record(nil), records...)
+	sortRecords(sorted)
+
+	for index := range sorted {
+		record := &sorted[index]
+		if record.Outcome != OutcomeCompleted {
+			continue
+		}
+
+		if previous != nil && sameDay(previous.StartedAt, record.StartedAt, location) {
+			longest = max(longest, record.StartedAt.Sub(previous.StartedAt.Add(previous.Actual)))
+		}
+
+		previous = record
+	}
+
+	return longest
+}
+
+// HourHeatmap counts completed breaks by weekday and hour in location
+func HourHeatmap(records []Record, location *time.Location) Heatmap {
+	var heatmap Heatmap
+
+	for _, record := range records {
+		if record.Outcome != OutcomeCompleted {
+			continue
+		}
+
+		local := record.StartedAt.In(location)
+		heatmap[(int(local.Weekday())+6)%7][local.Hour()]++
+	}
+
+	return heatmap
+}
+
+func sameDay(left, right time.Time, location *time.Location) bool {
+	return startOfDay(left.In(location)).Equal(startOfDay(right.In(location)))
+}
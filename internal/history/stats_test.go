@@ -0,0 +1,68 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBuildOverviewSplitsTodayAndWeek verifies the overview windows and heatmap cells
+func TestBuildOverviewSplitsTodayAndWeek(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	records := []Record{
+		testRecord("last-week", time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), OutcomeCompleted),
+		testRecord("monday", time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC), OutcomeCompleted),
+		testRecord("today-1", time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC), OutcomeCompleted),
+		testRecord("today-2", time.Date(2026, 3, 4, 9, 40, 0, 0, time.UTC), OutcomeSkipped),
+	}
+
+	overview := BuildOverview(records, now)
+
+	if overview.Today.Total != 2 || overview.Today.Completed != 1 || overview.Today.Skipped != 1 {
+		t.Fatalf("Today = %+v, want 1 completed and 1 skipped", overview.Today)
+	}
+
+	if overview.Week.Total != 3 {
+		t.Fatalf("Week.Total = %d, want 3", overview.Week.Total)
+	}
+
+	if overview.Heatmap[0][10] != 1 || overview.Heatmap[2][9] != 1 || overview.Heatmap.Max() != 1 {
+		t.Fatalf("Heatmap Monday 10h / Wednesday 9h = %d / %d, want 1 / 1", overview.Heatmap[0][10], overview.Heatmap[2][9])
+	}
+}
+
+// TestAverageStretchStaysWithinDays verifies stretches skip overnight gaps and interruptions
+func TestAverageStretchStaysWithinDays(t *testing.T) {
+	day := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	interrupted := testRecord("c", day.Add(30*time.Minute), OutcomeInterrupted)
+	records := []Record{
+		testRecord("a", day, OutcomeCompleted),
+		testRecord("b", day.Add(20*time.Minute+20*time.Second), OutcomeCompleted),
+		interrupted,
+		testRecord("d", day.Add(60*time.Minute+40*time.Second), OutcomeSkipped),
+		testRecord("e", day.AddDate(0, 0, 1), OutcomeCompleted),
+	}
+
+	if got := AverageStretch(records, time.UTC); got != 30*time.Minute {
+		t.Fatalf("AverageStretch() = %v, want 30m", got)
+	}
+
+	if got := AverageStretch(records[:1], time.UTC); got != 0 {
+		t.Fatalf("AverageStretch(one record) = %v, want 0", got)
+	}
+}
+
+// TestLongestStretchRunsThroughSkips verifies only completed breaks end a stretch
+func TestLongestStretchRunsThroughSkips(t *testing.T) {
+	day := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	records := []Record{
+		testRecord("a", day, OutcomeCompleted),
+		testRecord("b", day.Add(20*time.Minute), OutcomeSkipped),
+		testRecord("c", day.Add(50*time.Minute+20*time.Second), OutcomeCompleted),
+		testRecord("d", day.Add(60*time.Minute+40*time.Second), OutcomeCompleted),
+		testRecord("e", day.AddDate(0, 0, 1).Add(3*time.Hour), OutcomeCompleted),
+	}
+
+	if got := LongestStretch(records, time.UTC); got != 50*time.Minute {
+		t.Fatalf("LongestStretch() = %v, want 50m", got)
+	}
+}
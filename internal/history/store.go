@@ -0,0 +1,345 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"eagleeye/internal/atomicfile"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCompactEvery = 500
+	maxRecordLineSize   = 64 * 1024
+)
+
+// Options controls retention and compaction of a Store
+type Options struct {
+	// Retention drops records that started longer ago; zero keeps everything
+	Retention time.Duration
+	// CompactEvery rewrites the file after this many appends; zero uses a default
+	CompactEvery int
+}
+
+// DamagedError reports store lines that could not be read. It accompanies
+// the records that could
+type DamagedError struct {
+	Lines []int
+}
+
+func (damaged *DamagedError) Error() string {
+	lines := make([]string, 0, len(damaged.Lines))
+	for _, line := range damaged.Lines {
+		lines = append(lines, strconv.Itoa(line))
+	}
+
+	return fmt.Sprintf("break history lines skipped: %s", strings.Join(lines, ", "))
+}
+
+// Store is an append-only break history file. It is safe for concurrent use
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	options  Options
+	appended int
+	now      func() time.Time
+}
+
+// Open prepares the store at path, creating its directory, and compacts the
+// existing file so later appends start on a clean line
+func Open(path string, options Options) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create history directory: %w", err)
+	}
+
+	store := &Store{path: path, options: options, now: time.Now}
+
+	if err := store.Compact(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Path returns the store file path
+func (store *Store) Path() string {
+	return store.path
+}
+
+// SetRetention changes how long records are kept from the next compaction on
+func (store *Store) SetRetention(retention time.Duration) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.options.Retention = retention
+}
+
+// Append writes record as one line and compacts the file every
+// Options.CompactEvery appends
+func (store *Store) Append(record Record) error {
+	if !record.valid() {
+		return fmt.Errorf("append break record: missing id, type, outcome or start time")
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode break record: %w", err)
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if err := appendLine(store.path, line); err != nil {
+		return fmt.Errorf("write break history: %w", err)
+	}
+
+	store.appended++
+
+	compactEvery := store.options.CompactEvery
+	if compactEvery <= 0 {
+		compactEvery = defaultCompactEvery
+	}
+
+	if store.appended >= compactEvery {
+		return store.compactLocked()
+	}
+
+	return nil
+}
+
+// Compact rewrites the file without records past retention, damaged lines,
+// or earlier copies of a record ID. An already compact file is left alone
+func (store *Store) Compact() error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return store.compactLocked()
+}
+
+func (store *Store) compactLocked() error {
+	store.appended = 0
+
+	records, lines, err := readRecords(store.path)
+	if err != nil && !isDamaged(err) {
+		return err
+	}
+
+	var cutoff time.Time
+	if store.options.Retention > 0 {
+		cutoff = store.now().Add(-store.options.Retention)
+	}
+
+	kept := make([]Record, 0, len(records))
+	seen := make(map[string]int, len(records))
+
+	for _, record := range records {
+		if record.StartedAt.Before(cutoff) {
+			continue
+		}
+
+		if index, ok := seen[record.ID]; ok {
+			kept[index] = record
+
+			continue
+		}
+
+		seen[record.ID] = len(kept)
+		kept = append(kept, record)
+	}
+
+	// A last line cut off before its newline is rewritten too, or the next
+	// append would join it
+	if len(kept) == lines && endsWithNewline(store.path) {
+		return nil
+	}
+
+	sortRecords(kept)
+
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	for _, record := range kept {
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("encode break record: %w", err)
+		}
+	}
+
+	if err := atomicfile.Write(store.path, buffer.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("compact break history: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns the records matching query, oldest first. Damaged lines are
+// reported through *DamagedError alongside the readable records
+func (store *Store) Query(query Query) ([]Record, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	return Read(store.path, query)
+}
+
+// Read queries a store file without opening it for writing, so it can run
+// while the app holds the store. A missing file has no records
+func Read(path string, query Query) ([]Record, error) {
+	records, _, err := readRecords(path)
+	if err != nil && !isDamaged(err) {
+		return nil, err
+	}
+
+	matched := make([]Record, 0, len(records))
+
+	for _, record := range records {
+		if query.Matches(record) {
+			matched = append(matched, record)
+		}
+	}
+
+	sortRecords(matched)
+
+	return matched, err
+}
+
+// readRecords decodes every line of the store and counts the non-empty lines
+func readRecords(path string) ([]Record, int, error) {
+	records, lines, err := readLines(path, Record.valid)
+	if err != nil && !isDamaged(err) {
+		return records, lines, fmt.Errorf("read break history: %w", err)
+	}
+
+	return records, lines, err
+}
+
+// appendLine adds one JSON line to path and syncs it, creating the file
+// readable by the user only
+func appendLine(path string, line []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// readLines decodes every line of a JSON lines file and counts the non-empty
+// lines. Lines that fail to decode or fail valid are reported through
+// *DamagedError alongside the rest; a missing file has no lines
+func readLines[T any](path string, valid func(T) bool) ([]T, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	var (
+		items   []T
+		damaged []int
+		lines   int
+		number  int
+	)
+
+	reader := bufio.NewReaderSize(file, maxRecordLineSize)
+
+	for {
+		line, tooLong, readErr := readLine(reader)
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return items, lines, readErr
+		}
+
+		number++
+
+		if text := bytes.TrimSpace(line); len(text) > 0 || tooLong {
+			lines++
+
+			var item T
+			if tooLong || json.Unmarshal(text, &item) != nil || !valid(item) {
+				damaged = append(damaged, number)
+			} else {
+				items = append(items, item)
+			}
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	if len(damaged) > 0 {
+		return items, lines, &DamagedError{Lines: damaged}
+	}
+
+	return items, lines, nil
+}
+
+// readLine returns the next line of reader. A line that does not fit the
+// reader's buffer, such as a block of zeros left by a crash, is skipped to
+// its end and reported as too long
+func readLine(reader *bufio.Reader) ([]byte, bool, error) {
+	line, err := reader.ReadSlice('\n')
+	if !errors.Is(err, bufio.ErrBufferFull) {
+		return line, false, err
+	}
+
+	for errors.Is(err, bufio.ErrBufferFull) {
+		_, err = reader.ReadSlice('\n')
+	}
+
+	return nil, true, err
+}
+
+// endsWithNewline reports whether the file at path is missing, empty or ends
+// with a complete line
+func endsWithNewline(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return true
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return true
+	}
+
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return true
+	}
+
+	return last[0] == '\n'
+}
+
+func isDamaged(err error) bool {
+	var damaged *DamagedError
+
+	return errors.As(err, &damaged)
+}
+
+func sortRecords(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].StartedAt.Before(records[j].StartedAt)
+	})
+}
@@ -0,0 +1,253 @@
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testStart = time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+func testRecord(id string, startedAt time.Time, outcome Outcome) Record {
+	return Record{
+		ID:          id,
+		Type:        BreakShort,
+		Trigger:     "scheduled",
+		ScheduledAt: startedAt,
+		StartedAt:   startedAt,
+		Planned:     20 * time.Second,
+		Actual:      20 * time.Second,
+		Outcome:     outcome,
+	}
+}
+
+// TestStoreAppendAndQuery verifies appended records read back in start order
+func TestStoreAppendAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+
+	store, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	second := testRecord("b2", testStart.Add(time.Hour), OutcomeSkipped)
+	second.SkipSource = "tray"
+	first := testRecord("b1", testStart, OutcomeCompleted)
+
+	for _, record := range []Record{second, first} {
+		if err := store.Append(record); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	records, err := store.Query(Query{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(records, []Record{first, second}) {
+		t.Fatalf("Query() = %+v, want %+v", records, []Record{first, second})
+	}
+
+	skipped, err := store.Query(Query{Outcomes: []Outcome{OutcomeSkipped}})
+	if err != nil {
+		t.Fatalf("Query(skipped) error = %v", err)
+	}
+
+	if len(skipped) != 1 || skipped[0].ID != "b2" {
+		t.Fatalf("Query(skipped) = %+v, want b2 only", skipped)
+	}
+}
+
+// TestReadReportsDamagedLines verifies truncated lines are skipped and reported
+func TestReadReportsDamagedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+	data := `{"id":"b1","type":"short_break","started_at":"2026-03-02T09:00:00Z","outcome":"completed"}
+not json
+{"id":"b2","type":"short_break","started_at":"2026-03-02T10:00:00Z","outc`
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	records, err := Read(path, Query{})
+
+	var damaged *DamagedError
+	if !errors.As(err, &damaged) {
+		t.Fatalf("Read() error = %v, want *DamagedError", err)
+	}
+
+	if !reflect.DeepEqual(damaged.Lines, []int{2, 3}) {
+		t.Fatalf("damaged lines = %v, want [2 3]", damaged.Lines)
+	}
+
+	if len(records) != 1 || records[0].ID != "b1" {
+		t.Fatalf("Read() = %+v, want b1 only", records)
+	}
+}
+
+// TestReadSkipsOverlongLines verifies a line beyond the size limit is
+// reported as damaged without hiding the records around it
+func TestReadSkipsOverlongLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+	data := `{"id":"b1","type":"short_break","started_at":"2026-03-02T09:00:00Z","outcome":"completed"}
+` + strings.Repeat("\x00", 2*maxRecordLineSize) + `
+{"id":"b2","type":"short_break","started_at":"2026-03-02T10:00:00Z","outcome":"completed"}
+`
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	records, err := Read(path, Query{})
+
+	var damaged *DamagedError
+	if !errors.As(err, &damaged) || !reflect.DeepEqual(damaged.Lines, []int{2}) {
+		t.Fatalf("Read() error = %v, want line 2 damaged", err)
+	}
+
+	if len(records) != 2 || records[0].ID != "b1" || records[1].ID != "b2" {
+		t.Fatalf("Read() = %+v, want b1 and b2", records)
+	}
+
+	if _, err := Open(path, Options{}); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	records, err = Read(path, Query{})
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Read() after Open = %+v, %v, want b1 and b2 without damage", records, err)
+	}
+}
+
+// TestOpenEndsAnUnterminatedLastLine verifies a last record without its
+// newline is not joined by the next append
+func TestOpenEndsAnUnterminatedLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+	data := `{"id":"b1","type":"short_break","started_at":"2026-03-02T09:00:00Z","outcome":"completed"}`
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	store, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if err := store.Append(testRecord("b2", time.Now().UTC(), OutcomeCompleted)); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	records, err := Read(path, Query{})
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Read() = %+v, %v, want b1 and b2 without damage", records, err)
+	}
+}
+
+// TestReadMissingFileIsEmpty verifies a store that was never written has no records
+func TestReadMissingFileIsEmpty(t *testing.T) {
+	records, err := Read(filepath.Join(t.TempDir(), "breaks.jsonl"), Query{})
+	if err != nil || len(records) != 0 {
+		t.Fatalf("Read() = %v, %v, want no records and no error", records, err)
+	}
+}
+
+// TestOpenCompactsExpiredDamagedAndDuplicateLines verifies compaction on open
+func TestOpenCompactsExpiredDamagedAndDuplicateLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+	now := time.Now().UTC()
+
+	seed, err := Open(path, Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	interrupted := testRecord("dup", now.Add(-time.Hour), OutcomeInterrupted)
+	completed := testRecord("dup", now.Add(-time.Hour), OutcomeCompleted)
+
+	for _, record := range []Record{
+		testRecord("old", now.AddDate(0, 0, -40), OutcomeCompleted),
+		interrupted,
+		completed,
+	} {
+		if err := seed.Append(record); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+
+	_, _ = file.WriteString(`{"id":"torn"`)
+	_ = file.Close()
+
+	if _, err := Open(path, Options{Retention: 30 * 24 * time.Hour}); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Fatalf("compacted store has %d lines, want 1:\n%s", lines, data)
+	}
+
+	records, err := Read(path, Query{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if len(records) != 1 || records[0].Outcome != OutcomeCompleted {
+		t.Fatalf("Read() = %+v, want the last copy of dup", records)
+	}
+}
+
+// TestAppendCompactsPeriodically verifies CompactEvery triggers retention
+func TestAppendCompactsPeriodically(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breaks.jsonl")
+
+	store, err := Open(path, Options{Retention: time.Hour, CompactEvery: 3})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	store.now = func() time.Time { return testStart.Add(2 * time.Hour) }
+
+	for index, offset := range []time.Duration{0, 30 * time.Minute, 90 * time.Minute} {
+		record := testRecord(string(rune('a'+index)), testStart.Add(offset), OutcomeCompleted)
+
+		if err := store.Append(record); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	records, err := store.Query(Query{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	if len(records) != 1 || records[0].ID != "c" {
+		t.Fatalf("Query() = %+v, want only the record inside retention", records)
+	}
+}
+
+// TestAppendRejectsIncompleteRecord verifies records must carry their identity
+func TestAppendRejectsIncompleteRecord(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "breaks.jsonl"), Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if err := store.Append(Record{ID: "b1"}); err == nil {
+		t.Fatal("Append() error = nil, want error for a record without type and outcome")
+	}
+}
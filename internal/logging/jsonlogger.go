@@ -4,68 +4,51 @@ import (
 	"eagleeye/internal/storage"
 	"log/slog"
 	"os"
-	"path/filepath"
-	"strings"
 )
 
 // NewJSONLogger creates the process logger backed by a JSON slog handler
-func NewJSONLogger(appName string) (*slog.Logger, func()) {
-	level := new(slog.LevelVar)
-	level.Set(LevelFromEnv())
+// writing to a rotating log file. Levels starts at LevelFromEnv and can be
+// changed while the logger is in use
+func NewJSONLogger(appName string) (*slog.Logger, *Levels, func()) {
+	levels := NewLevels(LevelFromEnv())
 
-	handlerOptions := &slog.HandlerOptions{Level: level}
-	fallback := slog.New(slog.NewJSONHandler(os.Stderr, handlerOptions))
+	// Levels does the filtering; debug is the most verbose level it accepts
+	handlerOptions := &slog.HandlerOptions{Level: slog.LevelDebug}
+	fallback := slog.New(newLevelHandler(slog.NewJSONHandler(os.Stderr, handlerOptions), levels))
 
 	logPath, err := storage.ResolveLogPath(appName)
 
 	if err != nil {
 		fallback.Warn("resolve log path", "error", err)
 
-		return fallback, func() {}
+		return fallback, levels, func() {}
 	}
 
-	if err := os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
-		fallback.Warn("create log directory", "error", err)
-
-		return fallback, func() {}
-	}
-
-	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	writer, err := NewRotatingWriter(logPath, DefaultRotateOptions)
 	if err != nil {
 		fallback.Warn("open log file", "error", err)
 
-		return fallback, func() {}
-	}
-
-	if err := file.Chmod(0o600); err != nil {
-		fallback.Warn("secure log file permissions", "error", err)
-
-		if closeErr := file.Close(); closeErr != nil {
-			fallback.Warn("close log file after permission failure", "error", closeErr)
-		}
-
-		return fallback, func() {}
+		return fallback, levels, func() {}
 	}
 
-	logger := slog.New(slog.NewJSONHandler(file, handlerOptions))
+	logger := slog.New(newLevelHandler(slog.NewJSONHandler(writer, handlerOptions), levels))
 
-	return logger, func() {
-		if err := file.Close(); err != nil {
+	return logger, levels, func() {
+		if err := writer.Close(); err != nil {
 			fallback.Warn("close log file", "error", err)
 		}
 	}
 }
 
-// LevelFromEnv parses EAGLEEYE_LOG_LEVEL
+// LevelFromEnv parses EAGLEEYE_LOG_LEVEL, defaulting to info
 func LevelFromEnv() slog.Level {
-	switch strings.ToLower(os.Getenv("EAGLEEYE_LOG_LEVEL")) {
-	case "debug":
-		return slog.LevelDebug
-	case "warn", "warning":
-		return slog.LevelWarn
-	case "error":
-		return slog.LevelError
-	default:
-		return slog.LevelInfo
-	}
+	level, _ := EnvLevel()
+
+	return level
+}
+
+// EnvLevel parses EAGLEEYE_LOG_LEVEL and reports whether it names a level.
+// A set variable wins over the log_level setting the process starts with
+func EnvLevel() (slog.Level, bool) {
+	return ParseLevel(os.Getenv("EAGLEEYE_LOG_LEVEL"))
 }
@@ -0,0 +1,170 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// SubsystemKey is the logger attribute that selects a per-subsystem level
+const SubsystemKey = "subsystem"
+
+// Subsystems with their own log level
+const (
+	SubsystemTimekeeper = "timekeeper"
+	SubsystemOverlay    = "overlay"
+	SubsystemPlatform   = "platform"
+)
+
+// ForSubsystem returns logger tagged with subsystem, so its records are
+// filtered by that subsystem's level
+func ForSubsystem(logger *slog.Logger, subsystem string) *slog.Logger {
+	return logger.With(SubsystemKey, subsystem)
+}
+
+// ParseLevel reads a level name: debug, info, warn or warning, or error
+func ParseLevel(name string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return slog.LevelInfo, false
+	}
+}
+
+// Levels holds the default log level and per-subsystem levels of a logger
+// made by NewJSONLogger. A subsystem without its own level uses the default.
+// It is safe for concurrent use
+type Levels struct {
+	mu         sync.RWMutex
+	base       slog.Level
+	subsystems map[string]slog.Level
+	minimum    slog.Level
+}
+
+// NewLevels starts with base for every subsystem
+func NewLevels(base slog.Level) *Levels {
+	return &Levels{base: base, minimum: base}
+}
+
+// Set replaces the default level and every subsystem level
+func (levels *Levels) Set(base slog.Level, subsystems map[string]slog.Level) {
+	copied := make(map[string]slog.Level, len(subsystems))
+	minimum := base
+
+	for subsystem, level := range subsystems {
+		copied[subsystem] = level
+		minimum = min(minimum, level)
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	levels.base = base
+	levels.subsystems = copied
+	levels.minimum = minimum
+}
+
+// Level is the level records of subsystem must reach; "" is the default
+func (levels *Levels) Level(subsystem string) slog.Level {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	if level, ok := levels.subsystems[subsystem]; ok {
+		return level
+	}
+
+	return levels.base
+}
+
+// lowest is the most verbose level any subsystem logs at
+func (levels *Levels) lowest() slog.Level {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	return levels.minimum
+}
+
+// levelHandler filters records by the level of the subsystem named in a
+// SubsystemKey attribute, given to With or to the log call itself.
+// Subsystem attributes inside groups are not looked at
+type levelHandler struct {
+	handler   slog.Handler
+	levels    *Levels
+	subsystem string
+	grouped   bool
+}
+
+func newLevelHandler(handler slog.Handler, levels *Levels) *levelHandler {
+	return &levelHandler{handler: handler, levels: levels}
+}
+
+// Enabled lets records through that some subsystem might log until Handle
+// knows which subsystem a record belongs to
+func (handler *levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if handler.subsystem != "" {
+		return level >= handler.levels.Level(handler.subsystem)
+	}
+
+	return level >= handler.levels.lowest()
+}
+
+// Handle drops records below their subsystem's level
+func (handler *levelHandler) Handle(ctx context.Context, record slog.Record) error {
+	subsystem := handler.subsystem
+
+	if subsystem == "" && !handler.grouped {
+		record.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == SubsystemKey {
+				subsystem = attr.Value.String()
+
+				return false
+			}
+
+			return true
+		})
+	}
+
+	if record.Level < handler.levels.Level(subsystem) {
+		return nil
+	}
+
+	return handler.handler.Handle(ctx, record)
+}
+
+// WithAttrs remembers a subsystem attribute for later records
+func (handler *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	subsystem := handler.subsystem
+
+	if !handler.grouped {
+		for _, attr := range attrs {
+			if attr.Key == SubsystemKey {
+				subsystem = attr.Value.String()
+			}
+		}
+	}
+
+	return &levelHandler{
+		handler:   handler.handler.WithAttrs(attrs),
+		levels:    handler.levels,
+		subsystem: subsystem,
+		grouped:   handler.grouped,
+	}
+}
+
+// WithGroup keeps the subsystem chosen so far
+func (handler *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{
+		handler:   handler.handler.WithGroup(name),
+		levels:    handler.levels,
+		subsystem: handler.subsystem,
+		grouped:   handler.grouped || name != "",
+	}
+}
@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestLevelHandlerFiltersBySubsystem verifies subsystem levels apply to
+// loggers tagged with ForSubsystem and can change while logging
+func TestLevelHandlerFiltersBySubsystem(t *testing.T) {
+	var output bytes.Buffer
+
+	levels := NewLevels(slog.LevelInfo)
+	logger := slog.New(newLevelHandler(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}), levels))
+	overlay := ForSubsystem(logger, SubsystemOverlay)
+	timekeeper := ForSubsystem(logger, SubsystemTimekeeper)
+
+	overlay.Debug("overlay_hidden")
+	logger.Debug("app_hidden")
+
+	levels.Set(slog.LevelInfo, map[string]slog.Level{SubsystemOverlay: slog.LevelDebug})
+
+	overlay.Debug("overlay_shown")
+	timekeeper.Debug("timekeeper_hidden")
+	logger.Debug("app_hidden_too")
+	logger.Debug("tagged_shown", SubsystemKey, SubsystemOverlay)
+
+	levels.Set(slog.LevelError, nil)
+
+	overlay.Info("overlay_hidden_again")
+	logger.Error("app_error_shown")
+
+	got := output.String()
+
+	for _, message := range []string{"overlay_shown", "tagged_shown", "app_error_shown"} {
+		if !strings.Contains(got, `"msg":"`+message+`"`) {
+			t.Fatalf("log = %s, want %s", got, message)
+		}
+	}
+
+	if strings.Contains(got, "hidden") {
+		t.Fatalf("log = %s, want no records below their subsystem level", got)
+	}
+}
+
+// TestParseLevel verifies level names are read case-insensitively
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  slog.Level
+		valid bool
+	}{
+		{name: "DEBUG", want: slog.LevelDebug, valid: true},
+		{name: " warning ", want: slog.LevelWarn, valid: true},
+		{name: "error", want: slog.LevelError, valid: true},
+		{name: "verbose", want: slog.LevelInfo, valid: false},
+	}
+
+	for _, tt := range tests {
+		got, valid := ParseLevel(tt.name)
+		if got != tt.want || valid != tt.valid {
+			t.Fatalf("ParseLevel(%q) = %v, %v, want %v, %v", tt.name, got, valid, tt.want, tt.valid)
+		}
+	}
+}
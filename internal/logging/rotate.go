@@ -0,0 +1,411 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// backupTimeLayout stamps rotated files in UTC. It has a fixed width so
+	// names sort in rotation order
+	backupTimeLayout = "20060102T150405.000"
+	compressedSuffix = ".gz"
+	temporarySuffix  = ".tmp"
+	logFileMode      = 0o600
+)
+
+// RotateOptions limits how much log history a RotatingWriter keeps. Zero
+// values disable the matching limit
+type RotateOptions struct {
+	// MaxSize is the size in bytes after which the active file is rotated
+	MaxSize int64
+	// MaxFiles is how many rotated files are kept
+	MaxFiles int
+	// MaxAge is how long rotated files are kept
+	MaxAge time.Duration
+	// Compress gzips rotated files
+	Compress bool
+}
+
+// DefaultRotateOptions keep about 25 MiB of logs from the last 30 days
+var DefaultRotateOptions = RotateOptions{
+	MaxSize:  5 << 20,
+	MaxFiles: 5,
+	MaxAge:   30 * 24 * time.Hour,
+	Compress: true,
+}
+
+// RotatingWriter appends to a log file and moves it aside once it grows past
+// MaxSize, as name-20260302T091500.000.jsonl next to it. The active and
+// rotated files are readable only by the owner. Rotated files are compressed
+// in the background so writes never wait for it. It is safe for concurrent use
+type RotatingWriter struct {
+	mu           sync.Mutex
+	path         string
+	options      RotateOptions
+	file         *os.File
+	size         int64
+	lastBackup   time.Time
+	now          func() time.Time
+	compressing  bool
+	compressions sync.WaitGroup
+}
+
+// NewRotatingWriter opens path for appending, creating it and its directory
+// when missing, and prunes rotated files that exceed options
+func NewRotatingWriter(path string, options RotateOptions) (*RotatingWriter, error) {
+	writer := &RotatingWriter{path: path, options: options, now: time.Now}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+
+	if err := writer.openLocked(); err != nil {
+		return nil, err
+	}
+
+	// Leftovers from a crash mid-rotation are finished now; a failure here
+	// leaves extra files behind but the log itself is usable
+	writer.mu.Lock()
+	_ = writer.cleanupLocked()
+	writer.compressLocked()
+	writer.mu.Unlock()
+
+	return writer, nil
+}
+
+// Write appends p, rotating first when p would push the file past MaxSize.
+// A single write larger than MaxSize still goes into one file
+func (writer *RotatingWriter) Write(p []byte) (int, error) {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	if writer.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if writer.options.MaxSize > 0 && writer.size > 0 && writer.size+int64(len(p)) > writer.options.MaxSize {
+		// Pruning errors cannot be logged from inside the logger, and the
+		// next rotation retries them
+		if err := writer.rotateLocked(); err != nil && writer.file == nil {
+			return 0, err
+		}
+	}
+
+	written, err := writer.file.Write(p)
+	writer.size += int64(written)
+
+	return written, err
+}
+
+// Rotate moves the current file aside and starts a new one
+func (writer *RotatingWriter) Rotate() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	if writer.file == nil {
+		return os.ErrClosed
+	}
+
+	return writer.rotateLocked()
+}
+
+// Close closes the active file and waits for background compression to
+// finish. Later writes fail with os.ErrClosed
+func (writer *RotatingWriter) Close() error {
+	writer.mu.Lock()
+
+	var err error
+	if writer.file != nil {
+		err = writer.file.Close()
+		writer.file = nil
+	}
+
+	writer.mu.Unlock()
+	writer.compressions.Wait()
+
+	return err
+}
+
+// openLocked opens the active file and tightens permissions on a file
+// created by an older version
+func (writer *RotatingWriter) openLocked() error {
+	file, err := os.OpenFile(writer.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, logFileMode)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+
+	if err := file.Chmod(logFileMode); err != nil {
+		file.Close()
+
+		return fmt.Errorf("secure log file permissions: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("stat log file: %w", err)
+	}
+
+	writer.file = file
+	writer.size = info.Size()
+
+	return nil
+}
+
+// rotateLocked renames the active file to a backup, reopens the log and
+// prunes backups. On failure to reopen writer.file is nil
+func (writer *RotatingWriter) rotateLocked() error {
+	closeErr := writer.file.Close()
+	writer.file = nil
+
+	if closeErr != nil {
+		closeErr = fmt.Errorf("close log file: %w", closeErr)
+	}
+
+	if err := os.Rename(writer.path, writer.backupPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		// Keep logging to the old file rather than losing lines
+		if openErr := writer.openLocked(); openErr != nil {
+			return openErr
+		}
+
+		return errors.Join(closeErr, fmt.Errorf("rotate log file: %w", err))
+	}
+
+	if err := writer.openLocked(); err != nil {
+		return err
+	}
+
+	err := writer.cleanupLocked()
+	writer.compressLocked()
+
+	return errors.Join(closeErr, err)
+}
+
+// backupPath names a rotated file after the current time. Stamps only move
+// forward, past names already taken, so rotations within one millisecond
+// keep their order
+func (writer *RotatingWriter) backupPath() string {
+	prefix, ext := writer.backupPattern()
+
+	stamp := writer.now().UTC().Truncate(time.Millisecond)
+	if !stamp.After(writer.lastBackup) {
+		stamp = writer.lastBackup.Add(time.Millisecond)
+	}
+
+	for {
+		path := prefix + stamp.Format(backupTimeLayout) + ext
+		if !fileExists(path) && !fileExists(path+compressedSuffix) {
+			writer.lastBackup = stamp
+
+			return path
+		}
+
+		stamp = stamp.Add(time.Millisecond)
+	}
+}
+
+// backupPattern splits the backup name of path around its timestamp
+func (writer *RotatingWriter) backupPattern() (prefix, ext string) {
+	ext = filepath.Ext(writer.path)
+
+	return strings.TrimSuffix(writer.path, ext) + "-", ext
+}
+
+// logBackup is one rotated file
+type logBackup struct {
+	path    string
+	rotated time.Time
+}
+
+// backupsLocked lists rotated files, newest first
+func (writer *RotatingWriter) backupsLocked() ([]logBackup, error) {
+	prefix, ext := writer.backupPattern()
+
+	entries, err := os.ReadDir(filepath.Dir(writer.path))
+	if err != nil {
+		return nil, fmt.Errorf("list log files: %w", err)
+	}
+
+	base := filepath.Base(prefix)
+	backups := make([]logBackup, 0, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, base) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(name, base), compressedSuffix), ext)
+
+		rotated, err := time.Parse(backupTimeLayout, stamp)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, logBackup{path: filepath.Join(filepath.Dir(writer.path), name), rotated: rotated})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotated.After(backups[j].rotated)
+	})
+
+	return backups, nil
+}
+
+// cleanupLocked removes backups past MaxFiles or MaxAge, and compressed
+// files a crash left half written
+func (writer *RotatingWriter) cleanupLocked() error {
+	backups, err := writer.backupsLocked()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	if !writer.compressing {
+		prefix, ext := writer.backupPattern()
+
+		stale, _ := filepath.Glob(prefix + "*" + ext + compressedSuffix + temporarySuffix)
+		for _, path := range stale {
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("remove partial log file: %w", err))
+			}
+		}
+	}
+
+	for index, backup := range backups {
+		expired := writer.options.MaxAge > 0 && writer.now().Sub(backup.rotated) > writer.options.MaxAge
+		if expired || (writer.options.MaxFiles > 0 && index >= writer.options.MaxFiles) {
+			if err := os.Remove(backup.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("remove old log file: %w", err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// compressLocked starts compressing rotated files when asked to. One
+// compression runs at a time and picks up files rotated meanwhile
+func (writer *RotatingWriter) compressLocked() {
+	if !writer.options.Compress || writer.compressing {
+		return
+	}
+
+	writer.compressing = true
+	writer.compressions.Add(1)
+
+	go writer.compressBackups()
+}
+
+// compressBackups gzips uncompressed backups without holding the writer
+// lock. Files that fail are left for the next rotation to retry, since
+// errors cannot be logged from inside the logger
+func (writer *RotatingWriter) compressBackups() {
+	defer writer.compressions.Done()
+
+	tried := map[string]bool{}
+
+	for {
+		writer.mu.Lock()
+		pending := writer.uncompressedLocked(tried)
+
+		if len(pending) == 0 {
+			writer.compressing = false
+			writer.mu.Unlock()
+
+			return
+		}
+
+		writer.mu.Unlock()
+
+		for _, path := range pending {
+			tried[path] = true
+			_ = compressFile(path)
+		}
+	}
+}
+
+// uncompressedLocked lists backups that still need compressing, leaving out
+// those in skip
+func (writer *RotatingWriter) uncompressedLocked(skip map[string]bool) []string {
+	backups, err := writer.backupsLocked()
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup.path, compressedSuffix) && !skip[backup.path] {
+			paths = append(paths, backup.path)
+		}
+	}
+
+	return paths
+}
+
+// compressFile replaces path with path.gz. The compressed file is written
+// under a temporary name first so a crash never leaves a partial .gz
+func compressFile(path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open log file to compress: %w", err)
+	}
+	defer source.Close()
+
+	target := path + compressedSuffix
+	temporary := target + temporarySuffix
+
+	output, err := os.OpenFile(temporary, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, logFileMode)
+	if err != nil {
+		return fmt.Errorf("create compressed log file: %w", err)
+	}
+
+	compressor := gzip.NewWriter(output)
+	_, err = io.Copy(compressor, source)
+
+	if err == nil {
+		err = compressor.Close()
+	}
+
+	if err == nil {
+		err = output.Sync()
+	}
+
+	if closeErr := output.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(temporary, target)
+	}
+
+	if err != nil {
+		os.Remove(temporary)
+
+		return fmt.Errorf("compress log file: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove compressed log file: %w", err)
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+
+	return err == nil
+}
@@ -0,0 +1,258 @@
+package logging
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestWriter(t *testing.T, options RotateOptions, now *time.Time) (*RotatingWriter, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "logs", "EagleEye.log.jsonl")
+
+	writer, err := NewRotatingWriter(path, options)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+
+	writer.now = func() time.Time { return *now }
+
+	t.Cleanup(func() { writer.Close() })
+
+	return writer, path
+}
+
+func writeLine(t *testing.T, writer *RotatingWriter, line string) {
+	t.Helper()
+
+	if _, err := writer.Write([]byte(line + "\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+}
+
+// backupNames lists rotated files next to path, oldest first
+func backupNames(t *testing.T, path string) []string {
+	t.Helper()
+
+	matches, err := filepath.Glob(strings.TrimSuffix(path, ".jsonl") + "-*")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+
+	sort.Strings(matches)
+
+	return matches
+}
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			t.Fatalf("gzip.NewReader() error = %v", err)
+		}
+
+		reader = gzipReader
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	return string(data)
+}
+
+// TestRotatingWriterRotatesAtMaxSize verifies lines move to a backup instead of being lost
+func TestRotatingWriterRotatesAtMaxSize(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 15, 0, 0, time.UTC)
+	writer, path := newTestWriter(t, RotateOptions{MaxSize: 20}, &now)
+
+	writeLine(t, writer, "first line")
+	writeLine(t, writer, "second line")
+
+	backups := backupNames(t, path)
+	if len(backups) != 1 || filepath.Base(backups[0]) != "EagleEye.log-20260302T091500.000.jsonl" {
+		t.Fatalf("backups = %q, want one stamped backup", backups)
+	}
+
+	if got := readLog(t, backups[0]); got != "first line\n" {
+		t.Fatalf("backup = %q, want first line", got)
+	}
+
+	if got := readLog(t, path); got != "second line\n" {
+		t.Fatalf("active log = %q, want second line", got)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	for _, file := range append(backups, path) {
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("%s mode = %o, want 0600", filepath.Base(file), info.Mode().Perm())
+		}
+	}
+}
+
+// TestRotatingWriterKeepsMaxFiles verifies the oldest backups are removed
+func TestRotatingWriterKeepsMaxFiles(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	writer, path := newTestWriter(t, RotateOptions{MaxFiles: 2}, &now)
+
+	for _, line := range []string{"one", "two", "three", "four"} {
+		writeLine(t, writer, line)
+
+		if err := writer.Rotate(); err != nil {
+			t.Fatalf("Rotate() error = %v", err)
+		}
+	}
+
+	backups := backupNames(t, path)
+	if len(backups) != 2 {
+		t.Fatalf("backups = %q, want 2", backups)
+	}
+
+	if readLog(t, backups[0]) != "three\n" || readLog(t, backups[1]) != "four\n" {
+		t.Fatalf("backups hold %q and %q, want three and four", readLog(t, backups[0]), readLog(t, backups[1]))
+	}
+}
+
+// TestRotatingWriterRemovesExpiredBackups verifies MaxAge against the rotation time
+func TestRotatingWriterRemovesExpiredBackups(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	writer, path := newTestWriter(t, RotateOptions{MaxAge: 24 * time.Hour}, &now)
+
+	writeLine(t, writer, "old")
+
+	if err := writer.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	now = now.Add(36 * time.Hour)
+	writeLine(t, writer, "new")
+
+	if err := writer.Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	backups := backupNames(t, path)
+	if len(backups) != 1 || readLog(t, backups[0]) != "new\n" {
+		t.Fatalf("backups = %q, want only the recent one", backups)
+	}
+}
+
+// TestRotatingWriterCompressesBackups verifies rotated files are gzipped in place
+func TestRotatingWriterCompressesBackups(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	writer, path := newTestWriter(t, RotateOptions{MaxSize: 10, Compress: true}, &now)
+
+	writeLine(t, writer, "compressed")
+	writeLine(t, writer, "active")
+
+	// Close waits for the background compression
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	backups := backupNames(t, path)
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".jsonl.gz") {
+		t.Fatalf("backups = %q, want one .jsonl.gz", backups)
+	}
+
+	if got := readLog(t, backups[0]); got != "compressed\n" {
+		t.Fatalf("compressed backup = %q, want compressed line", got)
+	}
+}
+
+// TestRotatingWriterRemovesPartialCompression verifies a .gz.tmp left by a
+// crash is removed and its backup compressed again
+func TestRotatingWriterRemovesPartialCompression(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	path := filepath.Join(dir, "EagleEye.log.jsonl")
+	backup := filepath.Join(dir, "EagleEye.log-20260302T090000.000.jsonl")
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	for name, data := range map[string]string{backup: "kept\n", backup + ".gz.tmp": "partial"} {
+		if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	writer, err := NewRotatingWriter(path, RotateOptions{Compress: true})
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	backups := backupNames(t, path)
+	if len(backups) != 1 || backups[0] != backup+".gz" || readLog(t, backups[0]) != "kept\n" {
+		t.Fatalf("backups = %q, want only the compressed backup", backups)
+	}
+}
+
+// TestRotatingWriterSecuresExistingFile verifies an old world-readable log is tightened
+func TestRotatingWriterSecuresExistingFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not enforced on Windows")
+	}
+
+	path := filepath.Join(t.TempDir(), "EagleEye.log.jsonl")
+	if err := os.WriteFile(path, []byte("kept\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	writer, err := NewRotatingWriter(path, DefaultRotateOptions)
+	if err != nil {
+		t.Fatalf("NewRotatingWriter() error = %v", err)
+	}
+
+	writeLine(t, writer, "appended")
+
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Fatalf("mode = %o, want 0600", info.Mode().Perm())
+	}
+
+	if got := readLog(t, path); got != "kept\nappended\n" {
+		t.Fatalf("log = %q, want the old line kept", got)
+	}
+
+	if _, err := writer.Write([]byte("late\n")); err == nil {
+		t.Fatalf("Write() after Close error = nil, want os.ErrClosed")
+	}
+}
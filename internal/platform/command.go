@@ -0,0 +1,181 @@
+package platform
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	commandMessagePrefix  = "EAGLEEYE_COMMAND_V1"
+	maxCommandMessageSize = 1024
+	commandReplyTimeout   = 2 * time.Second
+	commandReplyOK        = "ok"
+	commandReplyError     = "error"
+)
+
+// ErrNotRunning means no instance is listening for commands
+var ErrNotRunning = errors.New("instance not running")
+
+// CommandHandler runs one command sent with SendCommand and returns the text
+// to reply with
+type CommandHandler func(command string) (string, error)
+
+// HandleCommands makes the activation listener run signed commands with
+// handler. Commands are refused until a handler is set
+func (guard *InstanceGuard) HandleCommands(handler CommandHandler) {
+	if guard == nil {
+		return
+	}
+
+	guard.commandMu.Lock()
+	defer guard.commandMu.Unlock()
+
+	guard.commandHandler = handler
+}
+
+// runCommand verifies a signed command, runs it and writes the reply: a
+// status line followed by the handler's text
+func (guard *InstanceGuard) runCommand(conn net.Conn, message []byte) {
+	guard.commandMu.Lock()
+	handler := guard.commandHandler
+	guard.commandMu.Unlock()
+
+	command, ok := parseCommandMessage(message, guard.activationSecret)
+	if !ok || handler == nil {
+		_ = writeCommandReply(conn, commandReplyError, "command refused")
+
+		return
+	}
+
+	reply, err := handler(command)
+	if err != nil {
+		_ = writeCommandReply(conn, commandReplyError, err.Error())
+
+		return
+	}
+
+	_ = writeCommandReply(conn, commandReplyOK, reply)
+}
+
+func writeCommandReply(conn net.Conn, status, text string) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(commandReplyTimeout)); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(conn, "%s\n%s", status, text)
+
+	return err
+}
+
+// SendCommand signs command, sends it to the running instance and returns
+// its reply. It fails with ErrNotRunning when no instance is listening
+func SendCommand(appName, command string) (string, error) {
+	address := fmt.Sprintf("127.0.0.1:%d", portFromName(appName))
+
+	conn, err := net.DialTimeout("tcp", address, 800*time.Millisecond)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNotRunning, err)
+	}
+
+	defer conn.Close()
+
+	secret, err := readActivationSecret(appName)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := buildCommandMessage(secret, command)
+	if err != nil {
+		return "", err
+	}
+
+	if len(message) > maxCommandMessageSize {
+		return "", fmt.Errorf("command exceeds %d bytes", maxCommandMessageSize)
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(commandReplyTimeout)); err != nil {
+		return "", err
+	}
+
+	if _, err := conn.Write(message); err != nil {
+		return "", fmt.Errorf("send command: %w", err)
+	}
+
+	// The listener reads until the end of the message, so the write side is
+	// closed before waiting for the reply
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		if err := tcpConn.CloseWrite(); err != nil {
+			return "", fmt.Errorf("send command: %w", err)
+		}
+	}
+
+	reader := bufio.NewReader(io.LimitReader(conn, maxCommandMessageSize))
+
+	status, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("read command reply: %w", err)
+	}
+
+	text, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("read command reply: %w", err)
+	}
+
+	if strings.TrimSpace(status) != commandReplyOK {
+		return "", errors.New(string(text))
+	}
+
+	return string(text), nil
+}
+
+// buildCommandMessage signs command with a fresh nonce
+func buildCommandMessage(secret []byte, command string) ([]byte, error) {
+	nonce := make([]byte, 16)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate command nonce: %w", err)
+	}
+
+	nonceHex := hex.EncodeToString(nonce)
+	commandHex := hex.EncodeToString([]byte(command))
+	mac := signFields(secret, commandMessagePrefix, nonceHex, commandHex)
+	message := fmt.Sprintf("%s:%s:%s:%s\n", commandMessagePrefix, nonceHex, commandHex, hex.EncodeToString(mac))
+
+	return []byte(message), nil
+}
+
+// parseCommandMessage verifies a signed command and returns its text
+func parseCommandMessage(message, secret []byte) (string, bool) {
+	if len(message) > maxCommandMessageSize {
+		return "", false
+	}
+
+	parts := strings.Split(strings.TrimSpace(string(message)), ":")
+	if len(parts) != 4 || parts[0] != commandMessagePrefix {
+		return "", false
+	}
+
+	command, err := hex.DecodeString(parts[2])
+	if err != nil {
+		return "", false
+	}
+
+	providedMAC, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return "", false
+	}
+
+	if !hmac.Equal(providedMAC, signFields(secret, parts[0], parts[1], parts[2])) {
+		return "", false
+	}
+
+	return string(command), true
+}
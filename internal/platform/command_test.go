@@ -0,0 +1,63 @@
+package platform
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSendCommandReachesHandler verifies signed commands are answered and
+// refused until a handler is set
+func TestSendCommandReachesHandler(t *testing.T) {
+	setPlatformUserConfigEnv(t, t.TempDir())
+
+	appName := "EagleEyeCommandTest" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	guard, err := AcquireSingleInstance(appName)
+
+	if err != nil {
+		t.Fatalf("AcquireSingleInstance() error = %v", err)
+	}
+
+	defer func() {
+		_ = guard.Release()
+	}()
+
+	guard.ListenForActivation(context.Background(), func() {
+		t.Errorf("command triggered activation")
+	})
+
+	if _, err := SendCommand(appName, "log-level"); err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Fatalf("SendCommand() without handler error = %v, want refused", err)
+	}
+
+	guard.HandleCommands(func(command string) (string, error) {
+		if command == "fail" {
+			return "", errors.New("no such level")
+		}
+
+		return "ran " + command, nil
+	})
+
+	reply, err := SendCommand(appName, "log-level debug overlay")
+	if err != nil || reply != "ran log-level debug overlay" {
+		t.Fatalf("SendCommand() = %q, %v, want the handler reply", reply, err)
+	}
+
+	if _, err := SendCommand(appName, "fail"); err == nil || err.Error() != "no such level" {
+		t.Fatalf("SendCommand(fail) error = %v, want the handler error", err)
+	}
+}
+
+// TestSendCommandWithoutInstance verifies ErrNotRunning when nothing listens
+func TestSendCommandWithoutInstance(t *testing.T) {
+	setPlatformUserConfigEnv(t, t.TempDir())
+
+	appName := "EagleEyeNoInstanceTest" + strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	if _, err := SendCommand(appName, "log-level"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("SendCommand() error = %v, want ErrNotRunning", err)
+	}
+}
@@ -0,0 +1,71 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	portableMarkerSuffix = ".portable"
+	portableDataSuffix   = "Data"
+)
+
+// Layout places application files by role. ConfigDir holds settings, StateDir
+// holds logs and history, and RuntimeDir holds per-session files such as the
+// activation secret. Portable layouts keep all three beside the executable
+type Layout struct {
+	ConfigDir  string
+	StateDir   string
+	RuntimeDir string
+	Portable   bool
+}
+
+// ResolveLayout returns the portable layout when an <appName>.portable marker
+// sits next to the executable, and the per-user OS layout otherwise
+func ResolveLayout(appName string) (Layout, error) {
+	if exeDir, ok := executableDir(); ok {
+		if layout, ok := portableLayout(exeDir, appName); ok {
+			return layout, nil
+		}
+	}
+
+	return userLayout(appName)
+}
+
+// LegacyAppDir is where releases before the layout split kept every file
+func LegacyAppDir(appName string) (string, error) {
+	configDir, err := os.UserConfigDir()
+
+	if err != nil {
+		return "", fmt.Errorf("resolve user config dir: %w", err)
+	}
+
+	return filepath.Join(configDir, appName), nil
+}
+
+// portableLayout keeps everything in <appName>Data beside the marker file
+func portableLayout(exeDir, appName string) (Layout, bool) {
+	if _, err := os.Stat(filepath.Join(exeDir, appName+portableMarkerSuffix)); err != nil {
+		return Layout{}, false
+	}
+
+	dataDir := filepath.Join(exeDir, appName+portableDataSuffix)
+
+	return Layout{ConfigDir: dataDir, StateDir: dataDir, RuntimeDir: dataDir, Portable: true}, true
+}
+
+// executableDir returns the directory of the running binary with symlinks
+// resolved, so a linked launcher still finds the real portable folder
+func executableDir() (string, bool) {
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", false
+	}
+
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+
+	return filepath.Dir(exePath), true
+}
@@ -0,0 +1,14 @@
+//go:build windows || darwin
+
+package platform
+
+// userLayout keeps every file in the per-user application data directory,
+// which Windows and macOS already reserve for the app
+func userLayout(appName string) (Layout, error) {
+	appDir, err := LegacyAppDir(appName)
+	if err != nil {
+		return Layout{}, err
+	}
+
+	return Layout{ConfigDir: appDir, StateDir: appDir, RuntimeDir: appDir}, nil
+}
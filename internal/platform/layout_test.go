@@ -0,0 +1,31 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPortableLayoutRequiresMarker(t *testing.T) {
+	exeDir := t.TempDir()
+
+	if _, ok := portableLayout(exeDir, "EagleEye"); ok {
+		t.Fatalf("portableLayout() without marker = portable, want user layout")
+	}
+
+	if err := os.WriteFile(filepath.Join(exeDir, "EagleEye.portable"), nil, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	layout, ok := portableLayout(exeDir, "EagleEye")
+	if !ok {
+		t.Fatalf("portableLayout() with marker = not portable")
+	}
+
+	dataDir := filepath.Join(exeDir, "EagleEyeData")
+	want := Layout{ConfigDir: dataDir, StateDir: dataDir, RuntimeDir: dataDir, Portable: true}
+
+	if layout != want {
+		t.Fatalf("portableLayout() = %+v, want %+v", layout, want)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build !windows && !darwin
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// userLayout follows the XDG base directory spec: config in
+// $XDG_CONFIG_HOME, logs and history in $XDG_STATE_HOME and runtime files in
+// $XDG_RUNTIME_DIR, falling back to the state directory without one
+func userLayout(appName string) (Layout, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return Layout{}, fmt.Errorf("resolve user config dir: %w", err)
+	}
+
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if !filepath.IsAbs(stateHome) {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return Layout{}, fmt.Errorf("resolve user state dir: %w", err)
+		}
+
+		stateHome = filepath.Join(homeDir, ".local", "state")
+	}
+
+	layout := Layout{
+		ConfigDir: filepath.Join(configDir, appName),
+		StateDir:  filepath.Join(stateHome, appName),
+	}
+
+	layout.RuntimeDir = layout.StateDir
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); filepath.IsAbs(runtimeDir) {
+		layout.RuntimeDir = filepath.Join(runtimeDir, appName)
+	}
+
+	return layout, nil
+}
@@ -0,0 +1,48 @@
+//go:build !windows && !darwin
+
+package platform
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestUserLayoutFollowsXDGDirectories(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(root, "state"))
+	t.Setenv("XDG_RUNTIME_DIR", filepath.Join(root, "run"))
+
+	layout, err := userLayout("EagleEye")
+	if err != nil {
+		t.Fatalf("userLayout() error = %v", err)
+	}
+
+	want := Layout{
+		ConfigDir:  filepath.Join(root, "config", "EagleEye"),
+		StateDir:   filepath.Join(root, "state", "EagleEye"),
+		RuntimeDir: filepath.Join(root, "run", "EagleEye"),
+	}
+
+	if layout != want {
+		t.Fatalf("userLayout() = %+v, want %+v", layout, want)
+	}
+}
+
+func TestUserLayoutFallsBackWithoutXDGVariables(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "relative/state")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+
+	layout, err := userLayout("EagleEye")
+	if err != nil {
+		t.Fatalf("userLayout() error = %v", err)
+	}
+
+	wantState := filepath.Join(home, ".local", "state", "EagleEye")
+	if layout.StateDir != wantState || layout.RuntimeDir != wantState {
+		t.Fatalf("userLayout() = %+v, want state and runtime in %s", layout, wantState)
+	}
+}
@@ -0,0 +1,21 @@
+package platform
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSessionMonitorUnsupported indicates lock/session tracking is unavailable
+var ErrSessionMonitorUnsupported = errors.New("session monitor unsupported")
+
+// SessionMonitor watches screen locks and session switches. onChange receives
+// true when the desktop becomes locked or inactive and false when it is usable
+// again; it is only called on transitions
+type SessionMonitor interface {
+	Start(ctx context.Context, onChange func(locked bool)) error
+}
+
+// NewSessionMonitor returns a platform-specific session monitor
+func NewSessionMonitor() SessionMonitor {
+	return newSessionMonitor()
+}
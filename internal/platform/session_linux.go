@@ -0,0 +1,247 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	logindBusName          = "org.freedesktop.login1"
+	logindManagerPath      = dbus.ObjectPath("/org/freedesktop/login1")
+	logindManagerInterface = "org.freedesktop.login1.Manager"
+	logindSessionInterface = "org.freedesktop.login1.Session"
+	logindAutoSessionPath  = dbus.ObjectPath("/org/freedesktop/login1/session/auto")
+	propertiesInterface    = "org.freedesktop.DBus.Properties"
+	screenSaverBusName     = "org.freedesktop.ScreenSaver"
+	screenSaverPath        = dbus.ObjectPath("/org/freedesktop/ScreenSaver")
+	screenSaverInterface   = "org.freedesktop.ScreenSaver"
+)
+
+// dbusSessionMonitor combines logind LockedHint/Active with the desktop
+// ScreenSaver ActiveChanged signal. Either bus may be missing; the monitor
+// only fails when neither source is available
+type dbusSessionMonitor struct {
+	connectSystem  func() (*dbus.Conn, error)
+	connectSession func() (*dbus.Conn, error)
+	sessionID      string
+
+	mu                sync.Mutex
+	lockedHint        bool
+	inactive          bool
+	screenSaverActive bool
+	locked            bool
+	onChange          func(locked bool)
+}
+
+func newSessionMonitor() SessionMonitor {
+	return &dbusSessionMonitor{
+		connectSystem: func() (*dbus.Conn, error) {
+			return dbus.ConnectSystemBus()
+		},
+		connectSession: func() (*dbus.Conn, error) {
+			return dbus.ConnectSessionBus()
+		},
+		sessionID: os.Getenv("XDG_SESSION_ID"),
+	}
+}
+
+// Start subscribes to logind and ScreenSaver signals until ctx is cancelled
+func (monitor *dbusSessionMonitor) Start(ctx context.Context, onChange func(locked bool)) error {
+	monitor.mu.Lock()
+	monitor.onChange = onChange
+	monitor.mu.Unlock()
+
+	logindErr := monitor.watchLogind(ctx)
+	screenSaverErr := monitor.watchScreenSaver(ctx)
+
+	if logindErr != nil && screenSaverErr != nil {
+		return fmt.Errorf("%w: logind: %v; screensaver: %v", ErrSessionMonitorUnsupported, logindErr, screenSaverErr)
+	}
+
+	return nil
+}
+
+// watchLogind reads the initial session state and follows PropertiesChanged
+func (monitor *dbusSessionMonitor) watchLogind(ctx context.Context) error {
+	conn, err := monitor.connectSystem()
+	if err != nil {
+		return fmt.Errorf("connect system bus: %w", err)
+	}
+
+	sessionPath := monitor.resolveSessionPath(conn)
+	session := conn.Object(logindBusName, sessionPath)
+
+	lockedHint, err := session.GetProperty(logindSessionInterface + ".LockedHint")
+	if err != nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("read LockedHint: %w", err)
+	}
+
+	active, err := session.GetProperty(logindSessionInterface + ".Active")
+	if err != nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("read session Active: %w", err)
+	}
+
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(sessionPath),
+		dbus.WithMatchInterface(propertiesInterface),
+		dbus.WithMatchMember("PropertiesChanged"),
+	); err != nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("subscribe logind session: %w", err)
+	}
+
+	monitor.update(func() {
+		monitor.lockedHint = variantBool(lockedHint)
+		monitor.inactive = !variantBool(active)
+	})
+
+	monitor.listen(ctx, conn, monitor.handleLogindSignal)
+
+	return nil
+}
+
+// watchScreenSaver follows ActiveChanged from the desktop screen saver
+func (monitor *dbusSessionMonitor) watchScreenSaver(ctx context.Context) error {
+	conn, err := monitor.connectSession()
+	if err != nil {
+		return fmt.Errorf("connect session bus: %w", err)
+	}
+
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchInterface(screenSaverInterface),
+		dbus.WithMatchMember("ActiveChanged"),
+	); err != nil {
+		_ = conn.Close()
+
+		return fmt.Errorf("subscribe screensaver: %w", err)
+	}
+
+	var active bool
+
+	// GetActive отсутствует у части окружений, поэтому начальное состояние необязательно
+	if err := conn.Object(screenSaverBusName, screenSaverPath).Call(screenSaverInterface+".GetActive", 0).Store(&active); err == nil {
+		monitor.update(func() {
+			monitor.screenSaverActive = active
+		})
+	}
+
+	monitor.listen(ctx, conn, monitor.handleScreenSaverSignal)
+
+	return nil
+}
+
+// resolveSessionPath finds this process's logind session object
+func (monitor *dbusSessionMonitor) resolveSessionPath(conn *dbus.Conn) dbus.ObjectPath {
+	manager := conn.Object(logindBusName, logindManagerPath)
+
+	var path dbus.ObjectPath
+	var err error
+
+	if monitor.sessionID != "" {
+		err = manager.Call(logindManagerInterface+".GetSession", 0, monitor.sessionID).Store(&path)
+	} else {
+		err = manager.Call(logindManagerInterface+".GetSessionByPID", 0, uint32(os.Getpid())).Store(&path)
+	}
+
+	if err != nil || !path.IsValid() {
+		return logindAutoSessionPath
+	}
+
+	return path
+}
+
+// listen dispatches bus signals until ctx is cancelled, then closes conn
+func (monitor *dbusSessionMonitor) listen(ctx context.Context, conn *dbus.Conn, handle func(*dbus.Signal)) {
+	signals := make(chan *dbus.Signal, 16)
+	conn.Signal(signals)
+
+	go func() {
+		defer conn.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case signal, ok := <-signals:
+				if !ok {
+					return
+				}
+
+				handle(signal)
+			}
+		}
+	}()
+}
+
+func (monitor *dbusSessionMonitor) handleLogindSignal(signal *dbus.Signal) {
+	if signal.Name != propertiesInterface+".PropertiesChanged" || len(signal.Body) < 2 {
+		return
+	}
+
+	iface, ok := signal.Body[0].(string)
+	if !ok || iface != logindSessionInterface {
+		return
+	}
+
+	changed, ok := signal.Body[1].(map[string]dbus.Variant)
+	if !ok {
+		return
+	}
+
+	monitor.update(func() {
+		if value, ok := changed["LockedHint"]; ok {
+			monitor.lockedHint = variantBool(value)
+		}
+
+		if value, ok := changed["Active"]; ok {
+			monitor.inactive = !variantBool(value)
+		}
+	})
+}
+
+func (monitor *dbusSessionMonitor) handleScreenSaverSignal(signal *dbus.Signal) {
+	if signal.Name != screenSaverInterface+".ActiveChanged" || len(signal.Body) < 1 {
+		return
+	}
+
+	active, ok := signal.Body[0].(bool)
+	if !ok {
+		return
+	}
+
+	monitor.update(func() {
+		monitor.screenSaverActive = active
+	})
+}
+
+// update applies a state change and notifies onChange on lock transitions
+func (monitor *dbusSessionMonitor) update(apply func()) {
+	monitor.mu.Lock()
+	apply()
+
+	locked := monitor.lockedHint || monitor.inactive || monitor.screenSaverActive
+	changed := locked != monitor.locked
+	monitor.locked = locked
+	onChange := monitor.onChange
+
+	monitor.mu.Unlock()
+
+	if changed && onChange != nil {
+		onChange(locked)
+	}
+}
+
+func variantBool(value dbus.Variant) bool {
+	flag, ok := value.Value().(bool)
+
+	return ok && flag
+}
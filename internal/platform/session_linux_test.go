@@ -0,0 +1,216 @@
+package platform
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+)
+
+const fakeSessionPath = dbus.ObjectPath("/org/freedesktop/login1/session/_31")
+
+func TestDBusSessionMonitorFollowsLogindLockedHint(t *testing.T) {
+	address := startPrivateBus(t)
+	props := exportFakeLogind(t, address)
+	changes := startTestSessionMonitor(t, address)
+
+	props.SetMust(logindSessionInterface, "LockedHint", true)
+	expectLockChange(t, changes, true)
+
+	props.SetMust(logindSessionInterface, "LockedHint", false)
+	expectLockChange(t, changes, false)
+}
+
+func TestDBusSessionMonitorTreatsInactiveSessionAsLocked(t *testing.T) {
+	address := startPrivateBus(t)
+	props := exportFakeLogind(t, address)
+	changes := startTestSessionMonitor(t, address)
+
+	props.SetMust(logindSessionInterface, "Active", false)
+	expectLockChange(t, changes, true)
+
+	props.SetMust(logindSessionInterface, "Active", true)
+	expectLockChange(t, changes, false)
+}
+
+func TestDBusSessionMonitorFollowsScreenSaver(t *testing.T) {
+	address := startPrivateBus(t)
+	exportFakeLogind(t, address)
+	changes := startTestSessionMonitor(t, address)
+
+	conn := connectPrivateBus(t, address)
+
+	for _, active := range []bool{true, false} {
+		if err := conn.Emit(screenSaverPath, screenSaverInterface+".ActiveChanged", active); err != nil {
+			t.Fatalf("Emit(ActiveChanged) error = %v", err)
+		}
+
+		expectLockChange(t, changes, active)
+	}
+}
+
+func TestDBusSessionMonitorWithoutBusesIsUnsupported(t *testing.T) {
+	monitor := &dbusSessionMonitor{
+		connectSystem: func() (*dbus.Conn, error) {
+			return nil, fmt.Errorf("no system bus")
+		},
+		connectSession: func() (*dbus.Conn, error) {
+			return nil, fmt.Errorf("no session bus")
+		},
+	}
+
+	if err := monitor.Start(context.Background(), nil); err == nil {
+		t.Fatalf("Start() error = nil, want unsupported")
+	}
+}
+
+// fakeLogindManager answers session lookups like systemd-logind
+type fakeLogindManager struct{}
+
+func (fakeLogindManager) GetSession(id string) (dbus.ObjectPath, *dbus.Error) {
+	return fakeSessionPath, nil
+}
+
+func (fakeLogindManager) GetSessionByPID(pid uint32) (dbus.ObjectPath, *dbus.Error) {
+	return fakeSessionPath, nil
+}
+
+func startPrivateBus(t *testing.T) string {
+	t.Helper()
+
+	daemonPath, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not available")
+	}
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "bus.conf")
+	config := strings.Join([]string{
+		`<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN" "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">`,
+		`<busconfig>`,
+		`  <type>session</type>`,
+		fmt.Sprintf(`  <listen>unix:path=%s</listen>`, filepath.Join(dir, "bus")),
+		`  <auth>EXTERNAL</auth>`,
+		`  <policy context="default">`,
+		`    <allow send_destination="*" eavesdrop="true"/>`,
+		`    <allow eavesdrop="true"/>`,
+		`    <allow own="*"/>`,
+		`  </policy>`,
+		`</busconfig>`,
+		"",
+	}, "\n")
+
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cmd := exec.Command(daemonPath, "--config-file="+configPath, "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() error = %v", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		t.Skipf("start dbus-daemon: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+
+	address, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatalf("read dbus-daemon address: %v", err)
+	}
+
+	return strings.TrimSpace(address)
+}
+
+func connectPrivateBus(t *testing.T, address string) *dbus.Conn {
+	t.Helper()
+
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatalf("dbus.Connect() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func exportFakeLogind(t *testing.T, address string) *prop.Properties {
+	t.Helper()
+
+	conn := connectPrivateBus(t, address)
+
+	if err := conn.Export(fakeLogindManager{}, logindManagerPath, logindManagerInterface); err != nil {
+		t.Fatalf("Export(manager) error = %v", err)
+	}
+
+	props, err := prop.Export(conn, fakeSessionPath, prop.Map{
+		logindSessionInterface: {
+			"LockedHint": {Value: false, Writable: true, Emit: prop.EmitTrue},
+			"Active":     {Value: true, Writable: true, Emit: prop.EmitTrue},
+		},
+	})
+	if err != nil {
+		t.Fatalf("prop.Export() error = %v", err)
+	}
+
+	reply, err := conn.RequestName(logindBusName, dbus.NameFlagDoNotQueue)
+	if err != nil || reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("RequestName() = %v, %v, want primary owner", reply, err)
+	}
+
+	return props
+}
+
+func startTestSessionMonitor(t *testing.T, address string) <-chan bool {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	connect := func() (*dbus.Conn, error) {
+		return dbus.Connect(address)
+	}
+	monitor := &dbusSessionMonitor{
+		connectSystem:  connect,
+		connectSession: connect,
+		sessionID:      "1",
+	}
+	changes := make(chan bool, 4)
+
+	if err := monitor.Start(ctx, func(locked bool) {
+		changes <- locked
+	}); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	return changes
+}
+
+func expectLockChange(t *testing.T, changes <-chan bool, want bool) {
+	t.Helper()
+
+	select {
+	case locked := <-changes:
+		if locked != want {
+			t.Fatalf("locked = %t, want %t", locked, want)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for locked = %t", want)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package platform
+
+import "context"
+
+type unsupportedSessionMonitor struct{}
+
+func newSessionMonitor() SessionMonitor {
+	return unsupportedSessionMonitor{}
+}
+
+// Start reports that session tracking is unavailable on this platform
+func (unsupportedSessionMonitor) Start(ctx context.Context, onChange func(locked bool)) error {
+	_, _ = ctx, onChange
+
+	return ErrSessionMonitorUnsupported
+}
@@ -19,7 +19,6 @@ import (
 )
 
 const (
-	activationSecretFileName = "single_instance.secret"
 	activationSecretSize     = 32
 	activationMessagePrefix  = "EAGLEEYE_ACTIVATE_V1"
 	maxActivationMessageSize = 256
@@ -27,6 +26,9 @@ const (
 	activationDebounce       = 500 * time.Millisecond
 )
 
+// ActivationSecretFileName is the per-session secret that signs activation pings
+const ActivationSecretFileName = "single_instance.secret"
+
 // ErrAlreadyRunning indicates another instance already holds the lock
 var ErrAlreadyRunning = errors.New("instance already running")
 
@@ -39,6 +41,8 @@ type InstanceGuard struct {
 	activationSecret []byte
 	activationMu     sync.Mutex
 	lastActivation   time.Time
+	commandMu        sync.Mutex
+	commandHandler   CommandHandler
 }
 
 // AcquireSingleInstance reserves the app-specific localhost port
@@ -106,26 +110,34 @@ func (guard *InstanceGuard) ListenForActivation(ctx context.Context, onActivate
 				continue
 			}
 
-			if err := conn.SetReadDeadline(time.Now().Add(activationReadTimeout)); err != nil {
-				_ = conn.Close()
+			guard.serveConnection(conn, onActivate)
+		}
+	}()
+}
 
-				continue
-			}
+// serveConnection reads one activation ping or command from conn. Commands
+// are answered on the same connection before it is closed
+func (guard *InstanceGuard) serveConnection(conn net.Conn, onActivate func()) {
+	defer conn.Close()
 
-			message, err := io.ReadAll(io.LimitReader(conn, maxActivationMessageSize+1))
-			if closeErr := conn.Close(); closeErr != nil {
-				continue
-			}
+	if err := conn.SetReadDeadline(time.Now().Add(activationReadTimeout)); err != nil {
+		return
+	}
 
-			if err != nil {
-				continue
-			}
+	message, err := io.ReadAll(io.LimitReader(conn, maxCommandMessageSize+1))
+	if err != nil {
+		return
+	}
 
-			if onActivate != nil && guard.isValidActivationMessage(message) && guard.allowActivation() {
-				onActivate()
-			}
-		}
-	}()
+	if strings.HasPrefix(string(message), commandMessagePrefix+":") {
+		guard.runCommand(conn, message)
+
+		return
+	}
+
+	if onActivate != nil && guard.isValidActivationMessage(message) && guard.allowActivation() {
+		onActivate()
+	}
 }
 
 // NotifyRunningInstance asks the already-running instance to show UI
@@ -245,15 +257,15 @@ func readActivationSecretFile(path string) ([]byte, error) {
 	return secret, nil
 }
 
-// activationSecretPath returns the per-user secret file path
+// activationSecretPath returns the secret file path in the runtime directory
 func activationSecretPath(appName string) (string, error) {
-	configDir, err := os.UserConfigDir()
+	layout, err := ResolveLayout(appName)
 
 	if err != nil {
-		return "", fmt.Errorf("resolve user config dir: %w", err)
+		return "", err
 	}
 
-	return filepath.Join(configDir, appName, activationSecretFileName), nil
+	return filepath.Join(layout.RuntimeDir, ActivationSecretFileName), nil
 }
 
 // buildActivationMessage signs a one-shot activation ping
@@ -295,11 +307,14 @@ func isValidActivationMessage(message, secret []byte) bool {
 
 // computeActivationMAC signs the activation prefix and nonce
 func computeActivationMAC(secret []byte, nonceHex string) []byte {
+	return signFields(secret, activationMessagePrefix, nonceHex)
+}
+
+// signFields signs fields joined by colons with secret
+func signFields(secret []byte, fields ...string) []byte {
 	mac := hmac.New(sha256.New, secret)
 
-	_, _ = mac.Write([]byte(activationMessagePrefix))
-	_, _ = mac.Write([]byte(":"))
-	_, _ = mac.Write([]byte(nonceHex))
+	_, _ = mac.Write([]byte(strings.Join(fields, ":")))
 
 	return mac.Sum(nil)
 }
@@ -167,5 +167,6 @@ func setPlatformUserConfigEnv(t *testing.T, path string) {
 		t.Setenv("APPDATA", path)
 	default:
 		t.Setenv("XDG_CONFIG_HOME", path)
+		t.Setenv("XDG_RUNTIME_DIR", path)
 	}
 }
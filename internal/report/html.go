@@ -0,0 +1,232 @@
+package report
+
+import (
+	"eagleeye/internal/ui/i18n"
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+const (
+	chartWidth        = 560.0
+	dailyChartHeight  = 220.0
+	dailyChartTop     = 20.0
+	dailyChartBottom  = 24.0
+	dailyBarWidth     = 40.0
+	compareRowHeight  = 32.0
+	compareLabelWidth = 130.0
+	compareBarWidth   = 360.0
+	takenColor        = "#5cb85c"
+	skippedColor      = "#e8be42"
+	emptyColor        = "#e4e4e4"
+)
+
+// dailyBar is one weekday column of the breaks-per-day chart
+type dailyBar struct {
+	Name          string
+	LabelX        float64
+	X             float64
+	TakenY        float64
+	TakenHeight   float64
+	SkippedY      float64
+	SkippedHeight float64
+	Count         int
+	CountY        float64
+}
+
+// compareBar is one week's row of the compliance chart
+type compareBar struct {
+	Label  string
+	Value  string
+	Y      float64
+	Width  float64
+	ValueX float64
+}
+
+// htmlPage is everything the HTML template needs
+type htmlPage struct {
+	content
+
+	DailyChartTitle      string
+	ComplianceChartTitle string
+	TakenLegend          string
+	SkippedLegend        string
+	ChartWidth           float64
+	DailyHeight          float64
+	BaselineY            float64
+	BarWidth             float64
+	DailyBars            []dailyBar
+	CompareHeight        float64
+	CompareLabelWidth    float64
+	CompareBarWidth      float64
+	CompareBars          []compareBar
+	TakenColor           string
+	SkippedColor         string
+	EmptyColor           string
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; max-width: 640px; margin: 2em auto; padding: 0 1em; color: #222; }
+h1 { font-size: 1.5em; }
+h2 { font-size: 1.15em; margin-top: 1.6em; }
+dl { display: grid; grid-template-columns: auto auto; gap: .3em 1.5em; justify-content: start; }
+dt { color: #555; }
+dd { margin: 0; font-weight: bold; }
+table { border-collapse: collapse; }
+th, td { padding: .3em .8em; border-bottom: 1px solid #ddd; }
+td.number, th.number { text-align: right; }
+svg text { font-size: 12px; fill: #444; }
+.legend span { display: inline-block; width: .8em; height: .8em; margin: 0 .3em 0 1em; vertical-align: middle; }
+footer { margin-top: 2em; color: #888; font-size: .85em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+
+<h2>{{.SummaryTitle}}</h2>
+<dl>
+{{- range .Summary}}
+<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{- end}}
+</dl>
+
+<h2>{{.DailyChartTitle}}</h2>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.ChartWidth}}" height="{{.DailyHeight}}" role="img" aria-label="{{.DailyChartTitle}}">
+{{- range .DailyBars}}
+<rect x="{{.X}}" y="{{.TakenY}}" width="{{$.BarWidth}}" height="{{.TakenHeight}}" fill="{{$.TakenColor}}"/>
+<rect x="{{.X}}" y="{{.SkippedY}}" width="{{$.BarWidth}}" height="{{.SkippedHeight}}" fill="{{$.SkippedColor}}"/>
+{{- if .Count}}
+<text x="{{.LabelX}}" y="{{.CountY}}" text-anchor="middle">{{.Count}}</text>
+{{- end}}
+<text x="{{.LabelX}}" y="{{$.DailyHeight}}" text-anchor="middle" dy="-6">{{.Name}}</text>
+{{- end}}
+<line x1="0" y1="{{.BaselineY}}" x2="{{.ChartWidth}}" y2="{{.BaselineY}}" stroke="#bbb"/>
+</svg>
+<p class="legend"><span style="background: {{.TakenColor}}"></span>{{.TakenLegend}}<span style="background: {{.SkippedColor}}"></span>{{.SkippedLegend}}</p>
+
+<h2>{{.TrendTitle}}</h2>
+{{- if .NoPrevious}}
+<p>{{.NoPrevious}}</p>
+{{- else}}
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.ChartWidth}}" height="{{.CompareHeight}}" role="img" aria-label="{{.ComplianceChartTitle}}">
+{{- range .CompareBars}}
+<text x="0" y="{{.Y}}" dy="16">{{.Label}}</text>
+<rect x="{{$.CompareLabelWidth}}" y="{{.Y}}" width="{{$.CompareBarWidth}}" height="22" fill="{{$.EmptyColor}}"/>
+<rect x="{{$.CompareLabelWidth}}" y="{{.Y}}" width="{{.Width}}" height="22" fill="{{$.TakenColor}}"/>
+<text x="{{.ValueX}}" y="{{.Y}}" dy="16">{{.Value}}</text>
+{{- end}}
+</svg>
+<dl>
+{{- range .Trend}}
+<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{- end}}
+</dl>
+{{- end}}
+
+<h2>{{.DailyTitle}}</h2>
+<table>
+<tr><th>{{index .Columns 0}}</th><th class="number">{{index .Columns 1}}</th><th class="number">{{index .Columns 2}}</th><th class="number">{{index .Columns 3}}</th></tr>
+{{- range .Days}}
+<tr><td>{{.Name}} {{.Date}}</td><td class="number">{{.Honoured}}</td><td class="number">{{.Skipped}}</td><td class="number">{{.Compliance}}</td></tr>
+{{- end}}
+</table>
+
+<footer>{{.Footer}}</footer>
+</body>
+</html>
+`))
+
+// HTML renders weekly as a self-contained HTML page with inline SVG charts
+// in localizer's language. It loads nothing from the network
+func HTML(weekly Weekly, localizer *i18n.Localizer) (string, error) {
+	page := htmlPage{
+		content:              newContent(weekly, localizer),
+		DailyChartTitle:      localizer.T("report.chartDaily"),
+		ComplianceChartTitle: localizer.T("report.compliance"),
+		TakenLegend:          localizer.T("stats.legendTaken"),
+		SkippedLegend:        localizer.T("stats.legendSkipped"),
+		ChartWidth:           chartWidth,
+		DailyHeight:          dailyChartHeight,
+		BaselineY:            dailyChartHeight - dailyChartBottom,
+		BarWidth:             dailyBarWidth,
+		DailyBars:            dailyBars(weekly, localizer),
+		CompareHeight:        2 * compareRowHeight,
+		CompareLabelWidth:    compareLabelWidth,
+		CompareBarWidth:      compareBarWidth,
+		TakenColor:           takenColor,
+		SkippedColor:         skippedColor,
+		EmptyColor:           emptyColor,
+	}
+
+	for index, row := range []struct {
+		label string
+		value int
+	}{
+		{localizer.T("report.previousWeek"), percent(weekly.Previous)},
+		{localizer.T("report.thisWeek"), percent(weekly.Week)},
+	} {
+		width := compareBarWidth * float64(row.value) / 100
+		page.CompareBars = append(page.CompareBars, compareBar{
+			Label:  row.label,
+			Value:  fmt.Sprintf("%d%%", row.value),
+			Y:      float64(index) * compareRowHeight,
+			Width:  width,
+			ValueX: compareLabelWidth + compareBarWidth + 8,
+		})
+	}
+
+	var builder strings.Builder
+	if err := htmlTemplate.Execute(&builder, page); err != nil {
+		return "", fmt.Errorf("render weekly report: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+// dailyBars stacks skipped on top of taken breaks, scaled to the busiest day
+func dailyBars(weekly Weekly, localizer *i18n.Localizer) []dailyBar {
+	busiest := 0
+	for _, summary := range weekly.Days {
+		busiest = max(busiest, decided(summary))
+	}
+
+	plotHeight := dailyChartHeight - dailyChartTop - dailyChartBottom
+	baseline := dailyChartHeight - dailyChartBottom
+	slot := chartWidth / float64(len(weekly.Days))
+	bars := make([]dailyBar, 0, len(weekly.Days))
+
+	for index, summary := range weekly.Days {
+		bar := dailyBar{
+			Name:   weekdayName(index, localizer),
+			LabelX: slot*float64(index) + slot/2,
+			X:      slot*float64(index) + (slot-dailyBarWidth)/2,
+			Count:  decided(summary),
+		}
+
+		if busiest > 0 {
+			bar.TakenHeight = plotHeight * float64(summary.Completed) / float64(busiest)
+			bar.SkippedHeight = plotHeight * float64(skipped(summary)) / float64(busiest)
+		}
+
+		bar.TakenHeight = roundPixel(bar.TakenHeight)
+		bar.SkippedHeight = roundPixel(bar.SkippedHeight)
+		bar.TakenY = roundPixel(baseline - bar.TakenHeight)
+		bar.SkippedY = roundPixel(bar.TakenY - bar.SkippedHeight)
+		bar.CountY = roundPixel(bar.SkippedY - 4)
+		bars = append(bars, bar)
+	}
+
+	return bars
+}
+
+// roundPixel keeps chart coordinates to one decimal so the markup stays short
+func roundPixel(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"eagleeye/internal/ui/i18n"
+	"fmt"
+	"strings"
+)
+
+// Markdown renders weekly as a Markdown document in localizer's language
+func Markdown(weekly Weekly, localizer *i18n.Localizer) string {
+	text := newContent(weekly, localizer)
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# %s\n\n", text.Title)
+
+	fmt.Fprintf(&builder, "## %s\n\n", text.SummaryTitle)
+	writeFacts(&builder, text.Summary)
+
+	fmt.Fprintf(&builder, "## %s\n\n", text.TrendTitle)
+
+	if text.NoPrevious != "" {
+		fmt.Fprintf(&builder, "%s\n\n", text.NoPrevious)
+	} else {
+		writeFacts(&builder, text.Trend)
+	}
+
+	fmt.Fprintf(&builder, "## %s\n\n", text.DailyTitle)
+	fmt.Fprintf(&builder, "| %s |\n", strings.Join(text.Columns[:], " | "))
+	builder.WriteString("| --- | ---: | ---: | ---: |\n")
+
+	for _, day := range text.Days {
+		fmt.Fprintf(&builder, "| %s %s | %d | %d | %s |\n", day.Name, day.Date, day.Honoured, day.Skipped, day.Compliance)
+	}
+
+	fmt.Fprintf(&builder, "\n_%s_\n", text.Footer)
+
+	return builder.String()
+}
+
+func writeFacts(builder *strings.Builder, facts []fact) {
+	for _, item := range facts {
+		fmt.Fprintf(builder, "- **%s:** %s\n", item.Label, item.Value)
+	}
+
+	builder.WriteString("\n")
+}
@@ -0,0 +1,208 @@
+// Package report renders the weekly eye-health report from recorded breaks.
+//
+// Build turns break records into the figures for one week and the week
+// before it; Markdown and HTML render those figures with the user's
+// language. Everything here is pure so reports can be tested with fixture
+// records; writing the files and opening them is up to the caller.
+package report
+
+import (
+	"eagleeye/internal/history"
+	"eagleeye/internal/ui/i18n"
+	"fmt"
+	"math"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Weekly holds the figures of one Monday-to-Sunday week and the week before
+// it. Days are Monday first
+type Weekly struct {
+	Start                  time.Time
+	Days                   [7]history.Summary
+	Week                   history.Summary
+	Previous               history.Summary
+	LongestStretch         time.Duration
+	PreviousLongestStretch time.Duration
+}
+
+// Build summarizes the week containing day and the week before it, in day's
+// location. Records outside those two weeks are ignored
+func Build(records []history.Record, day time.Time) Weekly {
+	week := history.Week(day)
+	previous := history.Week(week.From.AddDate(0, 0, -1))
+
+	var (
+		weeks, previousWeeks []history.Record
+		days                 [7][]history.Record
+	)
+
+	for _, record := range records {
+		switch {
+		case week.Matches(record):
+			weekday := (int(record.StartedAt.In(day.Location()).Weekday()) + 6) % 7
+			weeks = append(weeks, record)
+			days[weekday] = append(days[weekday], record)
+		case previous.Matches(record):
+			previousWeeks = append(previousWeeks, record)
+		}
+	}
+
+	weekly := Weekly{
+		Start:                  week.From,
+		Week:                   history.Summarize(weeks),
+		Previous:               history.Summarize(previousWeeks),
+		LongestStretch:         history.LongestStretch(weeks, day.Location()),
+		PreviousLongestStretch: history.LongestStretch(previousWeeks, day.Location()),
+	}
+
+	for index, records := range days {
+		weekly.Days[index] = history.Summarize(records)
+	}
+
+	return weekly
+}
+
+// FileName is the report's file name without extension, for example
+// "week-2026-03-02"
+func (weekly Weekly) FileName() string {
+	return "week-" + weekly.Start.Format(dateLayout)
+}
+
+// fact is one labelled figure of the report
+type fact struct {
+	Label string
+	Value string
+}
+
+// dayLine is one row of the day-by-day table
+type dayLine struct {
+	Name       string
+	Date       string
+	Honoured   int
+	Skipped    int
+	Compliance string
+}
+
+// content is the localized text both renderers share
+type content struct {
+	Language     string
+	Title        string
+	SummaryTitle string
+	Summary      []fact
+	TrendTitle   string
+	Trend        []fact
+	NoPrevious   string
+	DailyTitle   string
+	Columns      [4]string
+	Days         []dayLine
+	Footer       string
+}
+
+func newContent(weekly Weekly, localizer *i18n.Localizer) content {
+	text := content{
+		Language:     localizer.Language(),
+		Title:        localizer.T("report.title", weekly.Start.Format(dateLayout)),
+		SummaryTitle: localizer.T("report.summary"),
+		Summary: []fact{
+			{Label: localizer.T("report.honoured"), Value: fmt.Sprint(weekly.Week.Completed)},
+			{Label: localizer.T("report.skipped"), Value: fmt.Sprint(skipped(weekly.Week))},
+			{Label: localizer.T("report.compliance"), Value: formatCompliance(weekly.Week)},
+			{Label: localizer.T("report.longestStretch"), Value: formatDuration(weekly.LongestStretch, localizer)},
+		},
+		TrendTitle: localizer.T("report.trend"),
+		DailyTitle: localizer.T("report.daily"),
+		Columns: [4]string{
+			localizer.T("report.day"),
+			localizer.T("report.honoured"),
+			localizer.T("report.skipped"),
+			localizer.T("report.compliance"),
+		},
+		Footer: localizer.T("report.footer"),
+	}
+
+	if decided(weekly.Previous) == 0 {
+		text.NoPrevious = localizer.T("report.noPrevious")
+	} else {
+		text.Trend = []fact{
+			{
+				Label: localizer.T("report.compliance"),
+				Value: localizer.T("report.trendPoints", percent(weekly.Week)-percent(weekly.Previous), percent(weekly.Previous)),
+			},
+			{
+				Label: localizer.T("report.honoured"),
+				Value: localizer.T("report.trendCount", weekly.Week.Completed-weekly.Previous.Completed, weekly.Previous.Completed),
+			},
+			{
+				Label: localizer.T("report.longestStretch"),
+				Value: localizer.T("report.trendStretch",
+					formatDelta(weekly.LongestStretch-weekly.PreviousLongestStretch, localizer),
+					formatDuration(weekly.PreviousLongestStretch, localizer)),
+			},
+		}
+	}
+
+	for index, summary := range weekly.Days {
+		text.Days = append(text.Days, dayLine{
+			Name:       weekdayName(index, localizer),
+			Date:       weekly.Start.AddDate(0, 0, index).Format(dateLayout),
+			Honoured:   summary.Completed,
+			Skipped:    skipped(summary),
+			Compliance: formatCompliance(summary),
+		})
+	}
+
+	return text
+}
+
+// skipped counts the breaks the user did not take, as the statistics window does
+func skipped(summary history.Summary) int {
+	return summary.Skipped + summary.Postponed
+}
+
+func decided(summary history.Summary) int {
+	return summary.Completed + skipped(summary)
+}
+
+func percent(summary history.Summary) int {
+	return int(math.Round(summary.Compliance() * 100))
+}
+
+func formatCompliance(summary history.Summary) string {
+	if decided(summary) == 0 {
+		return "—"
+	}
+
+	return fmt.Sprintf("%d%%", percent(summary))
+}
+
+// formatDuration shows a duration rounded to minutes as "1 h 5 min" or "24 min"
+func formatDuration(duration time.Duration, localizer *i18n.Localizer) string {
+	if duration <= 0 {
+		return "—"
+	}
+
+	minutes := int(duration.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d %s", minutes, localizer.T("unit.min"))
+	}
+
+	return fmt.Sprintf("%d %s %d %s", minutes/60, localizer.T("unit.hour"), minutes%60, localizer.T("unit.min"))
+}
+
+// formatDelta shows a signed duration change such as "+15 min"
+func formatDelta(delta time.Duration, localizer *i18n.Localizer) string {
+	switch {
+	case delta.Round(time.Minute) == 0:
+		return "±0 " + localizer.T("unit.min")
+	case delta < 0:
+		return "-" + formatDuration(-delta, localizer)
+	default:
+		return "+" + formatDuration(delta, localizer)
+	}
+}
+
+func weekdayName(index int, localizer *i18n.Localizer) string {
+	return localizer.T(fmt.Sprintf("stats.weekday%d", index))
+}
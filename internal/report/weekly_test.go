@@ -0,0 +1,96 @@
+package report
+
+import (
+	"eagleeye/internal/history"
+	"eagleeye/internal/history/historytest"
+	"eagleeye/internal/ui/i18n"
+	"strings"
+	"testing"
+	"time"
+)
+
+var monday = time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+// fixtureRecords is a week with 3 of 4 breaks taken after a week with 1 of 2
+func fixtureRecords() []history.Record {
+	return []history.Record{
+		historytest.Record("previous-1", history.BreakShort, monday.AddDate(0, 0, -6).Add(9*time.Hour), history.OutcomeCompleted),
+		historytest.Record("previous-2", history.BreakShort, monday.AddDate(0, 0, -6).Add(10*time.Hour), history.OutcomeSkipped),
+		historytest.Record("monday-1", history.BreakShort, monday.Add(9*time.Hour), history.OutcomeCompleted),
+		historytest.Record("monday-2", history.BreakShort, monday.Add(9*time.Hour+30*time.Minute), history.OutcomeSkipped),
+		historytest.Record("monday-3", history.BreakShort, monday.Add(10*time.Hour+15*time.Minute+20*time.Second), history.OutcomeCompleted),
+		historytest.Record("friday-1", history.BreakShort, monday.AddDate(0, 0, 4).Add(14*time.Hour), history.OutcomeCompleted),
+		historytest.Record("friday-2", history.BreakShort, monday.AddDate(0, 0, 4).Add(14*time.Hour+20*time.Minute), history.OutcomeInterrupted),
+		historytest.Record("next-week", history.BreakShort, monday.AddDate(0, 0, 7).Add(9*time.Hour), history.OutcomeCompleted),
+	}
+}
+
+// TestBuildSummarizesWeekAndPreviousWeek verifies the figures behind the report
+func TestBuildSummarizesWeekAndPreviousWeek(t *testing.T) {
+	weekly := Build(fixtureRecords(), monday.AddDate(0, 0, 3).Add(12*time.Hour))
+
+	if !weekly.Start.Equal(monday) || weekly.FileName() != "week-2026-03-09" {
+		t.Fatalf("Start = %v, FileName() = %q, want %v and week-2026-03-09", weekly.Start, weekly.FileName(), monday)
+	}
+
+	if weekly.Week.Total != 5 || weekly.Week.Completed != 3 || weekly.Week.Skipped != 1 {
+		t.Fatalf("Week = %+v, want 5 records with 3 completed and 1 skipped", weekly.Week)
+	}
+
+	if weekly.Previous.Total != 2 || weekly.Previous.Completed != 1 {
+		t.Fatalf("Previous = %+v, want 2 records with 1 completed", weekly.Previous)
+	}
+
+	if weekly.Days[0].Total != 3 || weekly.Days[4].Total != 2 || weekly.Days[6].Total != 0 {
+		t.Fatalf("Days totals = %d / %d / %d, want 3 / 2 / 0", weekly.Days[0].Total, weekly.Days[4].Total, weekly.Days[6].Total)
+	}
+
+	if weekly.LongestStretch != 75*time.Minute {
+		t.Fatalf("LongestStretch = %v, want 1h15m", weekly.LongestStretch)
+	}
+}
+
+// TestMarkdownReportsTrend verifies the Markdown summary, trend and day table
+func TestMarkdownReportsTrend(t *testing.T) {
+	markdown := Markdown(Build(fixtureRecords(), monday), i18n.New(i18n.LanguageEN))
+
+	for _, want := range []string{
+		"# Eye-health report: week of 2026-03-09\n",
+		"- **Breaks honoured:** 3\n",
+		"- **Compliance:** 75%\n",
+		"- **Longest screen stretch without a break:** 1 h 15 min\n",
+		"- **Compliance:** +25 points (50% the week before)\n",
+		"- **Breaks honoured:** +2 (1 the week before)\n",
+		"| Mon 2026-03-09 | 2 | 1 | 67% |\n",
+		"| Sun 2026-03-15 | 0 | 0 | — |\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("Markdown() missing %q in\n%s", want, markdown)
+		}
+	}
+
+	empty := Markdown(Build(nil, monday), i18n.New(i18n.LanguageEN))
+	if !strings.Contains(empty, "No breaks were recorded the week before.") {
+		t.Fatalf("Markdown(no records) = %s, want the no previous week note", empty)
+	}
+}
+
+// TestHTMLIsSelfContained verifies the page carries its charts inline
+func TestHTMLIsSelfContained(t *testing.T) {
+	page, err := HTML(Build(fixtureRecords(), monday), i18n.New(i18n.LanguageRU))
+	if err != nil {
+		t.Fatalf("HTML() error = %v", err)
+	}
+
+	for _, want := range []string{`<html lang="ru">`, "<svg", "Отчёт о здоровье глаз", "75%", `fill="#5cb85c"`} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("HTML() missing %q", want)
+		}
+	}
+
+	for _, external := range []string{"<script", "<link", "src=", "url("} {
+		if strings.Contains(page, external) {
+			t.Fatalf("HTML() contains %q, want a self-contained page", external)
+		}
+	}
+}
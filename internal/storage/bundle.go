@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"eagleeye/internal/ui/preferences"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	bundleFormat         = "eagleeye-settings-bundle"
+	bundleFormatVersion  = 1
+	bundleChecksumPrefix = "sha256:"
+	// BundleFileName is the suggested name for exported bundles
+	BundleFileName = "eagleeye-settings.yaml"
+)
+
+var (
+	// ErrInvalidBundle means the file is not an EagleEye settings bundle
+	ErrInvalidBundle = errors.New("not an EagleEye settings bundle")
+	// ErrBundleTooNew means the bundle was written by a newer EagleEye build
+	ErrBundleTooNew = errors.New("settings bundle is from a newer EagleEye version")
+	// ErrBundleChecksum means the bundle was modified or damaged after export
+	ErrBundleChecksum = errors.New("settings bundle checksum mismatch")
+)
+
+// machineSpecificKeys are settings that describe one computer or one person
+// rather than a break routine; bundles leave them out unless asked to
+// include them
+var machineSpecificKeys = []string{
+	"run_on_startup", "language", "weekly_report", "history_retention_days", "comfort_checkin_every",
+	"log_level", "log_level_timekeeper", "log_level_overlay", "log_level_platform",
+}
+
+// runtimeStateKeys are never exported because they track process state
+var runtimeStateKeys = []string{"break_timer_started"}
+
+// BundleOptions controls what ExportBundle writes and ImportBundle applies
+type BundleOptions struct {
+	IncludeMachineSpecific bool
+}
+
+// yamlBundle is the portable settings bundle layout. Checksum covers the
+// canonical YAML encoding of Settings
+type yamlBundle struct {
+	Format        string    `yaml:"format"`
+	FormatVersion int       `yaml:"format_version"`
+	ExportedAt    string    `yaml:"exported_at,omitempty"`
+	Checksum      string    `yaml:"checksum"`
+	Settings      yaml.Node `yaml:"settings"`
+}
+
+// ExportBundle writes settings as a portable bundle. The bundle holds the
+// break schedule, overlay options and custom reminders; machine-specific
+// fields are added only when options ask for them
+func ExportBundle(writer io.Writer, settings preferences.Settings, options BundleOptions) error {
+	var document yaml.Node
+
+	if err := document.Encode(yamlFromSettings(settings)); err != nil {
+		return fmt.Errorf("encode bundle settings: %w", err)
+	}
+
+	excluded := runtimeStateKeys
+	if !options.IncludeMachineSpecific {
+		excluded = append(append([]string(nil), excluded...), machineSpecificKeys...)
+	}
+
+	removeMappingKeys(&document, excluded)
+
+	checksum, err := bundleChecksum(&document)
+	if err != nil {
+		return err
+	}
+
+	serialized, err := yaml.Marshal(yamlBundle{
+		Format:        bundleFormat,
+		FormatVersion: bundleFormatVersion,
+		ExportedAt:    time.Now().UTC().Format(time.RFC3339),
+		Checksum:      checksum,
+		Settings:      document,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal settings bundle: %w", err)
+	}
+
+	if _, err := writer.Write(serialized); err != nil {
+		return fmt.Errorf("write settings bundle: %w", err)
+	}
+
+	return nil
+}
+
+// ImportBundle reads a bundle and returns current with the bundle applied.
+// Only the keys present in the bundle change; runtime state is always kept
+// from current, and machine-specific fields are kept too unless options
+// include them. Entries that fail validation are skipped and reported
+// through *SettingsValidationError
+func ImportBundle(reader io.Reader, current preferences.Settings, options BundleOptions) (preferences.Settings, error) {
+	rawData, err := io.ReadAll(io.LimitReader(reader, maxSettingsFileSize+1))
+	if err != nil {
+		return current, fmt.Errorf("read settings bundle: %w", err)
+	}
+
+	if len(rawData) > maxSettingsFileSize {
+		return current, fmt.Errorf("settings bundle exceeds %d bytes", maxSettingsFileSize)
+	}
+
+	var bundle yamlBundle
+	if err := yaml.Unmarshal(rawData, &bundle); err != nil {
+		return current, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+
+	if bundle.Format != bundleFormat || bundle.FormatVersion < 1 || bundle.Settings.Kind != yaml.MappingNode {
+		return current, ErrInvalidBundle
+	}
+
+	if bundle.FormatVersion > bundleFormatVersion {
+		return current, fmt.Errorf("%w: format %d, supported %d", ErrBundleTooNew, bundle.FormatVersion, bundleFormatVersion)
+	}
+
+	checksum, err := bundleChecksum(&bundle.Settings)
+	if err != nil {
+		return current, err
+	}
+
+	if checksum != bundle.Checksum {
+		return current, ErrBundleChecksum
+	}
+
+	settingsData, err := yaml.Marshal(&bundle.Settings)
+	if err != nil {
+		return current, fmt.Errorf("marshal bundle settings: %w", err)
+	}
+
+	settingsData, _, err = migrateSettingsData(settingsData)
+	if err != nil {
+		return current, err
+	}
+
+	cleaned, issues, err := inspectSettings(settingsData)
+	if err != nil {
+		return current, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+
+	if _, err := decodeSettingsStrict(cleaned); err != nil {
+		return current, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+
+	excluded := runtimeStateKeys
+	if !options.IncludeMachineSpecific {
+		excluded = append(append([]string(nil), excluded...), machineSpecificKeys...)
+	}
+
+	layer := parseMapping(cleaned)
+	if layer != nil {
+		removeMappingKeys(layer, excluded)
+	}
+
+	imported := overlaySettings(current, layer)
+
+	if len(issues) > 0 {
+		return imported, &SettingsValidationError{Issues: issues}
+	}
+
+	return imported, nil
+}
+
+// bundleChecksum hashes the canonical encoding of the settings mapping, so
+// reformatting by the YAML encoder does not invalidate a bundle
+func bundleChecksum(document *yaml.Node) (string, error) {
+	canonical, err := yaml.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("marshal bundle settings: %w", err)
+	}
+
+	sum := sha256.Sum256(canonical)
+
+	return bundleChecksumPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// removeMappingKeys drops keys from a document or mapping node
+func removeMappingKeys(node *yaml.Node, keys []string) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	drop := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		drop[key] = true
+	}
+
+	kept := make([]*yaml.Node, 0, len(node.Content))
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		if !drop[node.Content[index].Value] {
+			kept = append(kept, node.Content[index], node.Content[index+1])
+		}
+	}
+
+	node.Content = kept
+}
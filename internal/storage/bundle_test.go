@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"bytes"
+	"eagleeye/internal/core/model"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func bundleTestSettings() preferences.Settings {
+	settings := preferences.DefaultSettings()
+	settings.ShortInterval = 25 * time.Minute
+	settings.StrictMode = true
+	settings.RunOnStartup = true
+	settings.BreakTimerStarted = true
+	settings.Reminders = []model.ReminderConfig{
+		{Name: "hydrate", Interval: 45 * time.Minute, Presentation: model.PresentationNotification, Enabled: true},
+	}
+
+	return settings
+}
+
+// TestBundleRoundTripExcludesMachineFields verifies export/import and the default exclusions
+func TestBundleRoundTripExcludesMachineFields(t *testing.T) {
+	var buffer bytes.Buffer
+
+	if err := ExportBundle(&buffer, bundleTestSettings(), BundleOptions{}); err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+
+	for _, key := range []string{"run_on_startup", "break_timer_started"} {
+		if strings.Contains(buffer.String(), key) {
+			t.Fatalf("bundle contains %s, want excluded:\n%s", key, buffer.String())
+		}
+	}
+
+	current := preferences.DefaultSettings()
+	current.RunOnStartup = false
+
+	imported, err := ImportBundle(bytes.NewReader(buffer.Bytes()), current, BundleOptions{IncludeMachineSpecific: true})
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+
+	want := bundleTestSettings()
+	want.RunOnStartup = false
+	want.BreakTimerStarted = false
+
+	if !reflect.DeepEqual(imported, want) {
+		t.Fatalf("ImportBundle() = %+v, want %+v", imported, want)
+	}
+}
+
+// TestBundleIncludesMachineFieldsOnRequest verifies opt-in machine-specific export and import
+func TestBundleIncludesMachineFieldsOnRequest(t *testing.T) {
+	var buffer bytes.Buffer
+
+	if err := ExportBundle(&buffer, bundleTestSettings(), BundleOptions{IncludeMachineSpecific: true}); err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+
+	current := preferences.DefaultSettings()
+
+	skipped, err := ImportBundle(bytes.NewReader(buffer.Bytes()), current, BundleOptions{})
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+
+	if skipped.RunOnStartup != current.RunOnStartup {
+		t.Fatalf("RunOnStartup = %v, want current %v by default", skipped.RunOnStartup, current.RunOnStartup)
+	}
+
+	applied, err := ImportBundle(bytes.NewReader(buffer.Bytes()), current, BundleOptions{IncludeMachineSpecific: true})
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+
+	if !applied.RunOnStartup {
+		t.Fatalf("RunOnStartup = false, want true from bundle")
+	}
+}
+
+// TestImportBundleKeepsAbsentAndPersonalKeys verifies a bundle changes only
+// the keys it holds and leaves personal options alone by default
+func TestImportBundleKeepsAbsentAndPersonalKeys(t *testing.T) {
+	bundle := partialBundle(t, "schema_version: 2\nshort_interval: 25m\nlanguage: ru\nweekly_report: true\ncomfort_checkin_every: 3\nhistory_retention_days: 7\n")
+
+	current := bundleTestSettings()
+	current.Language = "en"
+	current.HistoryRetentionDays = 90
+
+	imported, err := ImportBundle(strings.NewReader(bundle), current, BundleOptions{})
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+
+	want := current
+	want.ShortInterval = 25 * time.Minute
+
+	if !reflect.DeepEqual(imported, want) {
+		t.Fatalf("ImportBundle() = %+v, want %+v", imported, want)
+	}
+
+	applied, err := ImportBundle(strings.NewReader(bundle), current, BundleOptions{IncludeMachineSpecific: true})
+	if err != nil {
+		t.Fatalf("ImportBundle() error = %v", err)
+	}
+
+	if applied.Language != "ru" || !applied.WeeklyReport || applied.CheckInEvery != 3 || applied.HistoryRetentionDays != 7 {
+		t.Fatalf("ImportBundle() = %+v, want personal keys from bundle on request", applied)
+	}
+}
+
+// partialBundle wraps a settings document holding only some keys in a
+// valid bundle
+func partialBundle(t *testing.T, settings string) string {
+	t.Helper()
+
+	document := parseMapping([]byte(settings))
+
+	checksum, err := bundleChecksum(document)
+	if err != nil {
+		t.Fatalf("bundleChecksum() error = %v", err)
+	}
+
+	serialized, err := yaml.Marshal(yamlBundle{
+		Format:        bundleFormat,
+		FormatVersion: bundleFormatVersion,
+		Checksum:      checksum,
+		Settings:      *document,
+	})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	return string(serialized)
+}
+
+// TestImportBundleRejectsDamagedFiles verifies format, version and checksum checks
+func TestImportBundleRejectsDamagedFiles(t *testing.T) {
+	var buffer bytes.Buffer
+
+	if err := ExportBundle(&buffer, bundleTestSettings(), BundleOptions{}); err != nil {
+		t.Fatalf("ExportBundle() error = %v", err)
+	}
+
+	exported := buffer.String()
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "tampered", data: strings.Replace(exported, "short_interval: 25m", "short_interval: 5m", 1), want: ErrBundleChecksum},
+		{name: "newer format", data: strings.Replace(exported, "format_version: 1", "format_version: 9", 1), want: ErrBundleTooNew},
+		{name: "plain settings", data: "schema_version: 2\nshort_interval_minutes: 20\n", want: ErrInvalidBundle},
+		{name: "not yaml", data: "format: [", want: ErrInvalidBundle},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			current := preferences.DefaultSettings()
+
+			imported, err := ImportBundle(strings.NewReader(test.data), current, BundleOptions{})
+			if !errors.Is(err, test.want) {
+				t.Fatalf("ImportBundle() error = %v, want %v", err, test.want)
+			}
+
+			if !reflect.DeepEqual(imported, current) {
+				t.Fatalf("ImportBundle() = %+v, want current settings unchanged", imported)
+			}
+		})
+	}
+}
+
+// TestDiffSettingsListsChangedFields verifies the import preview contents
+func TestDiffSettingsListsChangedFields(t *testing.T) {
+	before := preferences.DefaultSettings()
+	after := bundleTestSettings()
+	after.RunOnStartup = before.RunOnStartup
+	after.BreakTimerStarted = before.BreakTimerStarted
+
+	changes := DiffSettings(before, after)
+
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	want := []string{"reminders", "short_interval", "strict_mode"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Fatalf("DiffSettings() fields = %v, want %v", fields, want)
+	}
+
+	if got := changes[1].String(); got != "short_interval: 15m → 25m" {
+		t.Fatalf("change = %q, want short interval 15m → 25m", got)
+	}
+
+	if len(DiffSettings(before, before)) != 0 {
+		t.Fatalf("DiffSettings(same) not empty")
+	}
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SettingChange is one settings.yaml field that differs between two
+// settings snapshots, with values rendered for display
+type SettingChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// String formats the change as "field: old → new"
+func (change SettingChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", change.Field, change.Old, change.New)
+}
+
+// DiffSettings lists the settings.yaml fields that differ from before to
+// after, in field name order
+func DiffSettings(before, after preferences.Settings) []SettingChange {
+	beforeFields := settingsFields(before)
+	afterFields := settingsFields(after)
+
+	names := make([]string, 0, len(afterFields))
+	for name := range afterFields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var changes []SettingChange
+
+	for _, name := range names {
+		if beforeFields[name] != afterFields[name] {
+			changes = append(changes, SettingChange{
+				Field: name,
+				Old:   beforeFields[name],
+				New:   afterFields[name],
+			})
+		}
+	}
+
+	return changes
+}
+
+// settingsFields renders each settings.yaml field of settings as text
+func settingsFields(settings preferences.Settings) map[string]string {
+	fileData := yamlFromSettings(settings)
+
+	var document yaml.Node
+	if err := document.Encode(fileData); err != nil {
+		return map[string]string{}
+	}
+
+	fields := make(map[string]string, len(document.Content)/2+1)
+
+	for index := 0; index+1 < len(document.Content); index += 2 {
+		key, value := document.Content[index], document.Content[index+1]
+
+		if key.Value == schemaVersionKey {
+			continue
+		}
+
+		fields[key.Value] = value.Value
+	}
+
+	fields["reminders"] = describeReminders(fileData.Reminders)
+
+	return fields
+}
+
+// describeReminders summarizes reminders as "name (every 45m), ..."
+func describeReminders(reminders []yamlReminder) string {
+	if len(reminders) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, 0, len(reminders))
+
+	for _, reminder := range reminders {
+		part := fmt.Sprintf("%s (every %s)", reminder.Name, preferences.FormatDuration(time.Duration(reminder.Interval)))
+		if reminder.Enabled != nil && !*reminder.Enabled {
+			part += " off"
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
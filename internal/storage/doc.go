@@ -1,7 +1,28 @@
 // Package storage persists EagleEye user data on the local filesystem.
 //
-// The package currently stores preferences as YAML under the per-user config
-// directory, supports EAGLEEYE_CONFIG_PATH for settings overrides, and resolves
-// the JSONL application log path. Higher-level packages own application
-// behavior; storage is responsible for paths, serialization, and file I/O.
+// The package stores preferences as YAML in the config directory of the
+// platform layout, supports EAGLEEYE_CONFIG_PATH for settings overrides, and
+// resolves the JSONL application log path in the state directory.
+// MigrateLayout moves files left in the old single directory, or copies the
+// settings into a portable install. settings.yaml carries a schema_version;
+// older files are upgraded through the registered migration chain (keeping a
+// backup of the original, and the comments and key order of the file) and
+// files from newer builds are never overwritten.
+// Writes go through a synced temp file and rename, the last good file is kept
+// as settings.yaml.bak, and a file that fails to parse is quarantined with a
+// timestamp and replaced from that backup.
+// Intervals and durations are Go duration strings with second precision;
+// the older integer keys are still read and renamed on load.
+// Validate reports unknown, mistyped and out-of-range entries with line
+// numbers; loading skips those entries and decodes the rest strictly.
+// WatchSettings reloads the file when it is edited outside the app.
+// ExportBundle and ImportBundle move settings between machines as a
+// versioned, checksummed file.
+// SaveSettingsFrom and RecordSettingsChange append each change, its source
+// and a snapshot to a bounded journal that ReadJournal returns for rollback.
+// An administrator policy.yaml adds defaults under the user file and locked
+// values and bounds over it; LoadSettingsWithProvenance reports which layer
+// decided each key.
+// Higher-level packages own application behavior; storage is responsible for
+// paths, serialization, and file I/O.
 package storage
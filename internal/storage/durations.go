@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errDurationValue rejects bare numbers and other non-duration values
+var errDurationValue = errors.New("expected a duration such as 25m or 1m30s")
+
+// yamlDuration stores a duration in settings.yaml as a Go duration string
+// such as 25m or 1m30s, with second precision
+type yamlDuration time.Duration
+
+// MarshalYAML writes the compact duration string
+func (duration yamlDuration) MarshalYAML() (any, error) {
+	return preferences.FormatDuration(time.Duration(duration)), nil
+}
+
+// UnmarshalYAML reads a duration string, rounded to whole seconds
+func (duration *yamlDuration) UnmarshalYAML(value *yaml.Node) error {
+	parsed, err := parseDurationNode(value)
+	if err != nil {
+		return err
+	}
+
+	*duration = yamlDuration(parsed.(time.Duration))
+
+	return nil
+}
+
+// parseDurationNode decodes a scalar Go duration string. Bare numbers are
+// rejected because their unit would be ambiguous
+func parseDurationNode(value *yaml.Node) (any, error) {
+	if value.Kind != yaml.ScalarNode {
+		return nil, errDurationValue
+	}
+
+	parsed, err := time.ParseDuration(value.Value)
+	if err != nil {
+		return nil, errDurationValue
+	}
+
+	return parsed.Round(time.Second), nil
+}
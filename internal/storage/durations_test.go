@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestSaveSettingsKeepsSecondPrecision verifies durations round-trip as Go duration strings
+func TestSaveSettingsKeepsSecondPrecision(t *testing.T) {
+	configPath := writeTestSettings(t, []string{"schema_version: 3", ""})
+
+	settings := bundleTestSettings()
+	settings.ShortInterval = 12*time.Minute + 30*time.Second
+	settings.LongDuration = 90 * time.Second
+
+	if err := SaveSettings("EagleEyeMigrations", settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	rawData, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	for _, want := range []string{"short_interval: 12m30s", "long_duration: 1m30s", "interval: 45m"} {
+		if !strings.Contains(string(rawData), want) {
+			t.Fatalf("settings.yaml = %q, want %q", rawData, want)
+		}
+	}
+
+	loaded, err := LoadSettings("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+
+	if loaded.ShortInterval != settings.ShortInterval || loaded.LongDuration != settings.LongDuration {
+		t.Fatalf("loaded = %v/%v, want %v/%v", loaded.ShortInterval, loaded.LongDuration, settings.ShortInterval, settings.LongDuration)
+	}
+}
+
+// TestLoadSettingsReadsLegacyIntegerKeys verifies old keys still load and lose to duration keys
+func TestLoadSettingsReadsLegacyIntegerKeys(t *testing.T) {
+	writeTestSettings(t, []string{
+		"schema_version: 3",
+		"short_interval_minutes: 40",
+		"short_interval: 25m",
+		"long_duration_minutes: 7",
+		"reminders:",
+		"  - name: stretch",
+		"    interval_minutes: 30",
+		"",
+	})
+
+	settings, err := LoadSettings("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+
+	if settings.ShortInterval != 25*time.Minute {
+		t.Fatalf("ShortInterval = %v, want duration key 25m over legacy 40", settings.ShortInterval)
+	}
+
+	if settings.LongDuration != 7*time.Minute {
+		t.Fatalf("LongDuration = %v, want legacy 7 minutes", settings.LongDuration)
+	}
+
+	if len(settings.Reminders) != 1 || settings.Reminders[0].Interval != 30*time.Minute {
+		t.Fatalf("Reminders = %+v, want stretch every 30m", settings.Reminders)
+	}
+}
+
+// TestValidateRejectsUnitlessDurations verifies bare numbers need a unit in duration keys
+func TestValidateRejectsUnitlessDurations(t *testing.T) {
+	issues, err := Validate([]byte("schema_version: 3\nshort_interval: 25\nlong_duration: 0s\n"))
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if len(issues) != 2 || issues[0].Kind != IssueTypeMismatch || issues[1].Kind != IssueOutOfRange {
+		t.Fatalf("Validate() = %v, want a type mismatch and an out-of-range issue", issues)
+	}
+
+	var value yamlDuration
+	if err := value.UnmarshalYAML(scalarNode("25")); !errors.Is(err, errDurationValue) {
+		t.Fatalf("UnmarshalYAML(25) error = %v, want errDurationValue", err)
+	}
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/platform"
+	"eagleeye/internal/ui/preferences"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	journalFileName   = "settings-journal.jsonl"
+	maxJournalEntries = 100
+)
+
+// ChangeSource names what caused a journaled settings change
+type ChangeSource string
+
+const (
+	// ChangeFromPreferences is a save from the preferences window
+	ChangeFromPreferences ChangeSource = "preferences"
+	// ChangeFromFile is settings.yaml edited outside the app and reloaded
+	ChangeFromFile ChangeSource = "file"
+	// ChangeFromImport is an imported settings bundle
+	ChangeFromImport ChangeSource = "import"
+	// ChangeFromCLI is a setting changed from the command line
+	ChangeFromCLI ChangeSource = "cli"
+	// ChangeFromHistory is a revert or reset from the History view
+	ChangeFromHistory ChangeSource = "history"
+)
+
+// JournalEntry is one recorded settings change. Snapshot holds the
+// settings.yaml contents after the change so it can be restored later
+type JournalEntry struct {
+	Time     time.Time       `json:"time"`
+	Source   ChangeSource    `json:"source"`
+	Changes  []SettingChange `json:"changes"`
+	Snapshot string          `json:"snapshot"`
+}
+
+// Settings decodes the snapshot, upgrading it if it predates the current
+// schema and skipping entries that are no longer valid
+func (entry JournalEntry) Settings() (preferences.Settings, error) {
+	return settingsFromSnapshot([]byte(entry.Snapshot))
+}
+
+// ReadJournal returns the recorded settings changes, oldest first. Damaged
+// lines are skipped and a missing journal is empty
+func ReadJournal(appName string) ([]JournalEntry, error) {
+	journalPath, err := resolveJournalPath(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	return readJournalFile(journalPath)
+}
+
+// RecordSettingsChange journals a change that reached settings.yaml without
+// SaveSettings, such as an edit made in a text editor. Changes limited to
+// runtime state are not recorded
+func RecordSettingsChange(appName string, source ChangeSource, before, after preferences.Settings) error {
+	snapshot, err := yaml.Marshal(yamlFromSettings(after))
+	if err != nil {
+		return fmt.Errorf("marshal settings snapshot: %w", err)
+	}
+
+	return appendJournal(appName, source, before, after, snapshot)
+}
+
+// appendJournal adds an entry for the differences between before and after,
+// dropping the oldest entries beyond maxJournalEntries
+func appendJournal(appName string, source ChangeSource, before, after preferences.Settings, snapshot []byte) error {
+	changes := journalChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	journalPath, err := resolveJournalPath(appName)
+	if err != nil {
+		return err
+	}
+
+	entries, err := readJournalFile(journalPath)
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, JournalEntry{
+		Time:     time.Now().UTC(),
+		Source:   source,
+		Changes:  changes,
+		Snapshot: string(snapshot),
+	})
+
+	if len(entries) > maxJournalEntries {
+		entries = entries[len(entries)-maxJournalEntries:]
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("encode journal entry: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(journalPath), 0o700); err != nil {
+		return fmt.Errorf("create journal directory: %w", err)
+	}
+
+	if err := atomicfile.Write(journalPath, buffer.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write settings journal: %w", err)
+	}
+
+	return nil
+}
+
+// journalChanges is DiffSettings without runtime state fields
+func journalChanges(before, after preferences.Settings) []SettingChange {
+	var changes []SettingChange
+
+	for _, change := range DiffSettings(before, after) {
+		if !slices.Contains(runtimeStateKeys, change.Field) {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+func readJournalFile(journalPath string) ([]JournalEntry, error) {
+	file, err := os.Open(journalPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("open settings journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSettingsFileSize*2)
+
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Snapshot == "" {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("read settings journal: %w", err)
+	}
+
+	return entries, nil
+}
+
+// settingsFromSnapshot decodes settings.yaml contents the way LoadSettings
+// reads the user layer, without the policy and override layers
+func settingsFromSnapshot(rawData []byte) (preferences.Settings, error) {
+	if len(bytes.TrimSpace(rawData)) == 0 {
+		return preferences.DefaultSettings(), nil
+	}
+
+	migrated, _, err := migrateSettingsData(rawData)
+	if err != nil {
+		return preferences.DefaultSettings(), err
+	}
+
+	cleaned, _, err := inspectSettings(migrated)
+	if err != nil {
+		return preferences.DefaultSettings(), err
+	}
+
+	return settingsFromMapping(parseMapping(cleaned), preferences.DefaultSettings()), nil
+}
+
+// resolveJournalPath keeps the journal in the state directory, or next to a
+// settings file chosen through EAGLEEYE_CONFIG_PATH so each profile has its
+// own history
+func resolveJournalPath(appName string) (string, error) {
+	if configPath, ok := os.LookupEnv(configPathEnv); ok && configPath != "" {
+		return filepath.Join(filepath.Dir(filepath.Clean(configPath)), journalFileName), nil
+	}
+
+	layout, err := platform.ResolveLayout(appName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(layout.StateDir, journalFileName), nil
+}
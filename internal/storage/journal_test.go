@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSaveSettingsJournalsChanges verifies each save records its source, diff and snapshot
+func TestSaveSettingsJournalsChanges(t *testing.T) {
+	setUserConfigEnv(t, t.TempDir())
+
+	const appName = "EagleEyeJournal"
+
+	first := preferences.DefaultSettings()
+	first.ShortInterval = 25 * time.Minute
+
+	if err := SaveSettings(appName, first); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	second := first
+	second.StrictMode = true
+
+	if err := SaveSettingsFrom(appName, second, ChangeFromImport); err != nil {
+		t.Fatalf("SaveSettingsFrom() error = %v", err)
+	}
+
+	entries, err := ReadJournal(appName)
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+
+	if entries[0].Source != ChangeFromPreferences || entries[1].Source != ChangeFromImport {
+		t.Fatalf("sources = %s, %s, want preferences, import", entries[0].Source, entries[1].Source)
+	}
+
+	want := []SettingChange{{Field: "strict_mode", Old: "false", New: "true"}}
+	if !reflect.DeepEqual(entries[1].Changes, want) {
+		t.Fatalf("Changes = %+v, want %+v", entries[1].Changes, want)
+	}
+
+	restored, err := entries[0].Settings()
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(restored, first) {
+		t.Fatalf("Settings() = %+v, want %+v", restored, first)
+	}
+}
+
+// TestSaveSettingsSkipsRuntimeOnlyChanges verifies timer state saves leave the journal alone
+func TestSaveSettingsSkipsRuntimeOnlyChanges(t *testing.T) {
+	setUserConfigEnv(t, t.TempDir())
+
+	const appName = "EagleEyeJournalRuntime"
+
+	settings := preferences.DefaultSettings()
+	settings.BreakTimerStarted = true
+
+	if err := SaveSettings(appName, settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	entries, err := ReadJournal(appName)
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("len(entries) = %d, want 0", len(entries))
+	}
+}
+
+// TestJournalKeepsNewestEntries verifies the journal is bounded and skips damaged lines
+func TestJournalKeepsNewestEntries(t *testing.T) {
+	configRoot := t.TempDir()
+	configPath := filepath.Join(configRoot, "settings.yaml")
+	t.Setenv(configPathEnv, configPath)
+	t.Setenv(policyPathEnv, filepath.Join(configRoot, "no-policy.yaml"))
+
+	const appName = "EagleEyeJournalBound"
+
+	journalPath := filepath.Join(configRoot, journalFileName)
+	if err := os.WriteFile(journalPath, []byte("{\"time\": \"trunc\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	settings := preferences.DefaultSettings()
+
+	for index := 1; index <= maxJournalEntries+5; index++ {
+		before := settings
+		settings.ShortInterval = time.Duration(index) * time.Minute
+
+		if err := RecordSettingsChange(appName, ChangeFromFile, before, settings); err != nil {
+			t.Fatalf("RecordSettingsChange() error = %v", err)
+		}
+	}
+
+	entries, err := ReadJournal(appName)
+	if err != nil {
+		t.Fatalf("ReadJournal() error = %v", err)
+	}
+
+	if len(entries) != maxJournalEntries {
+		t.Fatalf("len(entries) = %d, want %d", len(entries), maxJournalEntries)
+	}
+
+	if got := entries[len(entries)-1].Changes[0].New; got != "1h45m" {
+		t.Fatalf("newest change = %s, want 1h45m", got)
+	}
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SettingSource names the layer a setting's effective value came from
+type SettingSource string
+
+const (
+	SourceDefault       SettingSource = "default"
+	SourcePolicyDefault SettingSource = "policy_default"
+	SourceUser          SettingSource = "user"
+	SourceOverride      SettingSource = "override"
+	SourceLocked        SettingSource = "locked"
+	SourceBounded       SettingSource = "bounded"
+)
+
+// FieldProvenance records where one setting came from. Origin names the
+// variable or flag of an override; Bound is set for settings the policy
+// constrains, whether or not the value was clamped
+type FieldProvenance struct {
+	Source SettingSource
+	Origin string
+	Bound  *Bound
+}
+
+// Provenance maps settings.yaml keys to the layer that decided them
+type Provenance map[string]FieldProvenance
+
+// Source returns the layer for key, defaulting to SourceDefault
+func (provenance Provenance) Source(key string) SettingSource {
+	if field, ok := provenance[key]; ok {
+		return field.Source
+	}
+
+	return SourceDefault
+}
+
+// LoadSettingsWithProvenance is LoadSettings that also reports which layer
+// decided every setting: built-in defaults, policy defaults, the user file,
+// environment or flag overrides, or policy locks and bounds
+func LoadSettingsWithProvenance(appName string) (preferences.Settings, Provenance, error) {
+	configPath, err := resolveConfigPath(appName)
+
+	if err != nil {
+		return preferences.DefaultSettings(), newProvenance(), err
+	}
+
+	return loadSettingsLayers(configPath)
+}
+
+// mergeSettingsLayers combines the user mapping with overrides and the policy
+// into one settings.yaml mapping, lowest precedence first
+func mergeSettingsLayers(user *yaml.Node, overrides Overrides, policy Policy) (*yaml.Node, Provenance) {
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	provenance := newProvenance()
+
+	overlayMapping(merged, policy.defaults, provenance, SourcePolicyDefault)
+	overlayMapping(merged, user, provenance, SourceUser)
+	overlayMapping(merged, overrides.entries, provenance, SourceOverride)
+	overlayMapping(merged, policy.locked, provenance, SourceLocked)
+
+	for key, origin := range overrides.origins {
+		if field := provenance[key]; field.Source == SourceOverride {
+			field.Origin = origin
+			provenance[key] = field
+		}
+	}
+
+	return merged, provenance
+}
+
+// applyPolicyBounds clamps settings and marks bounded keys in provenance
+func applyPolicyBounds(settings preferences.Settings, policy Policy, provenance Provenance) preferences.Settings {
+	settings, clamped := policy.clamp(settings)
+
+	for key, bound := range policy.bounds {
+		bound := bound
+		field := provenance[key]
+		field.Bound = &bound
+		provenance[key] = field
+	}
+
+	for _, key := range clamped {
+		field := provenance[key]
+		field.Source = SourceBounded
+		provenance[key] = field
+	}
+
+	return settings
+}
+
+func newProvenance() Provenance {
+	provenance := make(Provenance, len(settingsRules))
+
+	for key, rule := range settingsRules {
+		if key != schemaVersionKey && rule.renamed == "" {
+			provenance[key] = FieldProvenance{Source: SourceDefault}
+		}
+	}
+
+	return provenance
+}
+
+// overlayMapping copies every entry of layer into target, recording source
+func overlayMapping(target, layer *yaml.Node, provenance Provenance, source SettingSource) {
+	if layer == nil {
+		return
+	}
+
+	for index := 0; index+1 < len(layer.Content); index += 2 {
+		key := layer.Content[index].Value
+
+		setMappingValue(target, key, layer.Content[index+1])
+
+		if key != schemaVersionKey {
+			provenance[key] = FieldProvenance{Source: source}
+		}
+	}
+}
+
+// overlaySettings returns settings with the entries of layer applied on top
+func overlaySettings(settings preferences.Settings, layer *yaml.Node) preferences.Settings {
+	var document yaml.Node
+
+	if err := document.Encode(yamlFromSettings(settings)); err != nil {
+		return settings
+	}
+
+	overlayMapping(&document, layer, newProvenance(), SourceUser)
+
+	return settingsFromMapping(&document, settings)
+}
+
+// settingsFromMapping decodes a validated settings mapping onto defaults,
+// returning fallback if the mapping cannot be decoded
+func settingsFromMapping(mapping *yaml.Node, fallback preferences.Settings) preferences.Settings {
+	rawData, err := yaml.Marshal(mapping)
+	if err != nil {
+		return fallback
+	}
+
+	fileData, err := decodeSettingsStrict(rawData)
+	if err != nil {
+		return fallback
+	}
+
+	settings := preferences.DefaultSettings()
+	applyYamlSettings(&settings, fileData)
+
+	return settings
+}
+
+// mappingValue returns the value for key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil {
+		return nil
+	}
+
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		if mapping.Content[index].Value == key {
+			return mapping.Content[index+1]
+		}
+	}
+
+	return nil
+}
+
+// mappingKeys lists the keys of a mapping node in name order
+func mappingKeys(mapping *yaml.Node) []string {
+	if mapping == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(mapping.Content)/2)
+
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		keys = append(keys, mapping.Content[index].Value)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// setMappingValue replaces the value for key or appends a new entry
+func setMappingValue(mapping *yaml.Node, key string, value *yaml.Node) {
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		if mapping.Content[index].Value == key {
+			mapping.Content[index+1] = value
+
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// userOwnedMapping encodes settings for the user file while keeping the file's
+// existing values for keys that another layer owns, so forced values never
+// leak into the user's own settings
+func userOwnedMapping(settings preferences.Settings, previous *yaml.Node, foreignKeys []string) (*yaml.Node, error) {
+	var document yaml.Node
+
+	if err := document.Encode(yamlFromSettings(settings)); err != nil {
+		return nil, err
+	}
+
+	for _, key := range foreignKeys {
+		if value := mappingValue(previous, key); value != nil {
+			setMappingValue(&document, key, value)
+		} else {
+			removeMappingKeys(&document, []string{key})
+		}
+	}
+
+	return &document, nil
+}
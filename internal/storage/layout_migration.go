@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/platform"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// layoutMove relocates one file from the legacy directory into the layout.
+// Copies leave the source for an installed copy that still uses it
+type layoutMove struct {
+	name      string
+	targetDir string
+	copy      bool
+}
+
+// MigrateLayout moves files that older releases kept together in the user
+// config directory to where the current layout expects them. Portable
+// installs copy the settings once so the installed copy keeps its own.
+// Existing targets are never overwritten. It returns the paths written
+func MigrateLayout(appName string) ([]string, error) {
+	layout, err := platform.ResolveLayout(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyDir, err := platform.LegacyAppDir(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrateLayout(legacyDir, layout)
+}
+
+func migrateLayout(legacyDir string, layout platform.Layout) ([]string, error) {
+	var (
+		written []string
+		errs    []error
+	)
+
+	for _, move := range layoutMoves(layout) {
+		source := filepath.Join(legacyDir, move.name)
+		target := filepath.Join(move.targetDir, move.name)
+
+		if source == target || !fileExists(source) || fileExists(target) {
+			continue
+		}
+
+		if err := relocateFile(source, target, move.copy); err != nil {
+			errs = append(errs, fmt.Errorf("migrate %s: %w", move.name, err))
+
+			continue
+		}
+
+		written = append(written, target)
+	}
+
+	return written, errors.Join(errs...)
+}
+
+func layoutMoves(layout platform.Layout) []layoutMove {
+	if layout.Portable {
+		return []layoutMove{
+			{name: settingsFileName, targetDir: layout.ConfigDir, copy: true},
+			{name: settingsFileName + backupSuffix, targetDir: layout.ConfigDir, copy: true},
+		}
+	}
+
+	return []layoutMove{
+		{name: logFileName, targetDir: layout.StateDir},
+		{name: platform.ActivationSecretFileName, targetDir: layout.RuntimeDir},
+	}
+}
+
+// relocateFile renames source to target, falling back to copy and remove
+// when the two directories sit on different filesystems
+func relocateFile(source, target string, keepSource bool) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	if !keepSource && os.Rename(source, target) == nil {
+		return nil
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return fmt.Errorf("stat source: %w", err)
+	}
+
+	rawData, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("read source: %w", err)
+	}
+
+	if err := atomicfile.Write(target, rawData, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	if keepSource {
+		return nil
+	}
+
+	if err := os.Remove(source); err != nil {
+		return fmt.Errorf("remove source: %w", err)
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
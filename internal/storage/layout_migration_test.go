@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"eagleeye/internal/platform"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeLegacyFiles fills a legacy app directory with one file per name
+func writeLegacyFiles(t *testing.T, names ...string) string {
+	t.Helper()
+
+	legacyDir := t.TempDir()
+
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(legacyDir, name), []byte(name), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	return legacyDir
+}
+
+// TestMigrateLayoutMovesLogAndSecret verifies the split layout moves runtime and state files
+func TestMigrateLayoutMovesLogAndSecret(t *testing.T) {
+	legacyDir := writeLegacyFiles(t, settingsFileName, logFileName, platform.ActivationSecretFileName)
+	root := t.TempDir()
+	layout := platform.Layout{
+		ConfigDir:  legacyDir,
+		StateDir:   filepath.Join(root, "state"),
+		RuntimeDir: filepath.Join(root, "run"),
+	}
+
+	written, err := migrateLayout(legacyDir, layout)
+	if err != nil {
+		t.Fatalf("migrateLayout() error = %v", err)
+	}
+
+	if len(written) != 2 {
+		t.Fatalf("migrateLayout() wrote %v, want log and secret", written)
+	}
+
+	for _, path := range []string{
+		filepath.Join(layout.StateDir, logFileName),
+		filepath.Join(layout.RuntimeDir, platform.ActivationSecretFileName),
+		filepath.Join(legacyDir, settingsFileName),
+	} {
+		if !fileExists(path) {
+			t.Fatalf("%s missing after migration", path)
+		}
+	}
+
+	if fileExists(filepath.Join(legacyDir, logFileName)) {
+		t.Fatalf("legacy log still present, want moved")
+	}
+}
+
+// TestMigrateLayoutCopiesSettingsIntoPortableDir verifies portable mode leaves the installed copy intact
+func TestMigrateLayoutCopiesSettingsIntoPortableDir(t *testing.T) {
+	legacyDir := writeLegacyFiles(t, settingsFileName, logFileName)
+	dataDir := filepath.Join(t.TempDir(), "EagleEyeData")
+	layout := platform.Layout{ConfigDir: dataDir, StateDir: dataDir, RuntimeDir: dataDir, Portable: true}
+
+	if _, err := migrateLayout(legacyDir, layout); err != nil {
+		t.Fatalf("migrateLayout() error = %v", err)
+	}
+
+	if !fileExists(filepath.Join(dataDir, settingsFileName)) || !fileExists(filepath.Join(legacyDir, settingsFileName)) {
+		t.Fatalf("settings.yaml not copied into %s with the original kept", dataDir)
+	}
+
+	if fileExists(filepath.Join(dataDir, logFileName)) {
+		t.Fatalf("portable migration copied the log, want settings only")
+	}
+}
+
+// TestMigrateLayoutNeverOverwritesTargets verifies files already in place win
+func TestMigrateLayoutNeverOverwritesTargets(t *testing.T) {
+	legacyDir := writeLegacyFiles(t, logFileName)
+	stateDir := t.TempDir()
+	targetPath := filepath.Join(stateDir, logFileName)
+
+	if err := os.WriteFile(targetPath, []byte("current"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	written, err := migrateLayout(legacyDir, platform.Layout{ConfigDir: legacyDir, StateDir: stateDir, RuntimeDir: stateDir})
+	if err != nil || len(written) != 0 {
+		t.Fatalf("migrateLayout() = %v, %v, want nothing written", written, err)
+	}
+
+	rawData, err := os.ReadFile(targetPath)
+	if err != nil || string(rawData) != "current" {
+		t.Fatalf("target = %q, %v, want current contents kept", rawData, err)
+	}
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// settingsSchemaVersion is the settings.yaml schema written by this build
+const settingsSchemaVersion = 3
+
+const schemaVersionKey = "schema_version"
+
+// ErrSettingsTooNew reports a settings file written by a newer EagleEye. Such
+// files are neither loaded nor overwritten
+var ErrSettingsTooNew = errors.New("settings file was written by a newer version")
+
+// settingsMigration upgrades the top-level mapping of a settings document by
+// one schema version. Steps edit the parsed nodes in place, so comments and
+// key order of a hand-edited file survive the upgrade
+type settingsMigration func(mapping *yaml.Node) error
+
+// settingsMigrations is the upgrade chain; entry N upgrades version N to N+1.
+// Append new steps here and bump settingsSchemaVersion together
+var settingsMigrations = []settingsMigration{
+	migrateV0AddRunOnStartup,
+	migrateV1AddSchemaVersion,
+	migrateV2DurationStrings,
+}
+
+// migrateV0AddRunOnStartup fills run_on_startup for files written before the
+// autostart toggle existed, keeping every other user customisation
+func migrateV0AddRunOnStartup(mapping *yaml.Node) error {
+	if mappingValue(mapping, "run_on_startup") != nil {
+		return nil
+	}
+
+	var value yaml.Node
+	if err := value.Encode(preferences.DefaultSettings().RunOnStartup); err != nil {
+		return err
+	}
+
+	setMappingValue(mapping, "run_on_startup", &value)
+
+	return nil
+}
+
+// migrateV1AddSchemaVersion marks the first explicitly versioned schema. The
+// field layout is unchanged; schema_version is stamped by the chain runner
+func migrateV1AddSchemaVersion(*yaml.Node) error {
+	return nil
+}
+
+// migrateV2DurationStrings replaces the whole-minute and whole-second
+// integer fields with duration strings, including reminder intervals. Values
+// that are not integers are left for validation to report
+func migrateV2DurationStrings(mapping *yaml.Node) error {
+	renameLegacyDurations(mapping, settingsRules)
+
+	reminders := mappingValue(mapping, "reminders")
+	if reminders == nil || reminders.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, entry := range reminders.Content {
+		if entry.Kind == yaml.MappingNode {
+			renameLegacyDurations(entry, reminderRules)
+		}
+	}
+
+	return nil
+}
+
+// renameLegacyDurations rewrites the legacy integer keys of rules in mapping
+// where they stand, keeping their comments
+func renameLegacyDurations(mapping *yaml.Node, rules map[string]fieldRule) {
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		key, value := mapping.Content[index], mapping.Content[index+1]
+
+		rule, ok := rules[key.Value]
+		if !ok || rule.renamed == "" {
+			continue
+		}
+
+		count, ok := nodeInt(value)
+		if !ok {
+			continue
+		}
+
+		if mappingValue(mapping, rule.renamed) != nil {
+			mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+			index -= 2
+
+			continue
+		}
+
+		key.Value = rule.renamed
+		value.Tag = "!!str"
+		value.Style = 0
+		value.Value = preferences.FormatDuration(time.Duration(count) * rule.unit)
+	}
+}
+
+// nodeInt decodes a scalar integer node
+func nodeInt(value *yaml.Node) (int, bool) {
+	if value.Kind != yaml.ScalarNode || value.ShortTag() != "!!int" {
+		return 0, false
+	}
+
+	var number int
+	if err := value.Decode(&number); err != nil {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// detectSchemaVersion returns the schema of a settings mapping. Unversioned
+// files with run_on_startup are version 1, older ones version 0
+func detectSchemaVersion(mapping *yaml.Node) (int, error) {
+	value := mappingValue(mapping, schemaVersionKey)
+
+	if value == nil {
+		if mappingValue(mapping, "run_on_startup") != nil {
+			return 1, nil
+		}
+
+		return 0, nil
+	}
+
+	version, ok := nodeInt(value)
+	if !ok || version < 0 {
+		return 0, fmt.Errorf("invalid %s %v", schemaVersionKey, nodeText(value))
+	}
+
+	return version, nil
+}
+
+// migrateSettingsDocument upgrades mapping from version to the current
+// schema one registered step at a time
+func migrateSettingsDocument(mapping *yaml.Node, version int) error {
+	if version > settingsSchemaVersion {
+		return fmt.Errorf("%w: schema %d, supported %d", ErrSettingsTooNew, version, settingsSchemaVersion)
+	}
+
+	for step := version; step < settingsSchemaVersion; step++ {
+		if err := settingsMigrations[step](mapping); err != nil {
+			return fmt.Errorf("migrate settings schema %d to %d: %w", step, step+1, err)
+		}
+
+		stampSchemaVersion(mapping, step+1)
+	}
+
+	return nil
+}
+
+// stampSchemaVersion sets schema_version, adding it as the first key of
+// files that had none. A comment heading the file stays on top
+func stampSchemaVersion(mapping *yaml.Node, version int) {
+	number := strconv.Itoa(version)
+
+	if value := mappingValue(mapping, schemaVersionKey); value != nil {
+		value.Tag = "!!int"
+		value.Style = 0
+		value.Value = number
+
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: schemaVersionKey}
+	if len(mapping.Content) > 0 {
+		key.HeadComment, mapping.Content[0].HeadComment = mapping.Content[0].HeadComment, ""
+	}
+
+	mapping.Content = append([]*yaml.Node{key, {Kind: yaml.ScalarNode, Tag: "!!int", Value: number}}, mapping.Content...)
+}
+
+// backupSettingsFile keeps the pre-migration file next to the settings file
+func backupSettingsFile(configPath string, version int, rawData []byte) (string, error) {
+	backupPath := fmt.Sprintf("%s.v%d.bak", configPath, version)
+
+	if err := atomicfile.Write(backupPath, rawData, 0o600); err != nil {
+		return "", fmt.Errorf("write settings backup: %w", err)
+	}
+
+	return backupPath, nil
+}
@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestSettingsMigrationChainMatchesSchemaVersion keeps the registry in sync
+func TestSettingsMigrationChainMatchesSchemaVersion(t *testing.T) {
+	if len(settingsMigrations) != settingsSchemaVersion {
+		t.Fatalf("len(settingsMigrations) = %d, want %d", len(settingsMigrations), settingsSchemaVersion)
+	}
+}
+
+// TestLoadSettingsMigratesHistoricalShapes covers every settings.yaml layout
+// that has shipped, checking the loaded values and the upgrade side effects
+func TestLoadSettingsMigratesHistoricalShapes(t *testing.T) {
+	tests := []struct {
+		name          string
+		lines         []string
+		wantVersion   int
+		wantBackup    bool
+		wantShort     time.Duration
+		wantAutostart bool
+		wantLanguage  string
+		wantStarted   bool
+		wantReminders int
+	}{
+		{
+			name:          "empty file",
+			lines:         []string{""},
+			wantVersion:   0,
+			wantBackup:    true,
+			wantShort:     15 * time.Minute,
+			wantAutostart: true,
+			wantLanguage:  "en",
+		},
+		{
+			name: "v0 legacy without run_on_startup",
+			lines: []string{
+				"short_interval_minutes: 1",
+				"short_duration_seconds: 2",
+				"long_interval_minutes: 3",
+				"long_duration_minutes: 4",
+				"strict_mode: true",
+				"idle_enabled: false",
+				"overlay_opacity: 0.95",
+				"fullscreen: true",
+				"language: ru",
+			},
+			wantVersion:   0,
+			wantBackup:    true,
+			wantShort:     time.Minute,
+			wantAutostart: true,
+			wantLanguage:  "ru",
+		},
+		{
+			name: "v1 with run_on_startup",
+			lines: []string{
+				"short_interval_minutes: 20",
+				"run_on_startup: false",
+				"language: en",
+			},
+			wantVersion:  1,
+			wantBackup:   true,
+			wantShort:    20 * time.Minute,
+			wantLanguage: "en",
+		},
+		{
+			name: "v1 with timer state and reminders",
+			lines: []string{
+				"short_interval_minutes: 25",
+				"run_on_startup: true",
+				"break_timer_started: true",
+				"reminders:",
+				"  - name: hydrate",
+				"    interval_minutes: 45",
+			},
+			wantVersion:   1,
+			wantBackup:    true,
+			wantShort:     25 * time.Minute,
+			wantAutostart: true,
+			wantLanguage:  "en",
+			wantStarted:   true,
+			wantReminders: 1,
+		},
+		{
+			name: "v2 with integer durations",
+			lines: []string{
+				"schema_version: 2",
+				"short_interval_minutes: 30",
+				"run_on_startup: true",
+			},
+			wantVersion:   2,
+			wantBackup:    true,
+			wantShort:     30 * time.Minute,
+			wantAutostart: true,
+			wantLanguage:  "en",
+		},
+		{
+			name: "v3 current",
+			lines: []string{
+				"schema_version: 3",
+				"short_interval: 12m30s",
+				"run_on_startup: true",
+				"reminders:",
+				"  - name: hydrate",
+				"    interval: 90s",
+			},
+			wantVersion:   3,
+			wantShort:     12*time.Minute + 30*time.Second,
+			wantAutostart: true,
+			wantLanguage:  "en",
+			wantReminders: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := writeTestSettings(t, tt.lines)
+			original, _ := os.ReadFile(configPath)
+
+			loaded, err := LoadSettings("EagleEyeMigrations")
+			if err != nil {
+				t.Fatalf("LoadSettings() error = %v", err)
+			}
+
+			if loaded.ShortInterval != tt.wantShort || loaded.RunOnStartup != tt.wantAutostart || loaded.Language != tt.wantLanguage {
+				t.Fatalf("loaded = %+v, want short %s, autostart %t, language %s", loaded, tt.wantShort, tt.wantAutostart, tt.wantLanguage)
+			}
+
+			if loaded.BreakTimerStarted != tt.wantStarted || len(loaded.Reminders) != tt.wantReminders {
+				t.Fatalf("loaded timer state/reminders = %t/%d, want %t/%d", loaded.BreakTimerStarted, len(loaded.Reminders), tt.wantStarted, tt.wantReminders)
+			}
+
+			backupPath := fmt.Sprintf("%s.v%d.bak", configPath, tt.wantVersion)
+			backup, err := os.ReadFile(backupPath)
+
+			if tt.wantBackup {
+				if err != nil || string(backup) != string(original) {
+					t.Fatalf("backup %s = %q, %v; want original contents", backupPath, backup, err)
+				}
+			} else if err == nil {
+				t.Fatalf("unexpected backup %s for current schema", backupPath)
+			}
+
+			if version := readTestSchemaVersion(t, configPath); version != settingsSchemaVersion {
+				t.Fatalf("schema_version on disk = %d, want %d", version, settingsSchemaVersion)
+			}
+		})
+	}
+}
+
+// TestLoadSettingsMigrationKeepsCommentsAndOrder verifies an upgraded
+// hand-edited file keeps its comments and key order
+func TestLoadSettingsMigrationKeepsCommentsAndOrder(t *testing.T) {
+	configPath := writeTestSettings(t, []string{
+		"# my break routine",
+		"strict_mode: true",
+		"short_interval_minutes: 25 # pomodoro",
+		"language: en",
+	})
+
+	if _, err := LoadSettings("EagleEyeMigrations"); err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+
+	migrated, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	want := "# my break routine\nschema_version: 3\nstrict_mode: true\nshort_interval: 25m # pomodoro\nlanguage: en\nrun_on_startup: true\n"
+	if string(migrated) != want {
+		t.Fatalf("migrated file = %q, want %q", migrated, want)
+	}
+}
+
+// TestLoadSettingsRefusesNewerSchema verifies newer files are left untouched
+func TestLoadSettingsRefusesNewerSchema(t *testing.T) {
+	configPath := writeTestSettings(t, []string{
+		"schema_version: 99",
+		"short_interval_minutes: 7",
+		"run_on_startup: true",
+	})
+	original, _ := os.ReadFile(configPath)
+
+	if _, err := LoadSettings("EagleEyeMigrations"); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("LoadSettings() error = %v, want ErrSettingsTooNew", err)
+	}
+
+	if err := SaveSettings("EagleEyeMigrations", preferences.DefaultSettings()); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("SaveSettings() error = %v, want ErrSettingsTooNew", err)
+	}
+
+	current, _ := os.ReadFile(configPath)
+	if string(current) != string(original) {
+		t.Fatalf("newer settings file was modified:\n%s", current)
+	}
+
+	matches, _ := filepath.Glob(configPath + ".v*.bak")
+	if len(matches) != 0 {
+		t.Fatalf("unexpected backups for newer schema: %v", matches)
+	}
+}
+
+// TestLoadSettingsRejectsInvalidSchemaVersion verifies malformed versions fail loudly
+func TestLoadSettingsRejectsInvalidSchemaVersion(t *testing.T) {
+	writeTestSettings(t, []string{"schema_version: two", "run_on_startup: true"})
+
+	if _, err := LoadSettings("EagleEyeMigrations"); err == nil {
+		t.Fatalf("LoadSettings() error = nil, want invalid schema_version error")
+	}
+}
+
+func writeTestSettings(t *testing.T, lines []string) string {
+	t.Helper()
+
+	setUserConfigEnv(t, t.TempDir())
+
+	configPath, err := resolveConfigPath("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("resolveConfigPath() error = %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return configPath
+}
+
+func readTestSchemaVersion(t *testing.T, configPath string) int {
+	t.Helper()
+
+	raw, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	var fileData yamlSettings
+	if err := yaml.Unmarshal(raw, &fileData); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	return fileData.SchemaVersion
+}
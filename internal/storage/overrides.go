@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// overrideEnvPrefix prefixes the environment variable for every settings key,
+// e.g. EAGLEEYE_SHORT_INTERVAL=25m
+const overrideEnvPrefix = "EAGLEEYE_"
+
+// Overrides is the process-only layer built from environment variables and
+// --set flags. It sits above the user file and below policy locks, and is
+// never written back to settings.yaml
+type Overrides struct {
+	entries *yaml.Node
+	origins map[string]string
+}
+
+// activeOverrides is the layer applied by every load and save in this process
+var activeOverrides = struct {
+	mu        sync.Mutex
+	overrides Overrides
+}{}
+
+// ParseOverrides reads EAGLEEYE_<KEY> variables from environ and key=value
+// assignments, which take precedence. Values are YAML, so lists such as
+// reminders can be given in flow style. Invalid entries are skipped and
+// reported through *SettingsValidationError alongside the usable overrides
+func ParseOverrides(environ []string, assignments []string) (Overrides, error) {
+	overrides := Overrides{
+		entries: &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		origins: map[string]string{},
+	}
+
+	var issues []SettingsIssue
+
+	env := make(map[string]string, len(environ))
+	for _, entry := range environ {
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			env[name] = value
+		}
+	}
+
+	for _, key := range sortedKeys(settingsRules) {
+		// EAGLEEYE_LOG_LEVEL belongs to the logger, which reads it itself
+		if key == schemaVersionKey || key == "log_level" {
+			continue
+		}
+
+		name := overrideEnvPrefix + strings.ToUpper(key)
+		if value, ok := env[name]; ok {
+			issues = overrides.add(key, value, name, issues)
+		}
+	}
+
+	for _, assignment := range assignments {
+		key, value, ok := strings.Cut(assignment, "=")
+		key = strings.TrimSpace(key)
+
+		if !ok || key == "" {
+			issues = append(issues, SettingsIssue{
+				Field:   "--set " + assignment,
+				Kind:    IssueTypeMismatch,
+				Message: "expected key=value",
+			})
+
+			continue
+		}
+
+		issues = overrides.add(key, value, "--set "+key, issues)
+	}
+
+	issues = append(issues, overrides.prune()...)
+
+	if len(issues) > 0 {
+		return overrides, &SettingsValidationError{Issues: issues}
+	}
+
+	return overrides, nil
+}
+
+// add parses one raw value and stores it under key
+func (overrides *Overrides) add(key, raw, origin string, issues []SettingsIssue) []SettingsIssue {
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: raw}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &document); err == nil && len(document.Content) > 0 {
+		value = document.Content[0]
+	} else if err != nil && strings.ContainsAny(raw, "[{") {
+		return append(issues, SettingsIssue{Field: origin, Kind: IssueTypeMismatch, Message: err.Error()})
+	}
+
+	setMappingValue(overrides.entries, key, value)
+	overrides.origins[key] = origin
+
+	return issues
+}
+
+// prune validates the collected entries with the settings rules
+func (overrides *Overrides) prune() []SettingsIssue {
+	issues := pruneMapping(overrides.entries, settingsRules, "")
+	removeMappingKeys(overrides.entries, []string{schemaVersionKey})
+
+	for index := range issues {
+		key, _, _ := strings.Cut(issues[index].Field, "[")
+		key, _, _ = strings.Cut(key, ".")
+
+		if origin, ok := overrides.origins[key]; ok {
+			issues[index].Field = origin
+		}
+
+		issues[index].Line = 0
+	}
+
+	for key, origin := range overrides.origins {
+		if renamed := settingsRules[key].renamed; renamed != "" {
+			if _, ok := overrides.origins[renamed]; !ok {
+				overrides.origins[renamed] = origin
+			}
+
+			delete(overrides.origins, key)
+		}
+	}
+
+	for key := range overrides.origins {
+		if mappingValue(overrides.entries, key) == nil {
+			delete(overrides.origins, key)
+		}
+	}
+
+	return issues
+}
+
+// IsZero reports whether no setting is overridden
+func (overrides Overrides) IsZero() bool {
+	return len(overrides.origins) == 0
+}
+
+// Keys lists overridden settings.yaml keys in name order
+func (overrides Overrides) Keys() []string {
+	keys := make([]string, 0, len(overrides.origins))
+
+	for key := range overrides.origins {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Origin names the variable or flag that overrides key
+func (overrides Overrides) Origin(key string) string {
+	return overrides.origins[key]
+}
+
+// Apply returns settings with the overridden values in place
+func (overrides Overrides) Apply(settings preferences.Settings) preferences.Settings {
+	if overrides.IsZero() {
+		return settings
+	}
+
+	return overlaySettings(settings, overrides.entries)
+}
+
+// String lists the overrides for logs
+func (overrides Overrides) String() string {
+	parts := make([]string, 0, len(overrides.origins))
+
+	for _, key := range overrides.Keys() {
+		parts = append(parts, fmt.Sprintf("%s from %s", key, overrides.origins[key]))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// SetOverrides activates overrides for every later load, reload and save in
+// this process
+func SetOverrides(overrides Overrides) {
+	activeOverrides.mu.Lock()
+	defer activeOverrides.mu.Unlock()
+
+	activeOverrides.overrides = overrides
+}
+
+func currentOverrides() Overrides {
+	activeOverrides.mu.Lock()
+	defer activeOverrides.mu.Unlock()
+
+	return activeOverrides.overrides
+}
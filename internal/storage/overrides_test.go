@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// activateTestOverrides parses and activates overrides for one test
+func activateTestOverrides(t *testing.T, environ []string, assignments []string) Overrides {
+	t.Helper()
+
+	overrides, err := ParseOverrides(environ, assignments)
+	if err != nil {
+		t.Fatalf("ParseOverrides() error = %v", err)
+	}
+
+	SetOverrides(overrides)
+	t.Cleanup(func() { SetOverrides(Overrides{}) })
+
+	return overrides
+}
+
+// TestParseOverridesPrefersFlagsOverEnvironment verifies sources, precedence and YAML values
+func TestParseOverridesPrefersFlagsOverEnvironment(t *testing.T) {
+	overrides, err := ParseOverrides(
+		[]string{
+			"EAGLEEYE_SHORT_INTERVAL_MINUTES=40",
+			"EAGLEEYE_STRICT_MODE=true",
+			"EAGLEEYE_LOG_LEVEL=debug",
+			"EAGLEEYE_LOG_LEVEL_OVERLAY=warn",
+			"HOME=/tmp",
+		},
+		[]string{"short_interval_minutes=25", "reminders=[{name: water, interval_minutes: 30}]"},
+	)
+	if err != nil {
+		t.Fatalf("ParseOverrides() error = %v", err)
+	}
+
+	if got, want := strings.Join(overrides.Keys(), ","), "log_level_overlay,reminders,short_interval,strict_mode"; got != want {
+		t.Fatalf("Keys() = %s, want %s", got, want)
+	}
+
+	if got := overrides.Origin("short_interval"); got != "--set short_interval_minutes" {
+		t.Fatalf("Origin(short_interval) = %q, want the --set flag", got)
+	}
+
+	if got := overrides.Origin("strict_mode"); got != "EAGLEEYE_STRICT_MODE" {
+		t.Fatalf("Origin(strict_mode) = %q, want EAGLEEYE_STRICT_MODE", got)
+	}
+
+	settings := overrides.Apply(bundleTestSettings())
+
+	if settings.ShortInterval != 25*time.Minute || !settings.StrictMode {
+		t.Fatalf("Apply() = %+v, want short interval 25m and strict mode", settings)
+	}
+
+	if len(settings.Reminders) != 1 || settings.Reminders[0].Name != "water" {
+		t.Fatalf("Reminders = %+v, want the overridden water reminder", settings.Reminders)
+	}
+}
+
+// TestParseOverridesSkipsInvalidEntries verifies bad overrides are reported with their origin
+func TestParseOverridesSkipsInvalidEntries(t *testing.T) {
+	overrides, err := ParseOverrides(
+		[]string{"EAGLEEYE_OVERLAY_OPACITY=2"},
+		[]string{"strict_mod=true", "fullscreen", "idle_enabled=no-thanks", "language=ru"},
+	)
+
+	var validation *SettingsValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("ParseOverrides() error = %v, want *SettingsValidationError", err)
+	}
+
+	if len(validation.Issues) != 4 {
+		t.Fatalf("Issues = %v, want 4", validation.Issues)
+	}
+
+	message := validation.Error()
+	for _, want := range []string{"EAGLEEYE_OVERLAY_OPACITY", "did you mean strict_mode", "--set fullscreen", "--set idle_enabled"} {
+		if !strings.Contains(message, want) {
+			t.Fatalf("Error() = %q, want it to mention %q", message, want)
+		}
+	}
+
+	if got := strings.Join(overrides.Keys(), ","); got != "language" {
+		t.Fatalf("Keys() = %s, want only the valid language override", got)
+	}
+}
+
+// TestOverridesLayerAboveFileAndBelowLocks verifies load precedence and provenance
+func TestOverridesLayerAboveFileAndBelowLocks(t *testing.T) {
+	writeTestSettings(t, []string{"schema_version: 2", "short_interval_minutes: 45", "strict_mode: false", ""})
+	writeTestPolicy(t, []string{"locked:", "  strict_mode: false", ""})
+	activateTestOverrides(t, []string{"EAGLEEYE_SHORT_INTERVAL_MINUTES=20"}, []string{"strict_mode=true"})
+
+	settings, provenance, err := LoadSettingsWithProvenance("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("LoadSettingsWithProvenance() error = %v", err)
+	}
+
+	if settings.ShortInterval != 20*time.Minute {
+		t.Fatalf("ShortInterval = %v, want overridden 20m", settings.ShortInterval)
+	}
+
+	if settings.StrictMode {
+		t.Fatalf("StrictMode = true, want the policy lock to win over --set")
+	}
+
+	field := provenance["short_interval"]
+	if field.Source != SourceOverride || field.Origin != "EAGLEEYE_SHORT_INTERVAL_MINUTES" {
+		t.Fatalf("provenance = %+v, want override from EAGLEEYE_SHORT_INTERVAL_MINUTES", field)
+	}
+
+	if got := provenance.Source("strict_mode"); got != SourceLocked {
+		t.Fatalf("Source(strict_mode) = %s, want %s", got, SourceLocked)
+	}
+}
+
+// TestSaveSettingsKeepsOverridesOutOfUserFile verifies overrides are never written back
+func TestSaveSettingsKeepsOverridesOutOfUserFile(t *testing.T) {
+	configPath := writeTestSettings(t, []string{"schema_version: 2", "short_interval_minutes: 45", ""})
+	activateTestOverrides(t, nil, []string{"short_interval_minutes=20", "fullscreen=true"})
+
+	settings, err := LoadSettings("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+
+	settings.IdleEnabled = !settings.IdleEnabled
+
+	if err := SaveSettings("EagleEyeMigrations", settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	rawData, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	if !strings.Contains(string(rawData), "short_interval: 45m") {
+		t.Fatalf("settings.yaml = %q, want the file's short interval kept", rawData)
+	}
+
+	if strings.Contains(string(rawData), "fullscreen") {
+		t.Fatalf("settings.yaml = %q, want overridden fullscreen omitted", rawData)
+	}
+}
@@ -0,0 +1,340 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	policyFileName = "policy.yaml"
+	policyPathEnv  = "EAGLEEYE_POLICY_PATH"
+)
+
+// Bound limits a numeric setting; nil ends are open. Duration settings are
+// bounded in seconds
+type Bound struct {
+	Min *float64
+	Max *float64
+
+	duration bool
+}
+
+// String formats the bound as "min X, max Y"
+func (bound Bound) String() string {
+	switch {
+	case bound.Min != nil && bound.Max != nil:
+		return fmt.Sprintf("min %s, max %s", bound.format(*bound.Min), bound.format(*bound.Max))
+	case bound.Min != nil:
+		return fmt.Sprintf("min %s", bound.format(*bound.Min))
+	case bound.Max != nil:
+		return fmt.Sprintf("max %s", bound.format(*bound.Max))
+	default:
+		return "unbounded"
+	}
+}
+
+func (bound Bound) format(value float64) string {
+	if bound.duration {
+		return preferences.FormatDuration(time.Duration(value * float64(time.Second)))
+	}
+
+	return fmt.Sprintf("%g", value)
+}
+
+// clamp limits value to the bound
+func (bound Bound) clamp(value float64) float64 {
+	if bound.Min != nil && value < *bound.Min {
+		value = *bound.Min
+	}
+
+	if bound.Max != nil && value > *bound.Max {
+		value = *bound.Max
+	}
+
+	return value
+}
+
+// Policy is the administrator layer read from the system-wide policy file.
+// Defaults apply where the user file is silent; Locked values and Bounds win
+// over every other layer. The zero Policy imposes nothing
+type Policy struct {
+	Path string
+
+	defaults *yaml.Node
+	locked   *yaml.Node
+	bounds   map[string]Bound
+}
+
+// yamlPolicy mirrors policy.yaml. Defaults and locked use settings.yaml keys
+type yamlPolicy struct {
+	Defaults yaml.Node            `yaml:"defaults"`
+	Locked   yaml.Node            `yaml:"locked"`
+	Bounds   map[string]yamlBound `yaml:"bounds"`
+}
+
+// yamlBound holds raw limits: numbers, or duration strings for durations
+type yamlBound struct {
+	Min yaml.Node `yaml:"min"`
+	Max yaml.Node `yaml:"max"`
+}
+
+// numericField reads and writes one bounded setting in settings.yaml units
+type numericField struct {
+	get      func(settings preferences.Settings) float64
+	set      func(settings *preferences.Settings, value float64)
+	duration bool
+}
+
+// boundedFields lists the settings that policy bounds may constrain
+var boundedFields = map[string]numericField{
+	"short_interval": durationField(func(settings *preferences.Settings) *time.Duration { return &settings.ShortInterval }),
+	"short_duration": durationField(func(settings *preferences.Settings) *time.Duration { return &settings.ShortDuration }),
+	"long_interval":  durationField(func(settings *preferences.Settings) *time.Duration { return &settings.LongInterval }),
+	"long_duration":  durationField(func(settings *preferences.Settings) *time.Duration { return &settings.LongDuration }),
+	"overlay_opacity": {
+		get: func(settings preferences.Settings) float64 { return settings.OverlayOpacity },
+		set: func(settings *preferences.Settings, value float64) { settings.OverlayOpacity = value },
+	},
+}
+
+// durationField exposes a duration setting in seconds
+func durationField(pick func(settings *preferences.Settings) *time.Duration) numericField {
+	return numericField{
+		get: func(settings preferences.Settings) float64 {
+			return float64(*pick(&settings)) / float64(time.Second)
+		},
+		set: func(settings *preferences.Settings, value float64) {
+			*pick(settings) = time.Duration(value * float64(time.Second))
+		},
+		duration: true,
+	}
+}
+
+// LoadPolicy reads the administrator policy file. A missing file yields the
+// zero Policy. Invalid entries are skipped and reported through
+// *SettingsValidationError alongside the usable policy
+func LoadPolicy() (Policy, error) {
+	return loadPolicyFile(resolvePolicyPath())
+}
+
+func loadPolicyFile(policyPath string) (Policy, error) {
+	policy := Policy{Path: policyPath}
+
+	stat, err := os.Stat(policyPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return policy, nil
+		}
+
+		return policy, fmt.Errorf("stat policy file: %w", err)
+	}
+
+	if stat.Size() > maxSettingsFileSize {
+		return policy, fmt.Errorf("policy file exceeds %d bytes", maxSettingsFileSize)
+	}
+
+	rawData, err := os.ReadFile(policyPath)
+	if err != nil {
+		return policy, fmt.Errorf("read policy file: %w", err)
+	}
+
+	var fileData yamlPolicy
+	if err := yaml.Unmarshal(rawData, &fileData); err != nil {
+		return policy, fmt.Errorf("parse policy yaml: %w", err)
+	}
+
+	var issues []SettingsIssue
+
+	policy.defaults, issues = policySection(&fileData.Defaults, "defaults", issues)
+	policy.locked, issues = policySection(&fileData.Locked, "locked", issues)
+	policy.bounds, issues = policyBounds(fileData.Bounds, issues)
+
+	if len(issues) > 0 {
+		return policy, &SettingsValidationError{Issues: issues}
+	}
+
+	return policy, nil
+}
+
+// policySection validates a defaults or locked mapping with the settings rules
+func policySection(node *yaml.Node, name string, issues []SettingsIssue) (*yaml.Node, []SettingsIssue) {
+	if node.Kind == 0 || node.ShortTag() == "!!null" {
+		return nil, issues
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil, append(issues, SettingsIssue{
+			Field:   "policy." + name,
+			Line:    node.Line,
+			Kind:    IssueTypeMismatch,
+			Message: "expected a mapping of settings",
+		})
+	}
+
+	issues = append(issues, pruneMapping(node, settingsRules, "policy."+name+".")...)
+	removeMappingKeys(node, []string{schemaVersionKey})
+
+	return node, issues
+}
+
+// policyBounds keeps bounds on numeric settings with a consistent range.
+// Duration bounds take duration strings or seconds; legacy integer keys keep
+// their old unit and apply to the duration key that replaced them
+func policyBounds(fileData map[string]yamlBound, issues []SettingsIssue) (map[string]Bound, []SettingsIssue) {
+	bounds := make(map[string]Bound, len(fileData))
+
+	for _, key := range sortedKeys(fileData) {
+		entry := fileData[key]
+		field := "policy.bounds." + key
+		target, unit := key, time.Second
+
+		if rule := settingsRules[key]; rule.renamed != "" {
+			target, unit = rule.renamed, rule.unit
+		}
+
+		numeric, ok := boundedFields[target]
+		if !ok {
+			issues = append(issues, SettingsIssue{Field: field, Kind: IssueUnknownKey, Message: "bounds apply only to interval, duration and opacity settings"})
+
+			continue
+		}
+
+		if !numeric.duration {
+			unit = 0
+		}
+
+		bound := Bound{duration: numeric.duration}
+
+		var minErr, maxErr error
+		bound.Min, minErr = boundLimit(&entry.Min, unit)
+		bound.Max, maxErr = boundLimit(&entry.Max, unit)
+
+		if err := errors.Join(minErr, maxErr); err != nil {
+			issues = append(issues, SettingsIssue{Field: field, Line: entry.Min.Line, Kind: IssueTypeMismatch, Message: err.Error()})
+
+			continue
+		}
+
+		if bound.Min != nil && bound.Max != nil && *bound.Min > *bound.Max {
+			issues = append(issues, SettingsIssue{Field: field, Kind: IssueOutOfRange, Message: fmt.Sprintf("%s: min is above max", bound)})
+
+			continue
+		}
+
+		bounds[target] = bound
+	}
+
+	return bounds, issues
+}
+
+// boundLimit reads one end of a bound. A zero unit marks a plain number;
+// otherwise numbers count unit and duration strings are accepted, both
+// returned in seconds
+func boundLimit(node *yaml.Node, unit time.Duration) (*float64, error) {
+	if node.Kind == 0 || node.ShortTag() == "!!null" {
+		return nil, nil
+	}
+
+	var number float64
+	if err := node.Decode(&number); err == nil {
+		if unit != 0 {
+			number *= float64(unit) / float64(time.Second)
+		}
+
+		return &number, nil
+	}
+
+	if unit != 0 {
+		if parsed, err := parseDurationNode(node); err == nil {
+			seconds := parsed.(time.Duration).Seconds()
+
+			return &seconds, nil
+		}
+	}
+
+	kind := kindFloat
+	if unit != 0 {
+		kind = kindDuration
+	}
+
+	return nil, fmt.Errorf("expected %s, got %q", kindName(kind), node.Value)
+}
+
+// IsZero reports whether the policy imposes nothing
+func (policy Policy) IsZero() bool {
+	return policy.defaults == nil && policy.locked == nil && len(policy.bounds) == 0
+}
+
+// Locked reports whether the policy locks key
+func (policy Policy) Locked(key string) bool {
+	return mappingValue(policy.locked, key) != nil
+}
+
+// LockedKeys lists locked settings.yaml keys in name order
+func (policy Policy) LockedKeys() []string {
+	return mappingKeys(policy.locked)
+}
+
+// BoundFor returns the bound on key, if any
+func (policy Policy) BoundFor(key string) (Bound, bool) {
+	bound, ok := policy.bounds[key]
+
+	return bound, ok
+}
+
+// Enforce applies locked values and bounds to settings, so edits from any
+// source cannot escape the policy
+func (policy Policy) Enforce(settings preferences.Settings) preferences.Settings {
+	if policy.locked != nil {
+		settings = overlaySettings(settings, policy.locked)
+	}
+
+	settings, _ = policy.clamp(settings)
+
+	return settings
+}
+
+// clamp applies bounds and returns the keys whose values changed
+func (policy Policy) clamp(settings preferences.Settings) (preferences.Settings, []string) {
+	var clamped []string
+
+	for _, key := range sortedKeys(policy.bounds) {
+		field := boundedFields[key]
+		value := field.get(settings)
+
+		if limited := policy.bounds[key].clamp(value); limited != value {
+			field.set(&settings, limited)
+			clamped = append(clamped, key)
+		}
+	}
+
+	return settings, clamped
+}
+
+// resolvePolicyPath returns the policy file path with env override support
+func resolvePolicyPath() string {
+	if policyPath, ok := os.LookupEnv(policyPathEnv); ok && policyPath != "" {
+		return filepath.Clean(policyPath)
+	}
+
+	return filepath.Join(systemPolicyDir(), policyFileName)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,6 @@
+package storage
+
+// systemPolicyDir returns the machine-wide policy directory
+func systemPolicyDir() string {
+	return "/Library/Application Support/EagleEye"
+}
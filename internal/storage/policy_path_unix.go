@@ -0,0 +1,8 @@
+//go:build !windows && !darwin
+
+package storage
+
+// systemPolicyDir returns the machine-wide policy directory
+func systemPolicyDir() string {
+	return "/etc/eagleeye"
+}
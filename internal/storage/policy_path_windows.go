@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// systemPolicyDir returns the machine-wide policy directory
+func systemPolicyDir() string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" {
+		programData = `C:\ProgramData`
+	}
+
+	return filepath.Join(programData, "EagleEye")
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestPolicy points the policy path at a temp file with lines
+func writeTestPolicy(t *testing.T, lines []string) string {
+	t.Helper()
+
+	policyPath := filepath.Join(t.TempDir(), policyFileName)
+	t.Setenv(policyPathEnv, policyPath)
+
+	if err := os.WriteFile(policyPath, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	return policyPath
+}
+
+// TestLoadSettingsMergesPolicyLayers verifies precedence and provenance of every layer
+func TestLoadSettingsMergesPolicyLayers(t *testing.T) {
+	writeTestSettings(t, []string{
+		"schema_version: 2",
+		"short_interval_minutes: 45",
+		"strict_mode: false",
+		"idle_enabled: false",
+		"",
+	})
+	writeTestPolicy(t, []string{
+		"defaults:",
+		"  long_interval_minutes: 55",
+		"  idle_enabled: true",
+		"locked:",
+		"  strict_mode: true",
+		"bounds:",
+		"  short_interval_minutes: {min: 10, max: 30}",
+		"  short_duration_seconds: {min: 10}",
+		"",
+	})
+
+	settings, provenance, err := LoadSettingsWithProvenance("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("LoadSettingsWithProvenance() error = %v", err)
+	}
+
+	if !settings.StrictMode {
+		t.Fatalf("StrictMode = false, want locked true")
+	}
+
+	if settings.ShortInterval != 30*time.Minute {
+		t.Fatalf("ShortInterval = %v, want clamped 30m", settings.ShortInterval)
+	}
+
+	if settings.LongInterval != 55*time.Minute {
+		t.Fatalf("LongInterval = %v, want policy default 55m", settings.LongInterval)
+	}
+
+	if settings.IdleEnabled {
+		t.Fatalf("IdleEnabled = true, want user value over policy default")
+	}
+
+	tests := map[string]SettingSource{
+		"strict_mode":    SourceLocked,
+		"short_interval": SourceBounded,
+		"short_duration": SourceDefault,
+		"long_interval":  SourcePolicyDefault,
+		"idle_enabled":   SourceUser,
+		"fullscreen":     SourceDefault,
+	}
+
+	for key, want := range tests {
+		if got := provenance.Source(key); got != want {
+			t.Fatalf("Source(%s) = %s, want %s", key, got, want)
+		}
+	}
+
+	if provenance["short_duration"].Bound == nil {
+		t.Fatalf("short_duration Bound = nil, want policy bound")
+	}
+
+	if got := provenance["short_interval"].Bound.String(); got != "min 10m, max 30m" {
+		t.Fatalf("short_interval Bound = %q, want legacy minutes read as min 10m, max 30m", got)
+	}
+}
+
+// TestSaveSettingsKeepsLockedValuesOutOfUserFile verifies forced values never reach settings.yaml
+func TestSaveSettingsKeepsLockedValuesOutOfUserFile(t *testing.T) {
+	configPath := writeTestSettings(t, []string{"schema_version: 2", "strict_mode: false", ""})
+	writeTestPolicy(t, []string{"locked:", "  strict_mode: true", "  fullscreen: true", ""})
+
+	settings, err := LoadSettings("EagleEyeMigrations")
+	if err != nil {
+		t.Fatalf("LoadSettings() error = %v", err)
+	}
+
+	if err := SaveSettings("EagleEyeMigrations", settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	rawData, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	if !strings.Contains(string(rawData), "strict_mode: false") {
+		t.Fatalf("settings.yaml = %q, want user strict_mode kept", rawData)
+	}
+
+	if strings.Contains(string(rawData), "fullscreen") {
+		t.Fatalf("settings.yaml = %q, want locked fullscreen omitted", rawData)
+	}
+}
+
+// TestLoadPolicyReportsInvalidEntries verifies bad policy entries are skipped, not fatal
+func TestLoadPolicyReportsInvalidEntries(t *testing.T) {
+	policyPath := writeTestPolicy(t, []string{
+		"locked:",
+		"  strict_mode: maybe",
+		"  idle_enabled: true",
+		"bounds:",
+		"  long_interval_minutes: {min: 60, max: 30}",
+		"  language: {min: 1}",
+		"",
+	})
+
+	policy, err := loadPolicyFile(policyPath)
+
+	var validation *SettingsValidationError
+	if !errors.As(err, &validation) || len(validation.Issues) != 3 {
+		t.Fatalf("loadPolicyFile() error = %v, want 3 issues", err)
+	}
+
+	if !policy.Locked("idle_enabled") || policy.Locked("strict_mode") {
+		t.Fatalf("LockedKeys() = %v, want only idle_enabled", policy.LockedKeys())
+	}
+
+	if _, ok := policy.BoundFor("long_interval"); ok {
+		t.Fatalf("BoundFor(long_interval) kept an inverted range")
+	}
+}
+
+// TestPolicyEnforceAppliesLocksAndBounds verifies runtime edits cannot escape the policy
+func TestPolicyEnforceAppliesLocksAndBounds(t *testing.T) {
+	policyPath := writeTestPolicy(t, []string{
+		"locked:",
+		"  fullscreen: true",
+		"bounds:",
+		"  overlay_opacity: {max: 0.8}",
+		"",
+	})
+
+	policy, err := loadPolicyFile(policyPath)
+	if err != nil {
+		t.Fatalf("loadPolicyFile() error = %v", err)
+	}
+
+	settings := policy.Enforce(bundleTestSettings())
+
+	if !settings.Fullscreen || settings.OverlayOpacity != 0.8 {
+		t.Fatalf("Enforce() = fullscreen %v opacity %v, want true and 0.8", settings.Fullscreen, settings.OverlayOpacity)
+	}
+
+	if settings.ShortInterval != bundleTestSettings().ShortInterval || len(settings.Reminders) != 1 {
+		t.Fatalf("Enforce() changed unlocked settings: %+v", settings)
+	}
+}
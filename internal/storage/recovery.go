@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	backupSuffix      = ".bak"
+	quarantineSuffix  = ".corrupt-"
+	quarantineStamp   = "20060102T150405"
+	recoveryCauseText = "settings file is corrupt"
+)
+
+// errCorruptSettings marks settings files that exist but cannot be parsed
+var errCorruptSettings = errors.New(recoveryCauseText)
+
+// SettingsRecovery reports that LoadSettings repaired a corrupt settings file.
+// It is returned as the error alongside usable settings; RestoredFrom is empty
+// when no good backup existed and defaults were used instead
+type SettingsRecovery struct {
+	QuarantinePath string
+	RestoredFrom   string
+	Cause          error
+}
+
+// Error describes the recovery for logs
+func (recovery *SettingsRecovery) Error() string {
+	if recovery.RestoredFrom == "" {
+		return fmt.Sprintf("settings reset to defaults, corrupt file moved to %s: %v", recovery.QuarantinePath, recovery.Cause)
+	}
+
+	return fmt.Sprintf("settings restored from %s, corrupt file moved to %s: %v", recovery.RestoredFrom, recovery.QuarantinePath, recovery.Cause)
+}
+
+// Unwrap returns the parse failure that triggered the recovery
+func (recovery *SettingsRecovery) Unwrap() error {
+	return recovery.Cause
+}
+
+// recoverSettings quarantines a corrupt settings file and restores the last
+// good backup, falling back to defaults when the backup is missing or broken
+func recoverSettings(configPath string, cause error) (preferences.Settings, error) {
+	quarantinePath := configPath + quarantineSuffix + time.Now().Format(quarantineStamp)
+
+	if err := os.Rename(configPath, quarantinePath); err != nil {
+		return preferences.DefaultSettings(), fmt.Errorf("quarantine corrupt settings: %w (after %w)", err, cause)
+	}
+
+	recovery := &SettingsRecovery{QuarantinePath: quarantinePath, Cause: cause}
+	backupPath := configPath + backupSuffix
+	backupData, err := os.ReadFile(backupPath)
+
+	if err != nil || !validSettingsData(backupData) {
+		return preferences.DefaultSettings(), recovery
+	}
+
+	if err := atomicfile.Write(configPath, backupData, 0o600); err != nil {
+		return preferences.DefaultSettings(), recovery
+	}
+
+	recordOwnWrite(configPath, backupData)
+
+	var validation *SettingsValidationError
+
+	settings, err := loadSettingsFile(configPath)
+	if err != nil && !errors.As(err, &validation) {
+		return preferences.DefaultSettings(), recovery
+	}
+
+	recovery.RestoredFrom = backupPath
+
+	return settings, recovery
+}
+
+// rotateSettingsBackup copies the current settings file to settings.yaml.bak
+// before it is replaced. Files that do not parse are never rotated in, so the
+// backup always holds the last good version. Newer-schema files block saving
+func rotateSettingsBackup(configPath string) error {
+	rawData, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil
+	}
+
+	version, err := detectSchemaVersion(parseMapping(rawData))
+	if err != nil {
+		return nil
+	}
+
+	if version > settingsSchemaVersion {
+		return fmt.Errorf("%w: schema %d, supported %d", ErrSettingsTooNew, version, settingsSchemaVersion)
+	}
+
+	if !validSettingsData(rawData) {
+		return nil
+	}
+
+	if err := atomicfile.Write(configPath+backupSuffix, rawData, 0o600); err != nil {
+		return fmt.Errorf("rotate settings backup: %w", err)
+	}
+
+	return nil
+}
+
+// validSettingsData reports whether data decodes as a supported settings file
+func validSettingsData(rawData []byte) bool {
+	if version, err := detectSchemaVersion(parseMapping(rawData)); err != nil || version > settingsSchemaVersion {
+		return false
+	}
+
+	var fileData yamlSettings
+
+	return yaml.Unmarshal(rawData, &fileData) == nil
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSaveSettingsRotatesBackup verifies the previous good file becomes settings.yaml.bak
+func TestSaveSettingsRotatesBackup(t *testing.T) {
+	setUserConfigEnv(t, t.TempDir())
+
+	appName := "EagleEyeBackup"
+	first := preferences.DefaultSettings()
+	first.ShortInterval = 25 * time.Minute
+
+	if err := SaveSettings(appName, first); err != nil {
+		t.Fatalf("SaveSettings(first) error = %v", err)
+	}
+
+	configPath, err := resolveConfigPath(appName)
+	if err != nil {
+		t.Fatalf("resolveConfigPath() error = %v", err)
+	}
+
+	if _, err := os.Stat(configPath + backupSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("backup after first save: Stat() error = %v, want not exist", err)
+	}
+
+	second := first
+	second.ShortInterval = 40 * time.Minute
+
+	if err := SaveSettings(appName, second); err != nil {
+		t.Fatalf("SaveSettings(second) error = %v", err)
+	}
+
+	backup, err := os.ReadFile(configPath + backupSuffix)
+	if err != nil {
+		t.Fatalf("ReadFile(backup) error = %v", err)
+	}
+
+	if !strings.Contains(string(backup), "short_interval: 25m") {
+		t.Fatalf("backup = %q, want previous short interval", backup)
+	}
+}
+
+// TestSaveSettingsKeepsGoodBackupOverCorruptFile verifies a broken file never replaces the backup
+func TestSaveSettingsKeepsGoodBackupOverCorruptFile(t *testing.T) {
+	configPath := writeTestSettings(t, []string{"schema_version: 2", "short_interval_minutes: 30"})
+	backupPath := configPath + backupSuffix
+
+	if err := os.WriteFile(backupPath, []byte("schema_version: 2\nshort_interval_minutes: 15\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile(backup) error = %v", err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("short_interval_minutes: [broken"), 0o600); err != nil {
+		t.Fatalf("WriteFile(config) error = %v", err)
+	}
+
+	if err := SaveSettings("EagleEyeMigrations", preferences.DefaultSettings()); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	backup, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("ReadFile(backup) error = %v", err)
+	}
+
+	if !strings.Contains(string(backup), "short_interval_minutes: 15") {
+		t.Fatalf("backup = %q, want last good contents", backup)
+	}
+}
+
+// TestLoadSettingsRecoversFromBackup verifies corrupt files are quarantined and the backup restored
+func TestLoadSettingsRecoversFromBackup(t *testing.T) {
+	configPath := writeTestSettings(t, []string{"short_interval_minutes: [broken"})
+
+	if err := os.WriteFile(configPath+backupSuffix, []byte("schema_version: 2\nshort_interval_minutes: 35\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile(backup) error = %v", err)
+	}
+
+	settings, err := LoadSettings("EagleEyeMigrations")
+
+	var recovery *SettingsRecovery
+	if !errors.As(err, &recovery) {
+		t.Fatalf("LoadSettings() error = %v, want *SettingsRecovery", err)
+	}
+
+	if recovery.RestoredFrom != configPath+backupSuffix {
+		t.Fatalf("RestoredFrom = %q, want %q", recovery.RestoredFrom, configPath+backupSuffix)
+	}
+
+	if settings.ShortInterval != 35*time.Minute {
+		t.Fatalf("ShortInterval = %v, want 35m", settings.ShortInterval)
+	}
+
+	assertQuarantined(t, configPath, recovery.QuarantinePath)
+
+	if _, err := LoadSettings("EagleEyeMigrations"); err != nil {
+		t.Fatalf("LoadSettings() after recovery error = %v, want nil", err)
+	}
+}
+
+// TestLoadSettingsRecoversToDefaults verifies defaults are used when no good backup exists
+func TestLoadSettingsRecoversToDefaults(t *testing.T) {
+	configPath := writeTestSettings(t, []string{"schema_version: \"two\""})
+
+	if err := os.WriteFile(configPath+backupSuffix, []byte("{not yaml"), 0o600); err != nil {
+		t.Fatalf("WriteFile(backup) error = %v", err)
+	}
+
+	settings, err := LoadSettings("EagleEyeMigrations")
+
+	var recovery *SettingsRecovery
+	if !errors.As(err, &recovery) {
+		t.Fatalf("LoadSettings() error = %v, want *SettingsRecovery", err)
+	}
+
+	if recovery.RestoredFrom != "" {
+		t.Fatalf("RestoredFrom = %q, want empty", recovery.RestoredFrom)
+	}
+
+	if settings.ShortInterval != preferences.DefaultSettings().ShortInterval {
+		t.Fatalf("ShortInterval = %v, want default", settings.ShortInterval)
+	}
+
+	assertQuarantined(t, configPath, recovery.QuarantinePath)
+
+	if _, err := os.Stat(configPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Stat(config) error = %v, want not exist", err)
+	}
+}
+
+func assertQuarantined(t *testing.T, configPath, quarantinePath string) {
+	t.Helper()
+
+	if !strings.HasPrefix(quarantinePath, configPath+quarantineSuffix) {
+		t.Fatalf("QuarantinePath = %q, want %s prefix", quarantinePath, configPath+quarantineSuffix)
+	}
+
+	if _, err := os.Stat(quarantinePath); err != nil {
+		t.Fatalf("Stat(quarantine) error = %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/platform"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const reportsDirName = "reports"
+
+// ResolveReportPath returns where a generated report named fileName lives in
+// the application state directory
+func ResolveReportPath(appName, fileName string) (string, error) {
+	layout, err := platform.ResolveLayout(appName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(layout.StateDir, reportsDirName, fileName), nil
+}
+
+// WriteReport saves a generated report and returns its path. Reports carry
+// personal usage data, so they are readable by the user only
+func WriteReport(appName, fileName string, data []byte) (string, error) {
+	reportPath, err := ResolveReportPath(appName, fileName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(reportPath), 0o700); err != nil {
+		return "", fmt.Errorf("create reports directory: %w", err)
+	}
+
+	if err := atomicfile.Write(reportPath, data, 0o600); err != nil {
+		return "", fmt.Errorf("write report: %w", err)
+	}
+
+	return reportPath, nil
+}
@@ -1,12 +1,16 @@
 package storage
 
 import (
+	"eagleeye/internal/atomicfile"
+	"eagleeye/internal/core/model"
+	"eagleeye/internal/platform"
 	"eagleeye/internal/ui/i18n"
 	"eagleeye/internal/ui/preferences"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,82 +19,168 @@ import (
 const (
 	settingsFileName    = "settings.yaml"
 	logFileName         = "EagleEye.log.jsonl"
+	historyFileName     = "breaks.jsonl"
+	goalsFileName       = "goals.json"
+	checkInFileName     = "comfort.jsonl"
+	activityFileName    = "activity.jsonl"
+	defaultProfile      = "default"
 	maxSettingsFileSize = 256 * 1024
 	configPathEnv       = "EAGLEEYE_CONFIG_PATH"
 )
 
 // yamlSettings mirrors the on-disk settings.yaml schema
 type yamlSettings struct {
-	ShortIntervalMinutes int     `yaml:"short_interval_minutes"`
-	ShortDurationSeconds int     `yaml:"short_duration_seconds"`
-	LongIntervalMinutes  int     `yaml:"long_interval_minutes"`
-	LongDurationMinutes  int     `yaml:"long_duration_minutes"`
-	StrictMode           bool    `yaml:"strict_mode"`
-	IdleEnabled          bool    `yaml:"idle_enabled"`
-	OverlayOpacity       float64 `yaml:"overlay_opacity"`
-	Fullscreen           bool    `yaml:"fullscreen"`
-	RunOnStartup         *bool   `yaml:"run_on_startup"`
-	Language             string  `yaml:"language"`
-	BreakTimerStarted    bool    `yaml:"break_timer_started"`
-}
-
-// LoadSettings reads user preferences from YAML or returns defaults
+	SchemaVersion     int          `yaml:"schema_version"`
+	ShortInterval     yamlDuration `yaml:"short_interval"`
+	ShortDuration     yamlDuration `yaml:"short_duration"`
+	LongInterval      yamlDuration `yaml:"long_interval"`
+	LongDuration      yamlDuration `yaml:"long_duration"`
+	StrictMode        bool         `yaml:"strict_mode"`
+	IdleEnabled       bool         `yaml:"idle_enabled"`
+	OverlayOpacity    float64      `yaml:"overlay_opacity"`
+	Fullscreen        bool         `yaml:"fullscreen"`
+	RunOnStartup      *bool        `yaml:"run_on_startup"`
+	Language          string       `yaml:"language"`
+	BreakTimerStarted bool         `yaml:"break_timer_started"`
+
+	Reminders []yamlReminder `yaml:"reminders,omitempty"`
+
+	HistoryRetentionDays *int `yaml:"history_retention_days"`
+	WeeklyReport         bool `yaml:"weekly_report"`
+	CheckInEvery         int  `yaml:"comfort_checkin_every"`
+
+	LogLevel           string `yaml:"log_level"`
+	TimekeeperLogLevel string `yaml:"log_level_timekeeper,omitempty"`
+	OverlayLogLevel    string `yaml:"log_level_overlay,omitempty"`
+	PlatformLogLevel   string `yaml:"log_level_platform,omitempty"`
+}
+
+// yamlReminder mirrors one custom reminder entry in settings.yaml. A missing
+// enabled key means the reminder is active
+type yamlReminder struct {
+	Name         string       `yaml:"name"`
+	Interval     yamlDuration `yaml:"interval"`
+	Message      string       `yaml:"message,omitempty"`
+	Presentation string       `yaml:"presentation,omitempty"`
+	Enabled      *bool        `yaml:"enabled,omitempty"`
+}
+
+// LoadSettings reads user preferences from YAML or returns defaults. A file
+// that fails to parse is quarantined and replaced by the last good backup; the
+// returned *SettingsRecovery error then accompanies usable settings. Entries
+// that are unknown, mistyped or out of range are skipped and reported through
+// *SettingsValidationError, again alongside usable settings
 func LoadSettings(appName string) (preferences.Settings, error) {
-	settings := preferences.DefaultSettings()
 	configPath, err := resolveConfigPath(appName)
 
 	if err != nil {
-		return settings, err
+		return preferences.DefaultSettings(), err
+	}
+
+	settings, err := loadSettingsFile(configPath)
+	if errors.Is(err, errCorruptSettings) {
+		return recoverSettings(configPath, err)
+	}
+
+	return settings, err
+}
+
+// loadSettingsFile reads, upgrades and decodes one settings file merged with
+// the policy layers. Parse failures are wrapped with errCorruptSettings;
+// ignored entries are reported through *SettingsValidationError alongside
+// the applied settings
+func loadSettingsFile(configPath string) (preferences.Settings, error) {
+	settings, _, err := loadSettingsLayers(configPath)
+
+	return settings, err
+}
+
+// loadSettingsLayers is loadSettingsFile that also reports provenance
+func loadSettingsLayers(configPath string) (preferences.Settings, Provenance, error) {
+	user, issues, err := readUserLayer(configPath)
+	if err != nil {
+		return preferences.DefaultSettings(), newProvenance(), err
 	}
 
+	policy, _ := loadPolicyFile(resolvePolicyPath())
+	merged, provenance := mergeSettingsLayers(user, currentOverrides(), policy)
+	settings := settingsFromMapping(merged, preferences.DefaultSettings())
+	settings = applyPolicyBounds(settings, policy, provenance)
+
+	if len(issues) > 0 {
+		return settings, provenance, &SettingsValidationError{Issues: issues}
+	}
+
+	return settings, provenance, nil
+}
+
+// readUserLayer reads, upgrades and validates the user settings file and
+// returns its valid entries as a mapping. A missing file is an empty layer
+func readUserLayer(configPath string) (*yaml.Node, []SettingsIssue, error) {
 	stat, err := os.Stat(configPath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return settings, nil
+			return nil, nil, nil
 		}
 
-		return settings, fmt.Errorf("stat settings file: %w", err)
+		return nil, nil, fmt.Errorf("stat settings file: %w", err)
 	}
 
 	if stat.Size() > maxSettingsFileSize {
-		return settings, fmt.Errorf("settings file exceeds %d bytes", maxSettingsFileSize)
+		return nil, nil, fmt.Errorf("settings file exceeds %d bytes", maxSettingsFileSize)
 	}
 
 	rawData, err := os.ReadFile(configPath)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return settings, nil
+			return nil, nil, nil
 		}
 
-		return settings, fmt.Errorf("read settings file: %w", err)
+		return nil, nil, fmt.Errorf("read settings file: %w", err)
 	}
 
-	var fileData yamlSettings
-	if err := yaml.Unmarshal(rawData, &fileData); err != nil {
-		return settings, fmt.Errorf("parse settings yaml: %w", err)
+	rawData, err = upgradeSettingsFile(configPath, rawData)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if fileData.RunOnStartup == nil {
-		defaultSettings := preferences.DefaultSettings()
+	cleaned, issues, err := inspectSettings(rawData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errCorruptSettings, err)
+	}
 
-		if err := os.Remove(configPath); err != nil && !errors.Is(err, os.ErrNotExist) {
-			return defaultSettings, fmt.Errorf("reset legacy settings: remove old file: %w", err)
-		}
+	if _, err := decodeSettingsStrict(cleaned); err != nil {
+		return nil, nil, fmt.Errorf("%w: parse settings yaml: %w", errCorruptSettings, err)
+	}
 
-		if err := SaveSettings(appName, defaultSettings); err != nil {
-			return defaultSettings, fmt.Errorf("reset legacy settings: save defaults: %w", err)
-		}
+	return parseMapping(cleaned), issues, nil
+}
+
+// parseMapping returns the top-level mapping of YAML data, or nil
+func parseMapping(rawData []byte) *yaml.Node {
+	var document yaml.Node
 
-		return defaultSettings, nil
+	if err := yaml.Unmarshal(rawData, &document); err != nil || len(document.Content) == 0 {
+		return nil
 	}
 
-	applyYamlSettings(&settings, fileData)
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
 
-	return settings, nil
+	return document.Content[0]
 }
 
-// SaveSettings writes user preferences to YAML with private file permissions
+// SaveSettings writes user preferences to YAML with private file permissions.
+// Keys locked by policy or overridden for this process keep the value the
+// user file already had. The change is journaled as a preferences save
 func SaveSettings(appName string, settings preferences.Settings) error {
+	return SaveSettingsFrom(appName, settings, ChangeFromPreferences)
+}
+
+// SaveSettingsFrom is SaveSettings that journals the change under source. A
+// journal failure is returned after the settings file has been written
+func SaveSettingsFrom(appName string, settings preferences.Settings, source ChangeSource) error {
 	configPath, err := resolveConfigPath(appName)
 
 	if err != nil {
@@ -101,47 +191,173 @@ func SaveSettings(appName string, settings preferences.Settings) error {
 		return fmt.Errorf("create config directory: %w", err)
 	}
 
-	fileData := yamlSettings{
-		ShortIntervalMinutes: int(settings.ShortInterval / time.Minute),
-		ShortDurationSeconds: int(settings.ShortDuration / time.Second),
-		LongIntervalMinutes:  int(settings.LongInterval / time.Minute),
-		LongDurationMinutes:  int(settings.LongDuration / time.Minute),
+	if err := rotateSettingsBackup(configPath); err != nil {
+		return err
+	}
 
-		StrictMode:        settings.StrictMode,
-		IdleEnabled:       settings.IdleEnabled,
-		OverlayOpacity:    settings.OverlayOpacity,
-		Fullscreen:        settings.Fullscreen,
-		RunOnStartup:      boolPointer(settings.RunOnStartup),
-		Language:          i18n.NormalizeLanguage(settings.Language),
-		BreakTimerStarted: settings.BreakTimerStarted,
+	policy, _ := loadPolicyFile(resolvePolicyPath())
+	previous, _ := os.ReadFile(configPath)
+
+	foreignKeys := append(policy.LockedKeys(), currentOverrides().Keys()...)
+
+	document, err := userOwnedMapping(settings, parseMapping(previous), foreignKeys)
+	if err != nil {
+		return fmt.Errorf("encode settings yaml: %w", err)
 	}
 
-	serialized, err := yaml.Marshal(fileData)
+	serialized, err := yaml.Marshal(document)
 
 	if err != nil {
 		return fmt.Errorf("marshal settings yaml: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, serialized, 0o600); err != nil {
+	if err := atomicfile.Write(configPath, serialized, 0o600); err != nil {
 		return fmt.Errorf("write settings file: %w", err)
 	}
 
-	if err := os.Chmod(configPath, 0o600); err != nil {
-		return fmt.Errorf("secure settings file permissions: %w", err)
+	recordOwnWrite(configPath, serialized)
+
+	before, _ := settingsFromSnapshot(previous)
+	after, _ := settingsFromSnapshot(serialized)
+
+	if err := appendJournal(appName, source, before, after, serialized); err != nil {
+		return fmt.Errorf("record settings change: %w", err)
 	}
 
 	return nil
 }
 
-// ResolveLogPath returns the per-user JSONL log path for the application
+// upgradeSettingsFile migrates an older settings file to the current schema,
+// backing up the original and rewriting the file in place. Current files are
+// returned unchanged
+func upgradeSettingsFile(configPath string, rawData []byte) ([]byte, error) {
+	migrated, version, err := migrateSettingsData(rawData)
+	if err != nil || version == settingsSchemaVersion {
+		return migrated, err
+	}
+
+	if _, err := backupSettingsFile(configPath, version, rawData); err != nil {
+		return nil, err
+	}
+
+	if err := atomicfile.Write(configPath, migrated, 0o600); err != nil {
+		return nil, fmt.Errorf("write migrated settings: %w", err)
+	}
+
+	recordOwnWrite(configPath, migrated)
+
+	return migrated, nil
+}
+
+// migrateSettingsData upgrades settings YAML to the current schema in memory
+// and reports the version it started from
+func migrateSettingsData(rawData []byte) ([]byte, int, error) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(rawData, &document); err != nil {
+		return nil, 0, fmt.Errorf("%w: parse settings yaml: %w", errCorruptSettings, err)
+	}
+
+	if len(document.Content) == 0 {
+		document = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	mapping := document.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, 0, fmt.Errorf("%w: parse settings yaml: settings must be a mapping", errCorruptSettings)
+	}
+
+	version, err := detectSchemaVersion(mapping)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: parse settings yaml: %w", errCorruptSettings, err)
+	}
+
+	if version == settingsSchemaVersion {
+		return rawData, version, nil
+	}
+
+	if err := migrateSettingsDocument(mapping, version); err != nil {
+		return nil, version, err
+	}
+
+	migrated, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, version, fmt.Errorf("marshal migrated settings: %w", err)
+	}
+
+	return migrated, version, nil
+}
+
+// ResolveLogPath returns the JSONL log path in the application state directory
 func ResolveLogPath(appName string) (string, error) {
-	configDir, err := resolveAppConfigDir(appName)
+	layout, err := platform.ResolveLayout(appName)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(layout.StateDir, logFileName), nil
+}
+
+// ResolveHistoryPath returns the break history path in the application state
+// directory
+func ResolveHistoryPath(appName string) (string, error) {
+	layout, err := platform.ResolveLayout(appName)
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(layout.StateDir, historyFileName), nil
+}
+
+// ResolveGoalsPath returns the goal streaks path in the application state
+// directory
+func ResolveGoalsPath(appName string) (string, error) {
+	layout, err := platform.ResolveLayout(appName)
+	if err != nil {
+		return "", err
+	}
 
+	return filepath.Join(layout.StateDir, goalsFileName), nil
+}
+
+// ResolveCheckInPath returns the comfort check-in path in the application
+// state directory
+func ResolveCheckInPath(appName string) (string, error) {
+	layout, err := platform.ResolveLayout(appName)
 	if err != nil {
 		return "", err
 	}
 
-	return filepath.Join(configDir, logFileName), nil
+	return filepath.Join(layout.StateDir, checkInFileName), nil
+}
+
+// ResolveActivityPath returns the screen activity path in the application
+// state directory
+func ResolveActivityPath(appName string) (string, error) {
+	layout, err := platform.ResolveLayout(appName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(layout.StateDir, activityFileName), nil
+}
+
+// SettingsProfile names the settings in use: "default", or the file name
+// without its extension when EAGLEEYE_CONFIG_PATH selects another file
+func SettingsProfile() string {
+	configPath, ok := os.LookupEnv(configPathEnv)
+	if !ok || configPath == "" {
+		return defaultProfile
+	}
+
+	name := filepath.Base(configPath)
+
+	return strings.TrimSuffix(name, filepath.Ext(name))
 }
 
 // resolveConfigPath returns the settings file path with env override support
@@ -150,41 +366,61 @@ func resolveConfigPath(appName string) (string, error) {
 		return filepath.Clean(configPath), nil
 	}
 
-	configDir, err := resolveAppConfigDir(appName)
+	layout, err := platform.ResolveLayout(appName)
 	if err != nil {
 		return "", err
 	}
 
-	return filepath.Join(configDir, settingsFileName), nil
+	return filepath.Join(layout.ConfigDir, settingsFileName), nil
 }
 
-// resolveAppConfigDir returns the per-user application config directory
-func resolveAppConfigDir(appName string) (string, error) {
-	configDir, err := os.UserConfigDir()
+// yamlFromSettings converts settings to their on-disk form
+func yamlFromSettings(settings preferences.Settings) yamlSettings {
+	return yamlSettings{
+		SchemaVersion: settingsSchemaVersion,
 
-	if err != nil {
-		return "", fmt.Errorf("resolve user config dir: %w", err)
+		ShortInterval: secondsDuration(settings.ShortInterval),
+		ShortDuration: secondsDuration(settings.ShortDuration),
+		LongInterval:  secondsDuration(settings.LongInterval),
+		LongDuration:  secondsDuration(settings.LongDuration),
+
+		StrictMode:        settings.StrictMode,
+		IdleEnabled:       settings.IdleEnabled,
+		OverlayOpacity:    settings.OverlayOpacity,
+		Fullscreen:        settings.Fullscreen,
+		RunOnStartup:      boolPointer(settings.RunOnStartup),
+		Language:          i18n.NormalizeLanguage(settings.Language),
+		BreakTimerStarted: settings.BreakTimerStarted,
+		Reminders:         yamlReminders(settings.Reminders),
+
+		HistoryRetentionDays: intPointer(settings.HistoryRetentionDays),
+		WeeklyReport:         settings.WeeklyReport,
+		CheckInEvery:         settings.CheckInEvery,
+
+		LogLevel:           preferences.NormalizeLogLevel(settings.LogLevel),
+		TimekeeperLogLevel: preferences.NormalizeLogLevel(settings.TimekeeperLogLevel),
+		OverlayLogLevel:    preferences.NormalizeLogLevel(settings.OverlayLogLevel),
+		PlatformLogLevel:   preferences.NormalizeLogLevel(settings.PlatformLogLevel),
 	}
 
-	return filepath.Join(configDir, appName), nil
 }
 
 // applyYamlSettings overlays validated YAML values onto defaults
 func applyYamlSettings(settings *preferences.Settings, fileData yamlSettings) {
-	if fileData.ShortIntervalMinutes > 0 {
-		settings.ShortInterval = time.Duration(fileData.ShortIntervalMinutes) * time.Minute
+	if fileData.ShortInterval > 0 {
+		settings.ShortInterval = time.Duration(fileData.ShortInterval)
 	}
 
-	if fileData.ShortDurationSeconds > 0 {
-		settings.ShortDuration = time.Duration(fileData.ShortDurationSeconds) * time.Second
+	if fileData.ShortDuration > 0 {
+		settings.ShortDuration = time.Duration(fileData.ShortDuration)
 	}
 
-	if fileData.LongIntervalMinutes > 0 {
-		settings.LongInterval = time.Duration(fileData.LongIntervalMinutes) * time.Minute
+	if fileData.LongInterval > 0 {
+		settings.LongInterval = time.Duration(fileData.LongInterval)
 	}
 
-	if fileData.LongDurationMinutes > 0 {
-		settings.LongDuration = time.Duration(fileData.LongDurationMinutes) * time.Minute
+	if fileData.LongDuration > 0 {
+		settings.LongDuration = time.Duration(fileData.LongDuration)
 	}
 
 	if fileData.OverlayOpacity >= 0.7 && fileData.OverlayOpacity <= 0.95 {
@@ -201,6 +437,75 @@ func applyYamlSettings(settings *preferences.Settings, fileData yamlSettings) {
 
 	settings.Language = i18n.NormalizeLanguage(fileData.Language)
 	settings.BreakTimerStarted = fileData.BreakTimerStarted
+	settings.Reminders = remindersFromYaml(fileData.Reminders)
+
+	if fileData.HistoryRetentionDays != nil {
+		settings.HistoryRetentionDays = *fileData.HistoryRetentionDays
+	}
+
+	settings.WeeklyReport = fileData.WeeklyReport
+	settings.CheckInEvery = fileData.CheckInEvery
+
+	if level := preferences.NormalizeLogLevel(fileData.LogLevel); level != "" {
+		settings.LogLevel = level
+	}
+
+	settings.TimekeeperLogLevel = preferences.NormalizeLogLevel(fileData.TimekeeperLogLevel)
+	settings.OverlayLogLevel = preferences.NormalizeLogLevel(fileData.OverlayLogLevel)
+	settings.PlatformLogLevel = preferences.NormalizeLogLevel(fileData.PlatformLogLevel)
+}
+
+// yamlReminders converts reminder settings to their on-disk form
+func yamlReminders(reminders []model.ReminderConfig) []yamlReminder {
+	reminders = preferences.NormalizeReminders(reminders)
+
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	fileData := make([]yamlReminder, 0, len(reminders))
+
+	for _, reminder := range reminders {
+		fileData = append(fileData, yamlReminder{
+			Name:         reminder.Name,
+			Interval:     secondsDuration(reminder.Interval),
+			Message:      reminder.Message,
+			Presentation: string(reminder.Presentation),
+			Enabled:      boolPointer(reminder.Enabled),
+		})
+	}
+
+	return fileData
+}
+
+// remindersFromYaml converts and validates on-disk reminder entries
+func remindersFromYaml(fileData []yamlReminder) []model.ReminderConfig {
+	reminders := make([]model.ReminderConfig, 0, len(fileData))
+
+	for _, entry := range fileData {
+		enabled := entry.Enabled == nil || *entry.Enabled
+
+		reminders = append(reminders, model.ReminderConfig{
+			Name:         entry.Name,
+			Interval:     time.Duration(entry.Interval),
+			Message:      entry.Message,
+			Presentation: model.ReminderPresentation(entry.Presentation),
+			Enabled:      enabled,
+		})
+	}
+
+	reminders = preferences.NormalizeReminders(reminders)
+
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	return reminders
+}
+
+// secondsDuration truncates value to the second precision settings.yaml keeps
+func secondsDuration(value time.Duration) yamlDuration {
+	return yamlDuration(value.Truncate(time.Second))
 }
 
 func boolPointer(value bool) *bool {
@@ -208,3 +513,9 @@ func boolPointer(value bool) *bool {
 
 	return &pointer
 }
+
+func intPointer(value int) *int {
+	pointer := value
+
+	return &pointer
+}
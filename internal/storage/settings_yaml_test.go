@@ -2,12 +2,16 @@ package storage
 
 import (
 	"bytes"
+	"eagleeye/internal/core/model"
 	"eagleeye/internal/ui/preferences"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestSaveLoadRunOnStartup verifies both true and false autostart values survive YAML roundtrip
@@ -105,6 +109,74 @@ func TestLoadSettingsWithoutBreakTimerStartedKeepsFalse(t *testing.T) {
 	}
 }
 
+// TestLoadSettingsReminders verifies reminder defaults and that invalid entries are dropped
+func TestLoadSettingsReminders(t *testing.T) {
+	configRoot := t.TempDir()
+	setUserConfigEnv(t, configRoot)
+
+	appName := "EagleEyeReminders"
+	configPath, err := resolveConfigPath(appName)
+
+	if err != nil {
+		t.Fatalf("resolveConfigPath() error = %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	raw := []byte(strings.Join([]string{
+		"run_on_startup: true",
+		"reminders:",
+		"  - name: hydrate",
+		"    interval_minutes: 45",
+		"    message: Drink some water",
+		"    presentation: notification",
+		"  - name: posture",
+		"    interval_minutes: 30",
+		"    presentation: hologram",
+		"    enabled: false",
+		"  - name: broken",
+		"    interval_minutes: 0",
+		"  - name: Hydrate",
+		"    interval_minutes: 10",
+		"",
+	}, "\n"))
+
+	if err := os.WriteFile(configPath, raw, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := LoadSettings(appName)
+
+	var validation *SettingsValidationError
+	if !errors.As(err, &validation) || len(validation.Issues) != 2 {
+		t.Fatalf("LoadSettings() error = %v, want presentation and interval issues", err)
+	}
+
+	want := []model.ReminderConfig{
+		{Name: "hydrate", Interval: 45 * time.Minute, Message: "Drink some water", Presentation: model.PresentationNotification, Enabled: true},
+		{Name: "posture", Interval: 30 * time.Minute, Presentation: model.PresentationTray},
+	}
+
+	if !reflect.DeepEqual(loaded.Reminders, want) {
+		t.Fatalf("loaded Reminders = %+v, want %+v", loaded.Reminders, want)
+	}
+
+	if err := SaveSettings(appName, loaded); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	reloaded, err := LoadSettings(appName)
+	if err != nil {
+		t.Fatalf("LoadSettings() after save error = %v", err)
+	}
+
+	if !reflect.DeepEqual(reloaded.Reminders, want) {
+		t.Fatalf("reloaded Reminders = %+v, want %+v", reloaded.Reminders, want)
+	}
+}
+
 // TestSaveSettingsUsesPrivateFileMode verifies saved settings are not world-readable
 func TestSaveSettingsUsesPrivateFileMode(t *testing.T) {
 	if runtime.GOOS == "windows" {
@@ -134,6 +206,57 @@ func TestSaveSettingsUsesPrivateFileMode(t *testing.T) {
 	}
 }
 
+// TestSaveSettingsKeepsSymlink verifies a settings.yaml linked from a
+// dotfiles directory stays a link and its target gets the new contents
+func TestSaveSettingsKeepsSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs extra privileges on Windows")
+	}
+
+	configRoot := t.TempDir()
+	setUserConfigEnv(t, configRoot)
+
+	appName := "EagleEyeSymlink"
+	configPath, err := resolveConfigPath(appName)
+	if err != nil {
+		t.Fatalf("resolveConfigPath() error = %v", err)
+	}
+
+	target := filepath.Join(t.TempDir(), "dotfiles", "settings.yaml")
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	if err := os.WriteFile(target, []byte("strict_mode: false\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	if err := os.Symlink(target, configPath); err != nil {
+		t.Fatalf("Symlink() error = %v", err)
+	}
+
+	settings := preferences.DefaultSettings()
+	settings.StrictMode = true
+
+	if err := SaveSettings(appName, settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	info, err := os.Lstat(configPath)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("Lstat(configPath) = %v, %v, want a symlink", info, err)
+	}
+
+	data, err := os.ReadFile(target)
+	if err != nil || !strings.Contains(string(data), "strict_mode: true") {
+		t.Fatalf("target = %q, %v, want the saved settings", data, err)
+	}
+}
+
 // TestLoadSettingsRejectsOversizedFile guards against loading unexpectedly large configs
 func TestLoadSettingsRejectsOversizedFile(t *testing.T) {
 	configRoot := t.TempDir()
@@ -161,8 +284,8 @@ func TestLoadSettingsRejectsOversizedFile(t *testing.T) {
 	}
 }
 
-// TestResolveLogPathUsesAppConfigDir verifies logs stay under the app config directory
-func TestResolveLogPathUsesAppConfigDir(t *testing.T) {
+// TestResolveLogPathUsesAppStateDir verifies logs live in the app state directory
+func TestResolveLogPathUsesAppStateDir(t *testing.T) {
 	configRoot := t.TempDir()
 	setUserConfigEnv(t, configRoot)
 
@@ -172,11 +295,49 @@ func TestResolveLogPathUsesAppConfigDir(t *testing.T) {
 	}
 
 	wantSuffix := filepath.Join("EagleEyeLogPath", logFileName)
+	if runtime.GOOS != "windows" && runtime.GOOS != "darwin" {
+		wantSuffix = filepath.Join("state", wantSuffix)
+	}
+
 	if !strings.HasSuffix(logPath, wantSuffix) {
 		t.Fatalf("ResolveLogPath() = %q, want suffix %q", logPath, wantSuffix)
 	}
 }
 
+// TestResolveHistoryPathAndProfile verifies break history and reports live in state and profiles follow the settings file
+func TestResolveHistoryPathAndProfile(t *testing.T) {
+	configRoot := t.TempDir()
+	setUserConfigEnv(t, configRoot)
+
+	historyPath, err := ResolveHistoryPath("EagleEyeHistoryPath")
+	if err != nil {
+		t.Fatalf("ResolveHistoryPath() error = %v", err)
+	}
+
+	if want := filepath.Join("EagleEyeHistoryPath", historyFileName); !strings.HasSuffix(historyPath, want) {
+		t.Fatalf("ResolveHistoryPath() = %q, want suffix %q", historyPath, want)
+	}
+
+	reportPath, err := WriteReport("EagleEyeHistoryPath", "week-2026-03-09.md", []byte("# report\n"))
+	if err != nil {
+		t.Fatalf("WriteReport() error = %v", err)
+	}
+
+	if filepath.Dir(filepath.Dir(reportPath)) != filepath.Dir(historyPath) {
+		t.Fatalf("WriteReport() = %q, want it under %q", reportPath, filepath.Dir(historyPath))
+	}
+
+	if got := SettingsProfile(); got != defaultProfile {
+		t.Fatalf("SettingsProfile() = %q, want %q", got, defaultProfile)
+	}
+
+	t.Setenv(configPathEnv, filepath.Join(configRoot, "focus.yaml"))
+
+	if got := SettingsProfile(); got != "focus" {
+		t.Fatalf("SettingsProfile() = %q, want focus", got)
+	}
+}
+
 // TestConfigPathEnvOverridesSettingsPathOnly verifies custom settings path does not move logs
 func TestConfigPathEnvOverridesSettingsPathOnly(t *testing.T) {
 	configRoot := t.TempDir()
@@ -214,68 +375,19 @@ func TestConfigPathEnvOverridesSettingsPathOnly(t *testing.T) {
 	}
 }
 
-// TestLoadSettingsLegacyResetWithoutRunOnStartup verifies old configs are replaced with defaults
-func TestLoadSettingsLegacyResetWithoutRunOnStartup(t *testing.T) {
-	configRoot := t.TempDir()
-	setUserConfigEnv(t, configRoot)
-
-	appName := "EagleEyeLegacyReset"
-	configPath, err := resolveConfigPath(appName)
-
-	if err != nil {
-		t.Fatalf("resolveConfigPath() error = %v", err)
-	}
-
-	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
-		t.Fatalf("MkdirAll() error = %v", err)
-	}
-
-	legacy := []byte(strings.Join([]string{
-		"short_interval_minutes: 1",
-		"short_duration_seconds: 2",
-		"long_interval_minutes: 3",
-		"long_duration_minutes: 4",
-		"strict_mode: true",
-		"idle_enabled: false",
-		"overlay_opacity: 0.95",
-		"fullscreen: true",
-		"language: ru",
-		"",
-	}, "\n"))
-
-	if err := os.WriteFile(configPath, legacy, 0o600); err != nil {
-		t.Fatalf("WriteFile() error = %v", err)
-	}
-
-	loaded, err := LoadSettings(appName)
-	if err != nil {
-		t.Fatalf("LoadSettings() error = %v", err)
-	}
-
-	defaults := preferences.DefaultSettings()
-	if loaded != defaults {
-		t.Fatalf("legacy load must reset to defaults; got %+v want %+v", loaded, defaults)
-	}
-
-	raw, err := os.ReadFile(configPath)
-	if err != nil {
-		t.Fatalf("ReadFile() error = %v", err)
-	}
-
-	if !strings.Contains(string(raw), "run_on_startup: true") {
-		t.Fatalf("reset config must contain run_on_startup: true, got:\n%s", string(raw))
-	}
-}
-
 // setUserConfigEnv isolates user config paths inside each test temp directory
 func setUserConfigEnv(t *testing.T, path string) {
 	t.Helper()
 
+	t.Setenv(policyPathEnv, filepath.Join(path, "no-policy.yaml"))
+
 	switch runtime.GOOS {
 	case "windows":
 		t.Setenv("APPDATA", path)
 	default:
 		t.Setenv("XDG_CONFIG_HOME", path)
+		t.Setenv("XDG_STATE_HOME", filepath.Join(path, "state"))
+		t.Setenv("XDG_RUNTIME_DIR", "")
 	}
 }
 
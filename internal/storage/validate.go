@@ -0,0 +1,491 @@
+package storage
+
+import (
+	"bytes"
+	"eagleeye/internal/core/model"
+	"eagleeye/internal/ui/i18n"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IssueKind classifies a problem with one settings.yaml entry
+type IssueKind string
+
+const (
+	IssueUnknownKey   IssueKind = "unknown_key"
+	IssueOutOfRange   IssueKind = "out_of_range"
+	IssueTypeMismatch IssueKind = "type_mismatch"
+)
+
+// SettingsIssue describes one settings.yaml entry that was ignored. Field is
+// a dotted path such as reminders[0].interval; Line is 1-based, or 0
+// for entries that do not come from a file
+type SettingsIssue struct {
+	Field   string
+	Line    int
+	Kind    IssueKind
+	Message string
+}
+
+// String formats the issue for logs and the preferences banner
+func (issue SettingsIssue) String() string {
+	if issue.Line == 0 {
+		return fmt.Sprintf("%s: %s", issue.Field, issue.Message)
+	}
+
+	return fmt.Sprintf("line %d: %s: %s", issue.Line, issue.Field, issue.Message)
+}
+
+// SettingsValidationError accompanies usable settings when some entries in
+// settings.yaml were ignored. The remaining entries were applied
+type SettingsValidationError struct {
+	Issues []SettingsIssue
+}
+
+// Error summarizes the ignored entries
+func (validation *SettingsValidationError) Error() string {
+	lines := make([]string, 0, len(validation.Issues))
+
+	for _, issue := range validation.Issues {
+		lines = append(lines, issue.String())
+	}
+
+	return fmt.Sprintf("settings entries ignored: %s", strings.Join(lines, "; "))
+}
+
+// valueKind is the Go type a settings value must decode into
+type valueKind int
+
+const (
+	kindInt valueKind = iota
+	kindBool
+	kindFloat
+	kindString
+	kindDuration
+	kindReminders
+)
+
+// fieldRule constrains one settings key. check returns a message for values
+// that decode but are out of range. Legacy integer keys name the duration key
+// that replaced them and the unit they counted in
+type fieldRule struct {
+	kind    valueKind
+	check   func(value any) string
+	renamed string
+	unit    time.Duration
+}
+
+var settingsRules = map[string]fieldRule{
+	schemaVersionKey:         {kind: kindInt},
+	"short_interval":         {kind: kindDuration, check: atLeastOneSecond},
+	"short_duration":         {kind: kindDuration, check: atLeastOneSecond},
+	"long_interval":          {kind: kindDuration, check: atLeastOneSecond},
+	"long_duration":          {kind: kindDuration, check: atLeastOneSecond},
+	"short_interval_minutes": {kind: kindInt, check: atLeastOne, renamed: "short_interval", unit: time.Minute},
+	"short_duration_seconds": {kind: kindInt, check: atLeastOne, renamed: "short_duration", unit: time.Second},
+	"long_interval_minutes":  {kind: kindInt, check: atLeastOne, renamed: "long_interval", unit: time.Minute},
+	"long_duration_minutes":  {kind: kindInt, check: atLeastOne, renamed: "long_duration", unit: time.Minute},
+	"strict_mode":            {kind: kindBool},
+	"idle_enabled":           {kind: kindBool},
+	"overlay_opacity":        {kind: kindFloat, check: checkOpacity},
+	"fullscreen":             {kind: kindBool},
+	"run_on_startup":         {kind: kindBool},
+	"language":               {kind: kindString, check: checkLanguage},
+	"break_timer_started":    {kind: kindBool},
+	"reminders":              {kind: kindReminders},
+	"history_retention_days": {kind: kindInt, check: atLeastZero},
+	"weekly_report":          {kind: kindBool},
+	"comfort_checkin_every":  {kind: kindInt, check: atLeastZero},
+	"log_level":              {kind: kindString, check: checkLogLevel},
+	"log_level_timekeeper":   {kind: kindString, check: checkLogLevel},
+	"log_level_overlay":      {kind: kindString, check: checkLogLevel},
+	"log_level_platform":     {kind: kindString, check: checkLogLevel},
+}
+
+var reminderRules = map[string]fieldRule{
+	"name":             {kind: kindString, check: checkNotBlank},
+	"interval":         {kind: kindDuration, check: atLeastOneSecond},
+	"interval_minutes": {kind: kindInt, check: atLeastOne, renamed: "interval", unit: time.Minute},
+	"message":          {kind: kindString},
+	"presentation":     {kind: kindString, check: checkPresentation},
+	"enabled":          {kind: kindBool},
+}
+
+// Validate reports every settings.yaml entry that LoadSettings would ignore.
+// The error is non-nil only when the data is not a YAML mapping at all
+func Validate(rawData []byte) ([]SettingsIssue, error) {
+	_, issues, err := inspectSettings(rawData)
+
+	return issues, err
+}
+
+// inspectSettings validates rawData and returns it re-encoded without the
+// offending entries and with legacy keys renamed, so the result decodes
+// strictly
+func inspectSettings(rawData []byte) ([]byte, []SettingsIssue, error) {
+	var document yaml.Node
+
+	if err := yaml.Unmarshal(rawData, &document); err != nil {
+		return nil, nil, fmt.Errorf("parse settings yaml: %w", err)
+	}
+
+	if document.Kind == 0 {
+		return rawData, nil, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		if root.ShortTag() == "!!null" {
+			return rawData, nil, nil
+		}
+
+		return nil, nil, fmt.Errorf("parse settings yaml: line %d: top level must be a mapping", root.Line)
+	}
+
+	issues := pruneMapping(root, settingsRules, "")
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	cleaned, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal validated settings: %w", err)
+	}
+
+	return cleaned, issues, nil
+}
+
+// decodeSettingsStrict decodes rawData rejecting keys the schema does not know
+func decodeSettingsStrict(rawData []byte) (yamlSettings, error) {
+	var fileData yamlSettings
+
+	decoder := yaml.NewDecoder(bytes.NewReader(rawData))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&fileData); err != nil && !errors.Is(err, io.EOF) {
+		return fileData, err
+	}
+
+	return fileData, nil
+}
+
+// pruneMapping removes entries of mapping that break rules and reports them.
+// Valid legacy keys are rewritten to their duration key unless the mapping
+// already has that key, which then wins
+func pruneMapping(mapping *yaml.Node, rules map[string]fieldRule, prefix string) []SettingsIssue {
+	var issues []SettingsIssue
+
+	present := make(map[string]bool, len(mapping.Content)/2)
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		present[mapping.Content[index].Value] = true
+	}
+
+	kept := make([]*yaml.Node, 0, len(mapping.Content))
+
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		key, value := mapping.Content[index], mapping.Content[index+1]
+		field := prefix + key.Value
+
+		rule, known := rules[key.Value]
+		if !known {
+			issues = append(issues, SettingsIssue{
+				Field:   field,
+				Line:    key.Line,
+				Kind:    IssueUnknownKey,
+				Message: unknownKeyMessage(key.Value, rules),
+			})
+
+			continue
+		}
+
+		issue, ok := checkValue(field, value, rule)
+		if !ok {
+			issues = append(issues, issue)
+
+			continue
+		}
+
+		if rule.kind == kindReminders {
+			issues = append(issues, pruneReminders(value, field)...)
+		}
+
+		if rule.renamed != "" {
+			if present[rule.renamed] {
+				continue
+			}
+
+			key, value = renameLegacyEntry(key, value, rule)
+		}
+
+		kept = append(kept, key, value)
+	}
+
+	mapping.Content = kept
+
+	return issues
+}
+
+// pruneReminders validates each reminder entry in place
+func pruneReminders(sequence *yaml.Node, field string) []SettingsIssue {
+	var issues []SettingsIssue
+
+	kept := make([]*yaml.Node, 0, len(sequence.Content))
+
+	for index, entry := range sequence.Content {
+		entryField := fmt.Sprintf("%s[%d]", field, index)
+
+		if entry.Kind != yaml.MappingNode {
+			issues = append(issues, SettingsIssue{
+				Field:   entryField,
+				Line:    entry.Line,
+				Kind:    IssueTypeMismatch,
+				Message: "expected a mapping with name and interval",
+			})
+
+			continue
+		}
+
+		issues = append(issues, pruneMapping(entry, reminderRules, entryField+".")...)
+		kept = append(kept, entry)
+	}
+
+	sequence.Content = kept
+
+	return issues
+}
+
+// renameLegacyEntry converts a validated legacy integer entry to its
+// duration key, keeping line numbers for later messages
+func renameLegacyEntry(key, value *yaml.Node, rule fieldRule) (*yaml.Node, *yaml.Node) {
+	var count int
+	_ = value.Decode(&count)
+
+	renamedKey := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: rule.renamed, Line: key.Line}
+	renamedValue := &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!str",
+		Value: preferences.FormatDuration(time.Duration(count) * rule.unit),
+		Line:  value.Line,
+	}
+
+	return renamedKey, renamedValue
+}
+
+// checkValue decodes value into the rule's type and applies its range check
+func checkValue(field string, value *yaml.Node, rule fieldRule) (SettingsIssue, bool) {
+	if value.ShortTag() == "!!null" {
+		return SettingsIssue{}, true
+	}
+
+	decoded, err := decodeValue(value, rule.kind)
+	if err != nil {
+		return SettingsIssue{
+			Field:   field,
+			Line:    value.Line,
+			Kind:    IssueTypeMismatch,
+			Message: fmt.Sprintf("expected %s, got %q", kindName(rule.kind), nodeText(value)),
+		}, false
+	}
+
+	if rule.check == nil {
+		return SettingsIssue{}, true
+	}
+
+	if message := rule.check(decoded); message != "" {
+		return SettingsIssue{
+			Field:   field,
+			Line:    value.Line,
+			Kind:    IssueOutOfRange,
+			Message: message,
+		}, false
+	}
+
+	return SettingsIssue{}, true
+}
+
+func decodeValue(value *yaml.Node, kind valueKind) (any, error) {
+	switch kind {
+	case kindInt:
+		var decoded int
+		err := value.Decode(&decoded)
+
+		return decoded, err
+	case kindBool:
+		var decoded bool
+		err := value.Decode(&decoded)
+
+		return decoded, err
+	case kindFloat:
+		var decoded float64
+		err := value.Decode(&decoded)
+
+		return decoded, err
+	case kindDuration:
+		return parseDurationNode(value)
+	case kindString:
+		if value.Kind != yaml.ScalarNode {
+			return nil, errors.New("not a scalar")
+		}
+
+		return value.Value, nil
+	case kindReminders:
+		if value.Kind != yaml.SequenceNode {
+			return nil, errors.New("not a sequence")
+		}
+
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown value kind %d", kind)
+	}
+}
+
+func kindName(kind valueKind) string {
+	switch kind {
+	case kindInt:
+		return "a whole number"
+	case kindBool:
+		return "true or false"
+	case kindFloat:
+		return "a number"
+	case kindDuration:
+		return "a duration such as 25m or 1m30s"
+	case kindReminders:
+		return "a list of reminders"
+	default:
+		return "text"
+	}
+}
+
+// nodeText renders a node briefly for messages
+func nodeText(value *yaml.Node) string {
+	switch value.Kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "list"
+	default:
+		return value.Value
+	}
+}
+
+func atLeastOne(value any) string {
+	if value.(int) < 1 {
+		return fmt.Sprintf("must be at least 1, got %d", value.(int))
+	}
+
+	return ""
+}
+
+func atLeastZero(value any) string {
+	if value.(int) < 0 {
+		return fmt.Sprintf("must be 0 or more, got %d", value.(int))
+	}
+
+	return ""
+}
+
+func atLeastOneSecond(value any) string {
+	if value.(time.Duration) < time.Second {
+		return fmt.Sprintf("must be at least 1s, got %s", value.(time.Duration))
+	}
+
+	return ""
+}
+
+func checkOpacity(value any) string {
+	if opacity := value.(float64); opacity < 0.7 || opacity > 0.95 {
+		return fmt.Sprintf("must be between 0.7 and 0.95, got %g", opacity)
+	}
+
+	return ""
+}
+
+func checkLanguage(value any) string {
+	language := strings.ToLower(strings.TrimSpace(value.(string)))
+
+	if language != "" && i18n.NormalizeLanguage(language) != language {
+		return fmt.Sprintf("unsupported language %q, use %s or %s", value, i18n.LanguageEN, i18n.LanguageRU)
+	}
+
+	return ""
+}
+
+func checkLogLevel(value any) string {
+	level := value.(string)
+
+	if strings.TrimSpace(level) != "" && preferences.NormalizeLogLevel(level) == "" {
+		return fmt.Sprintf("unsupported log level %q, use %s", level, strings.Join(preferences.LogLevelNames, ", "))
+	}
+
+	return ""
+}
+
+func checkNotBlank(value any) string {
+	if strings.TrimSpace(value.(string)) == "" {
+		return "must not be empty"
+	}
+
+	return ""
+}
+
+func checkPresentation(value any) string {
+	switch model.ReminderPresentation(strings.ToLower(strings.TrimSpace(value.(string)))) {
+	case "", model.PresentationTray, model.PresentationNotification, model.PresentationOverlay:
+		return ""
+	default:
+		return fmt.Sprintf("unsupported presentation %q, use %s, %s or %s",
+			value, model.PresentationTray, model.PresentationNotification, model.PresentationOverlay)
+	}
+}
+
+// unknownKeyMessage suggests the closest known key for likely typos
+func unknownKeyMessage(key string, rules map[string]fieldRule) string {
+	best, bestDistance := "", 3
+
+	for known := range rules {
+		if distance := editDistance(key, known); distance < bestDistance || (distance == bestDistance && known < best) {
+			best, bestDistance = known, distance
+		}
+	}
+
+	if best == "" {
+		return "unknown setting"
+	}
+
+	return fmt.Sprintf("unknown setting, did you mean %s?", best)
+}
+
+// editDistance is the Levenshtein distance between two keys
+func editDistance(left, right string) int {
+	previous := make([]int, len(right)+1)
+	current := make([]int, len(right)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(left); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(right)]
+}
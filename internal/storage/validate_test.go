@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestValidateReportsFieldIssues verifies kinds, fields and line numbers of reported issues
+func TestValidateReportsFieldIssues(t *testing.T) {
+	raw := []byte(strings.Join([]string{
+		"schema_version: 2",
+		"short_intervl_minutes: 25",
+		"short_duration_seconds: twenty",
+		"overlay_opacity: 0.5",
+		"strict_mode: true",
+		"language: de",
+		"reminders:",
+		"  - name: stretch",
+		"    interval_minutes: 60",
+		"    colour: red",
+		"  - just text",
+		"",
+	}, "\n"))
+
+	issues, err := Validate(raw)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	type brief struct {
+		Field string
+		Line  int
+		Kind  IssueKind
+	}
+
+	got := make([]brief, 0, len(issues))
+	for _, issue := range issues {
+		got = append(got, brief{Field: issue.Field, Line: issue.Line, Kind: issue.Kind})
+	}
+
+	want := []brief{
+		{Field: "short_intervl_minutes", Line: 2, Kind: IssueUnknownKey},
+		{Field: "short_duration_seconds", Line: 3, Kind: IssueTypeMismatch},
+		{Field: "overlay_opacity", Line: 4, Kind: IssueOutOfRange},
+		{Field: "language", Line: 6, Kind: IssueOutOfRange},
+		{Field: "reminders[0].colour", Line: 10, Kind: IssueUnknownKey},
+		{Field: "reminders[1]", Line: 11, Kind: IssueTypeMismatch},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Validate() issues = %+v, want %+v", got, want)
+	}
+
+	if !strings.Contains(issues[0].Message, "short_interval_minutes") {
+		t.Fatalf("unknown key message = %q, want suggestion", issues[0].Message)
+	}
+}
+
+// TestValidateAcceptsCleanFile verifies a saved file produces no issues
+func TestValidateAcceptsCleanFile(t *testing.T) {
+	raw := []byte("schema_version: 2\nshort_interval_minutes: 20\noverlay_opacity: 0.8\nlanguage: ru\nrun_on_startup:\n")
+
+	issues, err := Validate(raw)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("Validate() = %+v, %v, want no issues", issues, err)
+	}
+}
+
+// TestValidateRejectsNonMapping verifies a top-level scalar is a parse error, not an issue
+func TestValidateRejectsNonMapping(t *testing.T) {
+	if _, err := Validate([]byte("just a string")); err == nil {
+		t.Fatalf("Validate() error = nil, want error")
+	}
+}
+
+// TestLoadSettingsSkipsInvalidEntries verifies mismatched values no longer reject the whole file
+func TestLoadSettingsSkipsInvalidEntries(t *testing.T) {
+	writeTestSettings(t, []string{
+		"schema_version: 2",
+		"short_interval_minutes: 25",
+		"long_interval_minutes: soon",
+		"fulscreen: true",
+		"",
+	})
+
+	settings, err := LoadSettings("EagleEyeMigrations")
+
+	var validation *SettingsValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("LoadSettings() error = %v, want *SettingsValidationError", err)
+	}
+
+	if len(validation.Issues) != 2 {
+		t.Fatalf("issues = %+v, want 2", validation.Issues)
+	}
+
+	if settings.ShortInterval != 25*time.Minute {
+		t.Fatalf("ShortInterval = %v, want 25m", settings.ShortInterval)
+	}
+
+	if settings.Fullscreen {
+		t.Fatalf("Fullscreen = true, want misspelled key ignored")
+	}
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"eagleeye/internal/ui/preferences"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// settingsReloadDelay coalesces the burst of events editors emit per save
+const settingsReloadDelay = 300 * time.Millisecond
+
+// ownWrites remembers the last contents EagleEye itself wrote per settings
+// path so the watcher can tell them apart from external edits
+var ownWrites = struct {
+	mu     sync.Mutex
+	hashes map[string][sha256.Size]byte
+}{hashes: map[string][sha256.Size]byte{}}
+
+// WatchSettings reloads settings.yaml whenever it changes outside the app.
+// onChange receives validated settings; onError receives files that failed to
+// load, in which case the current settings stay in effect, and
+// *SettingsValidationError after onChange when some entries were ignored. Both run on the
+// watcher goroutine. Watching stops when ctx is done
+func WatchSettings(ctx context.Context, appName string, onChange func(preferences.Settings), onError func(error)) error {
+	configPath, err := resolveConfigPath(appName)
+	if err != nil {
+		return err
+	}
+
+	return watchSettingsFile(ctx, configPath, settingsReloadDelay, onChange, onError)
+}
+
+// watchSettingsFile watches the parent directory rather than the file itself,
+// because atomic saves replace the inode a file watch would be bound to. When
+// settings.yaml is a symlink the target's directory is watched too, so edits
+// to the linked file reload as well
+func watchSettingsFile(ctx context.Context, configPath string, delay time.Duration, onChange func(preferences.Settings), onError func(error)) error {
+	configDir := filepath.Dir(configPath)
+
+	if err := os.MkdirAll(configDir, 0o700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	targetPath, err := filepath.EvalSymlinks(configPath)
+	if err != nil {
+		targetPath = configPath
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create settings watcher: %w", err)
+	}
+
+	for _, dir := range watchedSettingsDirs(configPath, targetPath) {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+
+			return fmt.Errorf("watch config directory: %w", err)
+		}
+	}
+
+	reloader := &settingsReloader{
+		configPath: configPath,
+		targetPath: filepath.Clean(targetPath),
+		onChange:   onChange,
+		onError:    onError,
+	}
+	reloader.last, _ = hashSettingsFile(configPath)
+
+	go reloader.run(ctx, watcher, delay)
+
+	return nil
+}
+
+// watchedSettingsDirs lists the directories holding settings.yaml and, for a
+// symlink, the file it points to
+func watchedSettingsDirs(configPath, targetPath string) []string {
+	dirs := []string{filepath.Dir(configPath)}
+
+	if targetDir := filepath.Dir(targetPath); targetDir != dirs[0] {
+		dirs = append(dirs, targetDir)
+	}
+
+	return dirs
+}
+
+// settingsReloader debounces watcher events and applies external edits
+type settingsReloader struct {
+	configPath string
+	targetPath string
+	onChange   func(preferences.Settings)
+	onError    func(error)
+
+	mu   sync.Mutex
+	last [sha256.Size]byte
+}
+
+func (reloader *settingsReloader) run(ctx context.Context, watcher *fsnotify.Watcher, delay time.Duration) {
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	var timer *time.Timer
+
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if !reloader.watches(event.Name) || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+				continue
+			}
+
+			if timer == nil {
+				timer = time.AfterFunc(delay, reloader.reload)
+			} else {
+				timer.Reset(delay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			reloader.report(fmt.Errorf("watch settings: %w", err))
+		}
+	}
+}
+
+// watches reports whether name is settings.yaml or the file it links to
+func (reloader *settingsReloader) watches(name string) bool {
+	name = filepath.Clean(name)
+
+	return name == reloader.configPath || name == reloader.targetPath
+}
+
+// reload applies the file if its contents differ from both the last applied
+// version and the last version EagleEye wrote itself
+func (reloader *settingsReloader) reload() {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	hash, err := hashSettingsFile(reloader.configPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			reloader.report(err)
+		}
+
+		return
+	}
+
+	if hash == reloader.last {
+		return
+	}
+
+	reloader.last = hash
+
+	if isOwnWrite(reloader.configPath, hash) {
+		return
+	}
+
+	var validation *SettingsValidationError
+
+	settings, err := loadSettingsFile(reloader.configPath)
+	if err != nil && !errors.As(err, &validation) {
+		reloader.report(err)
+
+		return
+	}
+
+	// A schema upgrade during load rewrites the file; remember that version too
+	if upgraded, err := hashSettingsFile(reloader.configPath); err == nil {
+		reloader.last = upgraded
+	}
+
+	if reloader.onChange != nil {
+		reloader.onChange(settings)
+	}
+
+	if validation != nil {
+		reloader.report(validation)
+	}
+}
+
+func (reloader *settingsReloader) report(err error) {
+	if reloader.onError != nil {
+		reloader.onError(err)
+	}
+}
+
+// recordOwnWrite marks data as written by EagleEye at path
+func recordOwnWrite(path string, data []byte) {
+	ownWrites.mu.Lock()
+	defer ownWrites.mu.Unlock()
+
+	ownWrites.hashes[filepath.Clean(path)] = sha256.Sum256(data)
+}
+
+// isOwnWrite reports whether hash matches the last contents EagleEye wrote
+func isOwnWrite(path string, hash [sha256.Size]byte) bool {
+	ownWrites.mu.Lock()
+	defer ownWrites.mu.Unlock()
+
+	recorded, ok := ownWrites.hashes[filepath.Clean(path)]
+
+	return ok && recorded == hash
+}
+
+func hashSettingsFile(path string) ([sha256.Size]byte, error) {
+	rawData, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return sha256.Sum256(rawData), nil
+}
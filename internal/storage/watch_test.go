@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"eagleeye/internal/ui/preferences"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testReloadDelay = 20 * time.Millisecond
+
+// TestWatchSettingsAppliesExternalEdits verifies hand edits reach onChange
+func TestWatchSettingsAppliesExternalEdits(t *testing.T) {
+	configPath, changes, errs := startTestWatcher(t)
+
+	if err := os.WriteFile(configPath, []byte("schema_version: 2\nshort_interval_minutes: 42\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	select {
+	case settings := <-changes:
+		if settings.ShortInterval != 42*time.Minute {
+			t.Fatalf("ShortInterval = %v, want 42m", settings.ShortInterval)
+		}
+	case err := <-errs:
+		t.Fatalf("onError(%v), want onChange", err)
+	case <-time.After(2 * time.Second):
+		t.Fatalf("onChange not called after external edit")
+	}
+}
+
+// TestWatchSettingsIgnoresOwnWrites verifies SaveSettings does not echo back as a reload
+func TestWatchSettingsIgnoresOwnWrites(t *testing.T) {
+	_, changes, errs := startTestWatcher(t)
+
+	settings := preferences.DefaultSettings()
+	settings.ShortInterval = 33 * time.Minute
+
+	if err := SaveSettings("EagleEyeWatch", settings); err != nil {
+		t.Fatalf("SaveSettings() error = %v", err)
+	}
+
+	select {
+	case settings := <-changes:
+		t.Fatalf("onChange(%+v) after own write, want none", settings)
+	case err := <-errs:
+		t.Fatalf("onError(%v) after own write, want none", err)
+	case <-time.After(10 * testReloadDelay):
+	}
+}
+
+// TestWatchSettingsReportsInvalidEdits verifies broken files are reported, not applied
+func TestWatchSettingsReportsInvalidEdits(t *testing.T) {
+	configPath, changes, errs := startTestWatcher(t)
+
+	if err := os.WriteFile(configPath, []byte("short_interval_minutes: [broken"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	select {
+	case settings := <-changes:
+		t.Fatalf("onChange(%+v) for broken file, want onError", settings)
+	case <-errs:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("onError not called for broken file")
+	}
+
+	if _, err := os.Stat(configPath); err != nil {
+		t.Fatalf("Stat() error = %v, want broken file left in place", err)
+	}
+}
+
+// TestWatchSettingsFollowsSymlink verifies edits to the target of a
+// symlinked settings.yaml are reloaded
+func TestWatchSettingsFollowsSymlink(t *testing.T) {
+	configDir := t.TempDir()
+	targetPath := filepath.Join(t.TempDir(), "dotfiles-settings.yaml")
+	configPath := filepath.Join(configDir, "settings.yaml")
+	t.Setenv(configPathEnv, configPath)
+	t.Setenv(policyPathEnv, filepath.Join(configDir, "no-policy.yaml"))
+
+	if err := os.WriteFile(targetPath, []byte("schema_version: 2\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if err := os.Symlink(targetPath, configPath); err != nil {
+		t.Skipf("Symlink() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changes := make(chan preferences.Settings, 4)
+
+	err := watchSettingsFile(ctx, configPath, testReloadDelay,
+		func(settings preferences.Settings) { changes <- settings },
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("watchSettingsFile() error = %v", err)
+	}
+
+	if err := os.WriteFile(targetPath, []byte("schema_version: 2\nshort_interval_minutes: 42\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	select {
+	case settings := <-changes:
+		if settings.ShortInterval != 42*time.Minute {
+			t.Fatalf("ShortInterval = %v, want 42m", settings.ShortInterval)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("onChange not called after editing the symlink target")
+	}
+}
+
+func startTestWatcher(t *testing.T) (string, chan preferences.Settings, chan error) {
+	t.Helper()
+
+	configDir := t.TempDir()
+	configPath := filepath.Join(configDir, "settings.yaml")
+	t.Setenv(configPathEnv, configPath)
+	t.Setenv(policyPathEnv, filepath.Join(configDir, "no-policy.yaml"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	changes := make(chan preferences.Settings, 4)
+	errs := make(chan error, 4)
+
+	err := watchSettingsFile(ctx, configPath, testReloadDelay,
+		func(settings preferences.Settings) { changes <- settings },
+		func(err error) { errs <- err },
+	)
+	if err != nil {
+		t.Fatalf("watchSettingsFile() error = %v", err)
+	}
+
+	return configPath, changes, errs
+}
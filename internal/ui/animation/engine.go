@@ -80,20 +80,47 @@ func New(config Config, updateSprite func(fyne.Resource)) *Engine {
 
 // StartExercise starts an exercise animation sequence
 func (engine *Engine) StartExercise(ctx context.Context, spec ExerciseSpec) {
+	engine.StartExerciseAt(ctx, spec, 0)
+}
+
+// StartExerciseAt starts an exercise sequence as if elapsed time had already
+// passed since the session began. spec.Duration is the full session length;
+// the instruction phase and the combined left/right to up/down switch are
+// skipped or shortened accordingly, which lets a resumed break continue from
+// the phase it was paused in
+func (engine *Engine) StartExerciseAt(ctx context.Context, spec ExerciseSpec, elapsed time.Duration) {
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
 	engine.start(ctx, func(runCtx context.Context) {
-		engine.updateSprite(spec.Instruction)
+		instruction := engine.config.InstructionDuration - elapsed
 
-		if !sleepWithContext(runCtx, engine.config.InstructionDuration) {
-			return
+		if instruction > 0 {
+			engine.updateSprite(spec.Instruction)
+
+			if !sleepWithContext(runCtx, instruction) {
+				return
+			}
+		}
+
+		consumed := elapsed
+		if consumed < engine.config.InstructionDuration {
+			consumed = engine.config.InstructionDuration
 		}
 
-		if spec.Duration > engine.config.InstructionDuration {
-			spec.Duration -= engine.config.InstructionDuration
+		if spec.Duration > consumed {
+			spec.Duration -= consumed
 		} else {
 			spec.Duration = 0
 		}
 
-		engine.runExercise(runCtx, spec)
+		exerciseElapsed := elapsed - engine.config.InstructionDuration
+		if exerciseElapsed < 0 {
+			exerciseElapsed = 0
+		}
+
+		engine.runExercise(runCtx, spec, exerciseElapsed)
 	})
 }
 
@@ -167,8 +194,9 @@ func (engine *Engine) start(parent context.Context, run func(context.Context)) {
 	go run(runCtx)
 }
 
-// runExercise selects and runs the requested exercise scenario
-func (engine *Engine) runExercise(ctx context.Context, spec ExerciseSpec) {
+// runExercise selects and runs the requested exercise scenario. elapsed is
+// the exercise time already spent before spec.Duration began
+func (engine *Engine) runExercise(ctx context.Context, spec ExerciseSpec, elapsed time.Duration) {
 	if spec.Type == ExerciseLookOutside {
 		engine.notifyExerciseChange(ExerciseLookOutside)
 		engine.updateSprite(spec.LookOutside)
@@ -185,11 +213,14 @@ func (engine *Engine) runExercise(ctx context.Context, spec ExerciseSpec) {
 		return
 	}
 
-	if spec.Type == ExerciseLeftRight && remaining >= engine.config.CombinedSwitchAfter {
-		segment := engine.config.CombinedSwitchAfter
-		engine.notifyExerciseChange(ExerciseLeftRight)
-		engine.runDirectional(ctx, spec, ExerciseLeftRight, segment)
-		remaining -= segment
+	if spec.Type == ExerciseLeftRight && remaining+elapsed >= engine.config.CombinedSwitchAfter {
+		segment := engine.config.CombinedSwitchAfter - elapsed
+
+		if segment > 0 {
+			engine.notifyExerciseChange(ExerciseLeftRight)
+			engine.runDirectional(ctx, spec, ExerciseLeftRight, segment)
+			remaining -= segment
+		}
 
 		if remaining > 0 {
 			engine.notifyExerciseChange(ExerciseUpDown)
@@ -25,12 +25,65 @@ var translations = map[string]map[string]string{
 		"prefs.idleTrackingHelp":         "If you are away for 5+ minutes,\nEagleEye treats that as eye rest\nand restarts the break countdown.\nChecked every 20 seconds.",
 		"prefs.fullscreenOverlay":        "Fullscreen overlay",
 		"prefs.runOnStartup":             "Run on startup",
+		"prefs.weeklyReport":             "Open the weekly report on Monday morning",
 		"prefs.language":                 "Language",
+		"prefs.checkIn":                  "Eye comfort check-in:",
+		"prefs.checkInOff":               "Off",
+		"prefs.checkInEvery":             "After every long break",
+		"prefs.checkInEveryN":            "After every %d long breaks",
 		"prefs.overlayOpacity":           "Overlay opacity:",
+		"prefs.diagnostics":              "Diagnostics",
+		"prefs.logLevel":                 "Log level:",
+		"prefs.logLevelTimekeeper":       "Timer log level:",
+		"prefs.logLevelOverlay":          "Overlay log level:",
+		"prefs.logLevelPlatform":         "System log level:",
+		"prefs.logLevelInherit":          "Same as log level",
 		"prefs.autostartApplyErrorTitle": "Autostart Update Failed",
 		"prefs.autostartApplyErrorBody":  "Could not apply run on startup setting: %v",
 		"prefs.save":                     "Save",
 		"prefs.cancel":                   "Cancel",
+		"prefs.reminders":                "Reminders:",
+		"prefs.remindersSummary":         "%d of %d active",
+		"prefs.remindersManage":          "Manage...",
+		"prefs.remindersTitle":           "Custom reminders",
+		"prefs.reminderName":             "Name",
+		"prefs.reminderMessage":          "Message",
+		"prefs.reminderAdd":              "Add reminder",
+		"prefs.reminderApply":            "Apply",
+		"prefs.reminder.tray":            "Tray flash",
+		"prefs.reminder.notification":    "Notification",
+		"prefs.reminder.overlay":         "Overlay card",
+		"prefs.issuesTitle":              "Some settings in settings.yaml were ignored",
+		"prefs.managedTitle":             "Some settings are managed outside this window:",
+		"prefs.policyLocked":             "locked by administrator policy",
+		"prefs.policyBounded":            "limited by administrator policy (%s)",
+		"prefs.overridden":               "overridden by %s",
+		"prefs.bundle":                   "Settings file:",
+		"prefs.bundleExportButton":       "Export...",
+		"prefs.bundleImportButton":       "Import...",
+		"prefs.bundleExport":             "Export",
+		"prefs.bundleExportTitle":        "Export settings",
+		"prefs.bundleImportTitle":        "Import settings",
+		"prefs.bundleExported":           "Settings exported to %s",
+		"prefs.bundleMachine":            "Include machine-specific and personal settings (run on startup, language, reports)",
+		"prefs.bundleChanges":            "Importing will change:",
+		"prefs.bundleNoChanges":          "The file matches your current settings.",
+		"prefs.historyButton":            "History...",
+		"prefs.historyTitle":             "Settings history",
+		"prefs.historyEmpty":             "No settings changes have been recorded yet.",
+		"prefs.historySelect":            "Select a change to see what it did.",
+		"prefs.historyRevert":            "Revert to this",
+		"prefs.historyReset":             "Reset to defaults",
+		"prefs.historyRevertTitle":       "Revert settings",
+		"prefs.historyResetTitle":        "Reset settings",
+		"prefs.historyChanges":           "This will change:",
+		"prefs.historyNoChanges":         "These match your current settings.",
+		"prefs.historyFromPreferences":   "Preferences",
+		"prefs.historyFromFile":          "File edit",
+		"prefs.historyFromImport":        "Import",
+		"prefs.historyFromCLI":           "Command line",
+		"prefs.historyFromHistory":       "History",
+		"prefs.close":                    "Close",
 		"prefs.start":                    "Start",
 		"prefs.pauseBreakTimer":          "Pause break timer",
 		"prefs.resumeBreakTimer":         "Resume break timer",
@@ -42,10 +95,75 @@ var translations = map[string]map[string]string{
 		"prefs.pressResumeLine":          "Press Resume break timer",
 		"unit.min":                       "min",
 		"unit.sec":                       "sec",
+		"unit.hour":                      "h",
 		"tray.menuTitle":                 "EagleEye",
 		"tray.statusStarting":            "starting...",
 		"tray.statusFormat":              "Status: %s",
+		"tray.activityToday":             "today: %s active",
 		"tray.preferences":               "Preferences",
+		"tray.statistics":                "Statistics",
+		"tray.goals":                     "Goals",
+		"goal.honour_90":                 "Honour 90% of breaks",
+		"goal.no_skipped_long":           "No skipped long breaks",
+		"goal.ten_breaks":                "Take 10 breaks a day",
+		"goal.progress":                  "%s: %d-day streak (best %d)",
+		"goal.doneToday":                 "%s · done today",
+		"goal.badge":                     "Badge: %s, %d days in a row",
+		"overlay.streak":                 "%d-day streak",
+		"overlay.newBadge":               "New badge: %d days",
+		"tray.weeklyReport":              "Last week's report",
+		"prefs.statistics":               "Statistics",
+		"stats.windowTitle":              "Statistics",
+		"stats.today":                    "Today",
+		"stats.week":                     "This week",
+		"stats.figures":                  "Taken %d · skipped %d · %d%% honoured",
+		"stats.noBreaks":                 "No breaks yet",
+		"stats.legendTaken":              "Taken",
+		"stats.legendSkipped":            "Skipped or postponed",
+		"stats.averageStretch":           "Average work stretch between breaks: %s",
+		"stats.activityToday":            "Screen time today: %s active · %s idle · longest stretch %s",
+		"stats.activityWeek":             "This week: %s active · %s idle",
+		"stats.activityNone":             "No screen activity recorded yet",
+		"stats.heatmap":                  "Breaks taken this week by hour",
+		"stats.weekday0":                 "Mon",
+		"stats.weekday1":                 "Tue",
+		"stats.weekday2":                 "Wed",
+		"stats.weekday3":                 "Thu",
+		"stats.weekday4":                 "Fri",
+		"stats.weekday5":                 "Sat",
+		"stats.weekday6":                 "Sun",
+		"stats.comfort":                  "Eye comfort and breaks taken, last 4 weeks",
+		"stats.legendShare":              "Breaks taken, %",
+		"stats.legendComfort":            "Comfort rating",
+		"stats.comfortNone":              "No comfort check-ins yet. Turn them on in preferences to rate your eyes after long breaks.",
+		"stats.comfortTooFew":            "Rate your eyes on a few more days with breaks to see how comfort follows breaks taken.",
+		"stats.comfortBetter":            "Your eyes felt better on days you took more breaks (r = %.2f).",
+		"stats.comfortWorse":             "Your eyes felt worse on days you took more breaks (r = %.2f).",
+		"stats.comfortUnclear":           "No clear link between breaks taken and comfort yet (r = %.2f).",
+		"checkin.title":                  "How do your eyes feel?",
+		"checkin.scale":                  "1 — strained · 5 — comfortable",
+		"checkin.note":                   "Optional note",
+		"checkin.dismiss":                "Not now",
+		"report.title":                   "Eye-health report: week of %s",
+		"report.summary":                 "Summary",
+		"report.honoured":                "Breaks honoured",
+		"report.skipped":                 "Skipped or postponed",
+		"report.compliance":              "Compliance",
+		"report.longestStretch":          "Longest screen stretch without a break",
+		"report.trend":                   "Versus the previous week",
+		"report.trendPoints":             "%+d points (%d%% the week before)",
+		"report.trendCount":              "%+d (%d the week before)",
+		"report.trendStretch":            "%s (%s the week before)",
+		"report.noPrevious":              "No breaks were recorded the week before.",
+		"report.daily":                   "Day by day",
+		"report.day":                     "Day",
+		"report.chartDaily":              "Breaks per day",
+		"report.previousWeek":            "Previous week",
+		"report.thisWeek":                "This week",
+		"report.footer":                  "Generated by EagleEye",
+		"report.readyTitle":              "Your weekly eye-health report is ready",
+		"report.readyBody":               "Last week you honoured %d of %d breaks (%d%%).",
+		"report.failed":                  "Could not create the weekly report: %v",
 		"tray.disableBreaksFor":          "Disable breaks for...",
 		"tray.pauseForMinutes":           "%d minutes",
 		"tray.takeNextBreakNow":          "Start next break now",
@@ -56,13 +174,21 @@ var translations = map[string]map[string]string{
 		"tray.quit":                      "Quit",
 		"tray.pausedSuffix":              "(paused)",
 		"tray.nextBreakIn":               "next break in %s",
+		"tray.snoozeReminders":           "Snooze reminder",
+		"tray.reminderFlash":             "%s: %s",
 		"overlay.title":                  "Eagle Eye",
 		"overlay.subtitle":               "Time to rest your eyes!",
 		"overlay.skip":                   "Skip",
+		"overlay.resume":                 "Resume",
+		"overlay.paused":                 "Break paused",
 		"overlay.exercise.leftRight":     "Move your eyes left and right",
 		"overlay.exercise.upDown":        "Move your eyes up and down",
 		"overlay.exercise.blink":         "Squint and open your eyes again",
 		"overlay.exercise.lookOut":       "Look into the distance and relax",
+		"overlay.reminderDismiss":        "Dismiss",
+		"settings.recoveredTitle":        "Settings recovered",
+		"settings.recoveredBackup":       "settings.yaml was damaged and has been restored from the last backup. The broken file was kept as %s",
+		"settings.recoveredDefaults":     "settings.yaml was damaged and no usable backup was found, so defaults are in use. The broken file was kept as %s",
 	},
 	LanguageRU: {
 		"main.trayWindowMessage":         "EagleEye запущен в системном трее.",
@@ -77,12 +203,65 @@ var translations = map[string]map[string]string{
 		"prefs.idleTrackingHelp":         "Если ты отошел от компьютера\nна 5+ минут, EagleEye считает,\nчто глаза уже отдохнули,\nи запускает таймер заново.\nПроверка идет раз в 20 секунд.",
 		"prefs.fullscreenOverlay":        "Полноэкранный оверлей",
 		"prefs.runOnStartup":             "Запускать при входе в систему",
+		"prefs.weeklyReport":             "Открывать недельный отчёт в понедельник утром",
 		"prefs.language":                 "Язык",
+		"prefs.checkIn":                  "Опрос о самочувствии глаз:",
+		"prefs.checkInOff":               "Выключен",
+		"prefs.checkInEvery":             "После каждого длинного перерыва",
+		"prefs.checkInEveryN":            "После каждого %d-го длинного перерыва",
 		"prefs.overlayOpacity":           "Непрозрачность оверлея:",
+		"prefs.diagnostics":              "Диагностика",
+		"prefs.logLevel":                 "Уровень журнала:",
+		"prefs.logLevelTimekeeper":       "Журнал таймера:",
+		"prefs.logLevelOverlay":          "Журнал оверлея:",
+		"prefs.logLevelPlatform":         "Журнал системы:",
+		"prefs.logLevelInherit":          "Как уровень журнала",
 		"prefs.autostartApplyErrorTitle": "Не удалось обновить автозапуск",
 		"prefs.autostartApplyErrorBody":  "Не удалось применить настройку автозапуска: %v",
 		"prefs.save":                     "Сохранить",
 		"prefs.cancel":                   "Отмена",
+		"prefs.reminders":                "Напоминания:",
+		"prefs.remindersSummary":         "активно %d из %d",
+		"prefs.remindersManage":          "Настроить...",
+		"prefs.remindersTitle":           "Свои напоминания",
+		"prefs.reminderName":             "Название",
+		"prefs.reminderMessage":          "Сообщение",
+		"prefs.reminderAdd":              "Добавить напоминание",
+		"prefs.reminderApply":            "Применить",
+		"prefs.reminder.tray":            "Мигание в трее",
+		"prefs.reminder.notification":    "Уведомление",
+		"prefs.reminder.overlay":         "Карточка оверлея",
+		"prefs.issuesTitle":              "Часть настроек из settings.yaml пропущена",
+		"prefs.managedTitle":             "Часть настроек управляется вне этого окна:",
+		"prefs.policyLocked":             "заблокировано политикой администратора",
+		"prefs.policyBounded":            "ограничено политикой администратора (%s)",
+		"prefs.overridden":               "переопределено через %s",
+		"prefs.bundle":                   "Файл настроек:",
+		"prefs.bundleExportButton":       "Экспорт...",
+		"prefs.bundleImportButton":       "Импорт...",
+		"prefs.bundleExport":             "Экспортировать",
+		"prefs.bundleExportTitle":        "Экспорт настроек",
+		"prefs.bundleImportTitle":        "Импорт настроек",
+		"prefs.bundleExported":           "Настройки экспортированы в %s",
+		"prefs.bundleMachine":            "Включить личные настройки и настройки этого компьютера (автозапуск, язык, отчёты)",
+		"prefs.bundleChanges":            "Импорт изменит:",
+		"prefs.bundleNoChanges":          "Файл совпадает с текущими настройками.",
+		"prefs.historyButton":            "История...",
+		"prefs.historyTitle":             "История настроек",
+		"prefs.historyEmpty":             "Изменения настроек ещё не записывались.",
+		"prefs.historySelect":            "Выберите изменение, чтобы увидеть подробности.",
+		"prefs.historyRevert":            "Вернуть это",
+		"prefs.historyReset":             "Сбросить по умолчанию",
+		"prefs.historyRevertTitle":       "Откат настроек",
+		"prefs.historyResetTitle":        "Сброс настроек",
+		"prefs.historyChanges":           "Будет изменено:",
+		"prefs.historyNoChanges":         "Совпадает с текущими настройками.",
+		"prefs.historyFromPreferences":   "Настройки",
+		"prefs.historyFromFile":          "Правка файла",
+		"prefs.historyFromImport":        "Импорт",
+		"prefs.historyFromCLI":           "Командная строка",
+		"prefs.historyFromHistory":       "История",
+		"prefs.close":                    "Закрыть",
 		"prefs.start":                    "Старт",
 		"prefs.pauseBreakTimer":          "Пауза таймера перерывов",
 		"prefs.resumeBreakTimer":         "Возобновить таймер перерывов",
@@ -94,10 +273,75 @@ var translations = map[string]map[string]string{
 		"prefs.pressResumeLine":          "Нажмите Возобновить таймер",
 		"unit.min":                       "мин",
 		"unit.sec":                       "сек",
+		"unit.hour":                      "ч",
 		"tray.menuTitle":                 "EagleEye",
 		"tray.statusStarting":            "запуск...",
 		"tray.statusFormat":              "Статус: %s",
+		"tray.activityToday":             "сегодня: %s за экраном",
 		"tray.preferences":               "Настройки",
+		"tray.statistics":                "Статистика",
+		"tray.goals":                     "Цели",
+		"goal.honour_90":                 "Соблюдать 90% перерывов",
+		"goal.no_skipped_long":           "Не пропускать длинные перерывы",
+		"goal.ten_breaks":                "Делать 10 перерывов в день",
+		"goal.progress":                  "%s: серия %d дн. (лучшая %d)",
+		"goal.doneToday":                 "%s · сегодня выполнено",
+		"goal.badge":                     "Значок: %s, %d дн. подряд",
+		"overlay.streak":                 "Серия: %d дн.",
+		"overlay.newBadge":               "Новый значок: %d дн.",
+		"tray.weeklyReport":              "Отчёт за прошлую неделю",
+		"prefs.statistics":               "Статистика",
+		"stats.windowTitle":              "Статистика",
+		"stats.today":                    "Сегодня",
+		"stats.week":                     "Эта неделя",
+		"stats.figures":                  "Сделано %d · пропущено %d · соблюдено %d%%",
+		"stats.noBreaks":                 "Перерывов пока не было",
+		"stats.legendTaken":              "Сделано",
+		"stats.legendSkipped":            "Пропущено или отложено",
+		"stats.averageStretch":           "Средняя работа между перерывами: %s",
+		"stats.activityToday":            "Сегодня за экраном: %s активно · %s без действий · самый долгий отрезок %s",
+		"stats.activityWeek":             "За неделю: %s активно · %s без действий",
+		"stats.activityNone":             "Активность за экраном пока не записана",
+		"stats.heatmap":                  "Перерывы за неделю по часам",
+		"stats.weekday0":                 "Пн",
+		"stats.weekday1":                 "Вт",
+		"stats.weekday2":                 "Ср",
+		"stats.weekday3":                 "Чт",
+		"stats.weekday4":                 "Пт",
+		"stats.weekday5":                 "Сб",
+		"stats.weekday6":                 "Вс",
+		"stats.comfort":                  "Самочувствие глаз и сделанные перерывы за 4 недели",
+		"stats.legendShare":              "Сделано перерывов, %",
+		"stats.legendComfort":            "Оценка самочувствия",
+		"stats.comfortNone":              "Оценок самочувствия пока нет. Включите опрос в настройках, чтобы оценивать глаза после длинных перерывов.",
+		"stats.comfortTooFew":            "Оцените глаза ещё в несколько дней с перерывами, чтобы увидеть связь с сделанными перерывами.",
+		"stats.comfortBetter":            "В дни, когда вы делали больше перерывов, глаза чувствовали себя лучше (r = %.2f).",
+		"stats.comfortWorse":             "В дни, когда вы делали больше перерывов, глаза чувствовали себя хуже (r = %.2f).",
+		"stats.comfortUnclear":           "Явной связи между перерывами и самочувствием пока нет (r = %.2f).",
+		"checkin.title":                  "Как себя чувствуют глаза?",
+		"checkin.scale":                  "1 — напряжены · 5 — комфортно",
+		"checkin.note":                   "Заметка (необязательно)",
+		"checkin.dismiss":                "Не сейчас",
+		"report.title":                   "Отчёт о здоровье глаз: неделя с %s",
+		"report.summary":                 "Итоги",
+		"report.honoured":                "Сделано перерывов",
+		"report.skipped":                 "Пропущено или отложено",
+		"report.compliance":              "Соблюдение",
+		"report.longestStretch":          "Самая долгая работа без перерыва",
+		"report.trend":                   "По сравнению с прошлой неделей",
+		"report.trendPoints":             "%+d п.п. (%d%% неделей раньше)",
+		"report.trendCount":              "%+d (%d неделей раньше)",
+		"report.trendStretch":            "%s (%s неделей раньше)",
+		"report.noPrevious":              "За прошлую неделю перерывов не записано.",
+		"report.daily":                   "По дням",
+		"report.day":                     "День",
+		"report.chartDaily":              "Перерывы по дням",
+		"report.previousWeek":            "Прошлая неделя",
+		"report.thisWeek":                "Эта неделя",
+		"report.footer":                  "Создано EagleEye",
+		"report.readyTitle":              "Недельный отчёт о здоровье глаз готов",
+		"report.readyBody":               "На прошлой неделе сделано %d из %d перерывов (%d%%).",
+		"report.failed":                  "Не удалось создать недельный отчёт: %v",
 		"tray.disableBreaksFor":          "Отключить перерывы на...",
 		"tray.pauseForMinutes":           "%d минут",
 		"tray.takeNextBreakNow":          "\u041d\u0430\u0447\u0430\u0442\u044c \u0441\u043b\u0435\u0434\u0443\u044e\u0449\u0443\u044e \u0440\u0430\u0437\u043c\u0438\u043d\u043a\u0443 \u0441\u0435\u0439\u0447\u0430\u0441",
@@ -108,13 +352,21 @@ var translations = map[string]map[string]string{
 		"tray.quit":                      "Выход",
 		"tray.pausedSuffix":              "(пауза)",
 		"tray.nextBreakIn":               "следующий перерыв через %s",
+		"tray.snoozeReminders":           "Отложить напоминание",
+		"tray.reminderFlash":             "%s: %s",
 		"overlay.title":                  "Eagle Eye",
 		"overlay.subtitle":               "Пора отдыхать",
 		"overlay.skip":                   "Пропустить",
+		"overlay.resume":                 "Продолжить",
+		"overlay.paused":                 "Перерыв на паузе",
 		"overlay.exercise.leftRight":     "Двигайте глазами влево и вправо",
 		"overlay.exercise.upDown":        "Двигайте глазами вверх и вниз",
 		"overlay.exercise.blink":         "Зажмурьтесь и откройте глаза вновь",
 		"overlay.exercise.lookOut":       "Посмотрите вдаль и расслабьте глаза",
+		"overlay.reminderDismiss":        "Закрыть",
+		"settings.recoveredTitle":        "Настройки восстановлены",
+		"settings.recoveredBackup":       "Файл settings.yaml был повреждён и восстановлен из последней резервной копии. Повреждённый файл сохранён как %s",
+		"settings.recoveredDefaults":     "Файл settings.yaml был повреждён, а пригодной резервной копии нет, поэтому используются настройки по умолчанию. Повреждённый файл сохранён как %s",
 	},
 }
 
@@ -0,0 +1,120 @@
+package overlay
+
+import (
+	"eagleeye/internal/ui/i18n"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	checkInCardWidth      = float32(360)
+	checkInCardHeight     = float32(170)
+	checkInCardVisibleFor = 45 * time.Second
+	checkInRatings        = 5
+)
+
+// CheckInCard asks how the user's eyes feel after a long break. It is a
+// separate window shown once the break overlay has closed, so answering is
+// always optional and it hides itself when ignored
+type CheckInCard struct {
+	window        fyne.Window
+	localizer     *i18n.Localizer
+	title         *canvas.Text
+	scale         *widget.Label
+	note          *widget.Entry
+	dismissButton *widget.Button
+	hideTimer     *time.Timer
+	onSubmit      func(rating int, note string)
+}
+
+// NewCheckInCard creates a hidden comfort check-in window
+func NewCheckInCard(app fyne.App, localizer *i18n.Localizer) *CheckInCard {
+	localizer = defaultOverlayLocalizer(localizer)
+	window := newOverlayWindow(app)
+
+	background := canvas.NewRectangle(overlayBackgroundColor(230))
+	background.CornerRadius = reminderCardCornerRadius
+
+	title := canvas.NewText("", color.NRGBA{R: 232, G: 190, B: 66, A: 255})
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = 16
+
+	card := &CheckInCard{
+		window:    window,
+		localizer: localizer,
+		title:     title,
+		scale:     widget.NewLabel(""),
+		note:      widget.NewEntry(),
+	}
+
+	ratings := container.NewGridWithColumns(checkInRatings)
+	for rating := 1; rating <= checkInRatings; rating++ {
+		rating := rating
+		ratings.Add(widget.NewButton(strconv.Itoa(rating), func() {
+			card.submit(rating)
+		}))
+	}
+
+	card.dismissButton = widget.NewButton("", card.Hide)
+	footer := container.NewBorder(nil, nil, nil, card.dismissButton, card.note)
+	body := container.NewVBox(card.scale, ratings)
+	content := container.NewPadded(container.NewBorder(title, footer, nil, nil, body))
+
+	window.SetContent(container.NewStack(background, content))
+	window.Resize(fyne.NewSize(checkInCardWidth, checkInCardHeight))
+	window.SetCloseIntercept(card.Hide)
+
+	return card
+}
+
+// Show asks for a rating with an empty note. onSubmit receives the chosen
+// rating and note; it is not called when the card is dismissed or times out
+func (card *CheckInCard) Show(onSubmit func(rating int, note string)) {
+	card.onSubmit = onSubmit
+	card.title.Text = card.localizer.T("checkin.title")
+	card.title.Refresh()
+	card.scale.SetText(card.localizer.T("checkin.scale"))
+	card.note.SetText("")
+	card.note.SetPlaceHolder(card.localizer.T("checkin.note"))
+	card.dismissButton.SetText(card.localizer.T("checkin.dismiss"))
+
+	card.window.Resize(fyne.NewSize(checkInCardWidth, checkInCardHeight))
+	card.window.Show()
+
+	if card.hideTimer != nil {
+		card.hideTimer.Stop()
+	}
+
+	card.hideTimer = time.AfterFunc(checkInCardVisibleFor, func() {
+		fyne.Do(card.Hide)
+	})
+}
+
+// Hide closes the card without answering
+func (card *CheckInCard) Hide() {
+	if card.hideTimer != nil {
+		card.hideTimer.Stop()
+		card.hideTimer = nil
+	}
+
+	card.onSubmit = nil
+	card.window.Hide()
+}
+
+// submit hands the rating and trimmed note to the handler and closes the card
+func (card *CheckInCard) submit(rating int) {
+	onSubmit := card.onSubmit
+	note := strings.TrimSpace(card.note.Text)
+	card.Hide()
+
+	if onSubmit != nil {
+		onSubmit(rating, note)
+	}
+}
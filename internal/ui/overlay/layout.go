@@ -10,6 +10,7 @@ const (
 	overlayCardCornerRadius = float32(32)
 	overlayBottomClearance  = float32(12)
 	skipModeImageScale      = float32(1.05)
+	leftPanelBadgeGap       = float32(8)
 )
 
 // resizeToScreenFraction sizes the window from the detected screen size
@@ -312,10 +313,10 @@ func (layout *rightPanelLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 	return fyne.NewSize(width, imageMin.Height+skipMin.Height+overlayBottomClearance)
 }
 
-// leftPanelLayout stacks overlay copy and timer text
+// leftPanelLayout stacks overlay copy, timer text and the streak badge
 type leftPanelLayout struct{}
 
-// Layout arranges the title, subtitle, exercise text, and timer
+// Layout arranges the title, subtitle, exercise text, timer and optional badge
 func (layout *leftPanelLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 	if len(objects) < 4 {
 		return
@@ -356,6 +357,15 @@ func (layout *leftPanelLayout) Layout(objects []fyne.CanvasObject, size fyne.Siz
 
 	timer.Move(fyne.NewPos(pad, timerY))
 	timer.Resize(timerSize)
+
+	if len(objects) < 5 {
+		return
+	}
+
+	badge := objects[4]
+	badgeSize := badge.MinSize()
+	badge.Move(fyne.NewPos(pad+timerSize.Width+leftPanelBadgeGap, timerY+(timerSize.Height-badgeSize.Height)/2))
+	badge.Resize(badgeSize)
 }
 
 // MinSize returns the smallest left panel size for all text nodes
@@ -382,6 +392,10 @@ func (layout *leftPanelLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 		width = timerSize.Width
 	}
 
+	if len(objects) >= 5 && objects[4].Visible() {
+		width = max(width, timerSize.Width+leftPanelBadgeGap+objects[4].MinSize().Width)
+	}
+
 	height := titleSize.Height + subtitleSize.Height + exerciseSize.Height + timerSize.Height + 40
 
 	return fyne.NewSize(width+20, height)
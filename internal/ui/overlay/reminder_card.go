@@ -0,0 +1,93 @@
+package overlay
+
+import (
+	"eagleeye/internal/ui/i18n"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	reminderCardWidth        = float32(340)
+	reminderCardHeight       = float32(120)
+	reminderCardCornerRadius = float32(16)
+	reminderCardVisibleFor   = 12 * time.Second
+)
+
+// ReminderCard is a compact, non-blocking overlay used for custom reminders.
+// It hides itself after a short delay and never grabs the screen like a break
+type ReminderCard struct {
+	window        fyne.Window
+	localizer     *i18n.Localizer
+	title         *canvas.Text
+	message       *widget.Label
+	dismissButton *widget.Button
+	hideTimer     *time.Timer
+}
+
+// NewReminderCard creates a hidden reminder card window
+func NewReminderCard(app fyne.App, localizer *i18n.Localizer) *ReminderCard {
+	localizer = defaultOverlayLocalizer(localizer)
+	window := newOverlayWindow(app)
+
+	background := canvas.NewRectangle(overlayBackgroundColor(230))
+	background.CornerRadius = reminderCardCornerRadius
+
+	title := canvas.NewText("", color.NRGBA{R: 232, G: 190, B: 66, A: 255})
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = 16
+
+	message := widget.NewLabel("")
+	message.Wrapping = fyne.TextWrapWord
+
+	card := &ReminderCard{
+		window:    window,
+		localizer: localizer,
+		title:     title,
+		message:   message,
+	}
+
+	card.dismissButton = widget.NewButton(localizer.T("overlay.reminderDismiss"), card.Hide)
+	footer := container.NewHBox(layout.NewSpacer(), card.dismissButton)
+	content := container.NewPadded(container.NewBorder(title, footer, nil, nil, message))
+
+	window.SetContent(container.NewStack(background, content))
+	window.Resize(fyne.NewSize(reminderCardWidth, reminderCardHeight))
+	window.SetCloseIntercept(card.Hide)
+
+	return card
+}
+
+// Show displays the card with a reminder title and message
+func (card *ReminderCard) Show(title, message string) {
+	card.title.Text = title
+	card.title.Refresh()
+	card.message.SetText(message)
+	card.dismissButton.SetText(card.localizer.T("overlay.reminderDismiss"))
+
+	card.window.Resize(fyne.NewSize(reminderCardWidth, reminderCardHeight))
+	card.window.Show()
+
+	if card.hideTimer != nil {
+		card.hideTimer.Stop()
+	}
+
+	card.hideTimer = time.AfterFunc(reminderCardVisibleFor, func() {
+		fyne.Do(card.Hide)
+	})
+}
+
+// Hide closes the card if it is visible
+func (card *ReminderCard) Hide() {
+	if card.hideTimer != nil {
+		card.hideTimer.Stop()
+		card.hideTimer = nil
+	}
+
+	card.window.Hide()
+}
@@ -42,6 +42,7 @@ type Window struct {
 	titleLabel       *canvas.Text
 	subtitleLabel    *canvas.Text
 	exerciseLabel    *canvas.Text
+	badgeLabel       *canvas.Text
 	fullscreenBG     *canvas.Rectangle
 	cardBackground   *canvas.Rectangle
 	cardHost         *fyne.Container
@@ -49,10 +50,23 @@ type Window struct {
 	engine           *animation.Engine
 	cancelCtx        context.CancelFunc
 	onSkip           func()
+	onClose          func()
+	onResume         func()
 	localizer        *i18n.Localizer
 	currentExercise  animation.ExerciseType
 	strictMode       bool
 	cachedHWND       uintptr
+	session          activeSession
+}
+
+// activeSession remembers what the visible overlay is animating so a paused
+// break can restart its animation from the matching phase
+type activeSession struct {
+	ctx      context.Context
+	idle     bool
+	paused   bool
+	exercise animation.ExerciseSpec
+	idleSpec animation.IdleSpec
 }
 
 type splashWindowDriver interface {
@@ -64,6 +78,7 @@ type overlayLabels struct {
 	title    *canvas.Text
 	subtitle *canvas.Text
 	exercise *canvas.Text
+	badge    *canvas.Text
 }
 
 type overlayView struct {
@@ -146,7 +161,7 @@ func newOverlayView(config Config, localizer *i18n.Localizer) *overlayView {
 	labels := newOverlayLabels(config, localizer)
 	skipButton := widget.NewButton(localizer.T("overlay.skip"), nil)
 
-	leftContent := container.New(&leftPanelLayout{}, labels.title, labels.subtitle, labels.exercise, labels.timer)
+	leftContent := container.New(&leftPanelLayout{}, labels.title, labels.subtitle, labels.exercise, labels.timer, labels.badge)
 
 	rightLayout := &rightPanelLayout{}
 	rightContent := container.New(rightLayout, image, skipButton)
@@ -193,11 +208,17 @@ func newOverlayLabels(config Config, localizer *i18n.Localizer) overlayLabels {
 	exercise.Alignment = fyne.TextAlignLeading
 	exercise.TextSize = 17
 
+	badge := canvas.NewText("", color.NRGBA{R: 232, G: 190, B: 66, A: 255})
+	badge.Alignment = fyne.TextAlignLeading
+	badge.TextSize = 12
+	badge.Hide()
+
 	return overlayLabels{
 		timer:    timer,
 		title:    title,
 		subtitle: subtitle,
 		exercise: exercise,
+		badge:    badge,
 	}
 }
 
@@ -225,6 +246,7 @@ func newWindowState(
 		titleLabel:       view.labels.title,
 		subtitleLabel:    view.labels.subtitle,
 		exerciseLabel:    view.labels.exercise,
+		badgeLabel:       view.labels.badge,
 		fullscreenBG:     view.fullscreenBG,
 		cardBackground:   view.cardBackground,
 		cardHost:         view.cardHost,
@@ -244,9 +266,16 @@ func (overlay *Window) initializeWindow() {
 	overlay.applyNativeOpacity(overlay.config.Opacity)
 }
 
-// bindCloseHandler maps native close to the configured skip behavior
+// bindCloseHandler maps native close to the close handler, falling back to
+// the configured skip behavior
 func (overlay *Window) bindCloseHandler() {
 	overlay.window.SetCloseIntercept(func() {
+		if overlay.onClose != nil {
+			overlay.onClose()
+
+			return
+		}
+
 		if overlay.onSkip != nil {
 			overlay.onSkip()
 		}
@@ -270,6 +299,7 @@ func (overlay *Window) Show(session Session, spec animation.ExerciseSpec) {
 
 	spec.Duration = session.Remaining
 	spec.Type = session.Exercise
+	overlay.replaceSession(activeSession{ctx: ctx, exercise: spec})
 
 	overlay.setRemainingUnsafe(session.Remaining)
 	overlay.setExerciseUnsafe(session.Exercise)
@@ -296,6 +326,7 @@ func (overlay *Window) ShowIdle(remaining time.Duration, strict bool, idle anima
 
 	ctx, cancel := context.WithCancel(overlay.rootCtx)
 	overlay.cancelCtx = cancel
+	overlay.replaceSession(activeSession{ctx: ctx, idle: true, idleSpec: idle})
 
 	overlay.setRemainingUnsafe(remaining)
 	overlay.setExerciseUnsafe(animation.ExerciseBlink)
@@ -316,11 +347,82 @@ func (overlay *Window) ShowIdle(remaining time.Duration, strict bool, idle anima
 	}
 }
 
+// Pause freezes the active break session: the animation stops, the timer
+// holds at remaining, and the skip button becomes a Resume button that is
+// available even in strict mode
+func (overlay *Window) Pause(remaining time.Duration) {
+	if overlay.session.ctx == nil || overlay.session.paused {
+		return
+	}
+
+	overlay.session.paused = true
+
+	if overlay.engine != nil {
+		overlay.engine.Stop()
+	}
+
+	overlay.setRemainingUnsafe(remaining)
+	overlay.exerciseLabel.Text = overlay.localizer.T("overlay.paused")
+	overlay.exerciseLabel.Refresh()
+
+	overlay.skipButton.SetText(overlay.localizer.T("overlay.resume"))
+	overlay.skipButton.Show()
+	overlay.skipButton.Enable()
+
+	if overlay.rightPanel != nil {
+		overlay.rightPanel.Refresh()
+	}
+}
+
+// Resume leaves the paused state and restarts the animation from the phase
+// matching remaining, so the exercise ends together with the break timer
+func (overlay *Window) Resume(remaining time.Duration, strict bool) {
+	if !overlay.session.paused {
+		return
+	}
+
+	overlay.session.paused = false
+	overlay.skipButton.SetText(overlay.localizer.T("overlay.skip"))
+	overlay.setRemainingUnsafe(remaining)
+	overlay.setStrictModeUnsafe(strict)
+	overlay.setExerciseUnsafe(overlay.currentExercise)
+
+	if overlay.engine == nil || overlay.session.ctx == nil || overlay.session.ctx.Err() != nil {
+		return
+	}
+
+	if overlay.session.idle {
+		overlay.engine.StartIdle(overlay.session.ctx, overlay.session.idleSpec)
+
+		return
+	}
+
+	spec := overlay.session.exercise
+	overlay.engine.StartExerciseAt(overlay.session.ctx, spec, spec.Duration-remaining)
+}
+
+// replaceSession swaps the active session and restores the skip button text
+// if the previous session was left paused
+func (overlay *Window) replaceSession(session activeSession) {
+	if overlay.session.paused {
+		overlay.skipButton.SetText(overlay.localizer.T("overlay.skip"))
+	}
+
+	overlay.session = session
+}
+
+// Paused reports whether the overlay currently shows a paused break
+func (overlay *Window) Paused() bool {
+	return overlay.session.paused
+}
+
 // Hide closes the overlay and stops animations
 func (overlay *Window) Hide() {
 	overlay.releaseClipCursor()
 	overlay.stopEngine()
 
+	overlay.replaceSession(activeSession{})
+
 	if overlay.config.Fullscreen {
 		overlay.window.SetFullScreen(false)
 	}
@@ -355,11 +457,34 @@ func (overlay *Window) SetExercise(exercise animation.ExerciseType) {
 // SetOnSkip sets skip handler
 func (overlay *Window) SetOnSkip(handler func()) {
 	overlay.onSkip = handler
-	overlay.skipButton.OnTapped = func() {
-		if overlay.onSkip != nil {
-			overlay.onSkip()
+	overlay.skipButton.OnTapped = overlay.handleActionTapped
+}
+
+// SetOnResume sets the handler for the Resume button of a paused break
+func (overlay *Window) SetOnResume(handler func()) {
+	overlay.onResume = handler
+	overlay.skipButton.OnTapped = overlay.handleActionTapped
+}
+
+// handleActionTapped routes the shared action button to resume or skip
+func (overlay *Window) handleActionTapped() {
+	if overlay.session.paused {
+		if overlay.onResume != nil {
+			overlay.onResume()
 		}
+
+		return
 	}
+
+	if overlay.onSkip != nil {
+		overlay.onSkip()
+	}
+}
+
+// SetOnClose sets the native close button handler. When unset, closing the
+// window behaves like the skip button
+func (overlay *Window) SetOnClose(handler func()) {
+	overlay.onClose = handler
 }
 
 // UpdateConfig applies visual settings and stores window mode for the next show
@@ -383,8 +508,13 @@ func (overlay *Window) RefreshLocalization() {
 		overlay.titleLabel.Text = overlay.localizer.T("overlay.title")
 		overlay.subtitleLabel.Text = overlay.config.Message
 
-		overlay.skipButton.SetText(overlay.localizer.T("overlay.skip"))
-		overlay.setExerciseUnsafe(overlay.currentExercise)
+		if overlay.session.paused {
+			overlay.skipButton.SetText(overlay.localizer.T("overlay.resume"))
+			overlay.exerciseLabel.Text = overlay.localizer.T("overlay.paused")
+		} else {
+			overlay.skipButton.SetText(overlay.localizer.T("overlay.skip"))
+			overlay.setExerciseUnsafe(overlay.currentExercise)
+		}
 
 		overlay.titleLabel.Refresh()
 		overlay.subtitleLabel.Refresh()
@@ -392,6 +522,22 @@ func (overlay *Window) RefreshLocalization() {
 	})
 }
 
+// SetBadge shows a short achievement or streak note next to the timer; an
+// empty text hides it
+func (overlay *Window) SetBadge(text string) {
+	fyne.Do(func() {
+		overlay.badgeLabel.Text = text
+
+		if text == "" {
+			overlay.badgeLabel.Hide()
+		} else {
+			overlay.badgeLabel.Show()
+		}
+
+		overlay.badgeLabel.Refresh()
+	})
+}
+
 // SetSprite updates the center sprite image
 func (overlay *Window) SetSprite(resource fyne.Resource) {
 	fyne.Do(func() {
@@ -452,9 +598,15 @@ func (overlay *Window) setStrictModeUnsafe(enabled bool) {
 	}
 }
 
-// setExerciseUnsafe updates exercise text on the current UI context
+// setExerciseUnsafe updates exercise text on the current UI context. While a
+// break is paused only the exercise is recorded; the label keeps the paused text
 func (overlay *Window) setExerciseUnsafe(exercise animation.ExerciseType) {
 	overlay.currentExercise = exercise
+
+	if overlay.session.paused {
+		return
+	}
+
 	overlay.exerciseLabel.Text = exerciseDescription(exercise, overlay.localizer)
 
 	overlay.exerciseLabel.Refresh()
@@ -0,0 +1,221 @@
+package preferences
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	bundleFileName      = "eagleeye-settings.yaml"
+	maxBundleReadSize   = 256 * 1024
+	bundlePreviewWidth  = float32(480)
+	bundlePreviewHeight = float32(320)
+)
+
+// ImportPreview describes what importing a bundle would change. Changes and
+// Ignored are display lines; Settings is applied only after confirmation
+type ImportPreview struct {
+	Settings Settings
+	Changes  []string
+	Ignored  []string
+}
+
+// bundleControls is the export/import/history row shown in the main
+// preferences form
+type bundleControls struct {
+	label         *widget.Label
+	exportButton  *widget.Button
+	importButton  *widget.Button
+	historyButton *widget.Button
+	row           fyne.CanvasObject
+}
+
+func newBundleControls() bundleControls {
+	label := widget.NewLabel("")
+	exportButton := widget.NewButton("", nil)
+	importButton := widget.NewButton("", nil)
+	historyButton := widget.NewButton("", nil)
+
+	return bundleControls{
+		label:         label,
+		exportButton:  exportButton,
+		importButton:  importButton,
+		historyButton: historyButton,
+		row:           container.NewHBox(label, layout.NewSpacer(), exportButton, importButton, historyButton),
+	}
+}
+
+// showExportDialog asks whether to include machine-specific settings and
+// then where to write the bundle
+func (prefs *Window) showExportDialog() {
+	if prefs.callbacks.OnExportBundle == nil {
+		return
+	}
+
+	machine := widget.NewCheck(prefs.uiLocalizer.T("prefs.bundleMachine"), nil)
+
+	dialog.ShowCustomConfirm(
+		prefs.uiLocalizer.T("prefs.bundleExportTitle"),
+		prefs.uiLocalizer.T("prefs.bundleExport"),
+		prefs.uiLocalizer.T("prefs.cancel"),
+		machine,
+		func(confirmed bool) {
+			if confirmed {
+				prefs.chooseExportFile(machine.Checked)
+			}
+		},
+		prefs.window,
+	)
+}
+
+func (prefs *Window) chooseExportFile(includeMachine bool) {
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, prefs.window)
+
+			return
+		}
+
+		if writer == nil {
+			return
+		}
+
+		exportErr := prefs.callbacks.OnExportBundle(writer, includeMachine)
+		closeErr := writer.Close()
+
+		if exportErr == nil {
+			exportErr = closeErr
+		}
+
+		if exportErr != nil {
+			dialog.ShowError(exportErr, prefs.window)
+
+			return
+		}
+
+		dialog.ShowInformation(
+			prefs.uiLocalizer.T("prefs.bundleExportTitle"),
+			prefs.uiLocalizer.T("prefs.bundleExported", writer.URI().Name()),
+			prefs.window,
+		)
+	}, prefs.window)
+
+	saveDialog.SetFileName(bundleFileName)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".yaml", ".yml"}))
+	saveDialog.Show()
+}
+
+// showImportDialog picks a bundle file and previews it before applying
+func (prefs *Window) showImportDialog() {
+	if prefs.callbacks.OnPreviewImport == nil {
+		return
+	}
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, prefs.window)
+
+			return
+		}
+
+		if reader == nil {
+			return
+		}
+
+		data, err := io.ReadAll(io.LimitReader(reader, maxBundleReadSize+1))
+		_ = reader.Close()
+
+		if err != nil {
+			dialog.ShowError(err, prefs.window)
+
+			return
+		}
+
+		prefs.showImportPreview(data)
+	}, prefs.window)
+
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".yaml", ".yml"}))
+	openDialog.Show()
+}
+
+// showImportPreview lists the changes a bundle would make. Toggling the
+// machine-specific checkbox recomputes the preview
+func (prefs *Window) showImportPreview(data []byte) {
+	preview, err := prefs.callbacks.OnPreviewImport(data, false)
+	if err != nil {
+		dialog.ShowError(err, prefs.window)
+
+		return
+	}
+
+	details := widget.NewLabel(prefs.importPreviewText(preview))
+	details.Wrapping = fyne.TextWrapWord
+
+	machine := widget.NewCheck(prefs.uiLocalizer.T("prefs.bundleMachine"), func(include bool) {
+		updated, err := prefs.callbacks.OnPreviewImport(data, include)
+		if err != nil {
+			dialog.ShowError(err, prefs.window)
+
+			return
+		}
+
+		preview = updated
+		details.SetText(prefs.importPreviewText(preview))
+	})
+
+	content := container.NewBorder(nil, machine, nil, nil, container.NewVScroll(details))
+	confirm := dialog.NewCustomConfirm(
+		prefs.uiLocalizer.T("prefs.bundleImportTitle"),
+		prefs.uiLocalizer.T("prefs.reminderApply"),
+		prefs.uiLocalizer.T("prefs.cancel"),
+		content,
+		func(apply bool) {
+			if !apply || len(preview.Changes) == 0 {
+				return
+			}
+
+			prefs.UpdateSettings(preview.Settings)
+
+			if prefs.callbacks.OnImport != nil {
+				prefs.callbacks.OnImport(preview.Settings)
+			}
+		},
+		prefs.window,
+	)
+
+	confirm.Resize(fyne.NewSize(bundlePreviewWidth, bundlePreviewHeight))
+	confirm.Show()
+}
+
+func (prefs *Window) importPreviewText(preview ImportPreview) string {
+	var builder strings.Builder
+
+	if len(preview.Changes) == 0 {
+		builder.WriteString(prefs.uiLocalizer.T("prefs.bundleNoChanges"))
+	} else {
+		builder.WriteString(prefs.uiLocalizer.T("prefs.bundleChanges"))
+
+		for _, change := range preview.Changes {
+			fmt.Fprintf(&builder, "\n• %s", change)
+		}
+	}
+
+	if len(preview.Ignored) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(prefs.uiLocalizer.T("prefs.issuesTitle"))
+
+		for _, issue := range preview.Ignored {
+			fmt.Fprintf(&builder, "\n• %s", issue)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,91 @@
+package preferences
+
+import (
+	"eagleeye/internal/ui/i18n"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+const checkInSelectWrapWidth = float32(250)
+
+// checkInPresets are the comfort check-in frequencies offered in the form
+var checkInPresets = []int{0, 1, 2, 3}
+
+type checkInControls struct {
+	label     *widget.Label
+	selectBox *widget.Select
+	row       fyne.CanvasObject
+}
+
+func newCheckInControls() checkInControls {
+	label := widget.NewLabel("")
+	selectBox := widget.NewSelect(nil, nil)
+
+	selectWrap := container.NewGridWrap(
+		fyne.NewSize(checkInSelectWrapWidth, selectBox.MinSize().Height),
+		selectBox,
+	)
+
+	return checkInControls{
+		label:     label,
+		selectBox: selectBox,
+		row:       container.NewHBox(label, selectWrap, layout.NewSpacer()),
+	}
+}
+
+// checkInChoices lists the presets plus current when settings.yaml holds
+// another frequency, so saving the form keeps it
+func checkInChoices(current int) []int {
+	choices := append([]int(nil), checkInPresets...)
+	if current > 0 && !slices.Contains(choices, current) {
+		choices = append(choices, current)
+	}
+
+	return choices
+}
+
+// checkInOptionText names one check-in frequency
+func checkInOptionText(localizer *i18n.Localizer, every int) string {
+	switch every {
+	case 0:
+		return localizer.T("prefs.checkInOff")
+	case 1:
+		return localizer.T("prefs.checkInEvery")
+	default:
+		return localizer.T("prefs.checkInEveryN", every)
+	}
+}
+
+// setCheckInEvery shows every in the check-in select
+func (prefs *Window) setCheckInEvery(every int) {
+	prefs.checkInChoices = checkInChoices(every)
+	prefs.refreshCheckInOptions(every)
+}
+
+// refreshCheckInOptions relabels the check-in select and selects every
+func (prefs *Window) refreshCheckInOptions(every int) {
+	options := make([]string, 0, len(prefs.checkInChoices))
+	for _, choice := range prefs.checkInChoices {
+		options = append(options, checkInOptionText(prefs.uiLocalizer, choice))
+	}
+
+	prefs.checkInSelect.SetOptions(options)
+
+	if index := slices.Index(prefs.checkInChoices, every); index >= 0 {
+		prefs.checkInSelect.SetSelectedIndex(index)
+	}
+}
+
+// checkInEvery is the frequency selected in the form
+func (prefs *Window) checkInEvery() int {
+	index := prefs.checkInSelect.SelectedIndex()
+	if index < 0 || index >= len(prefs.checkInChoices) {
+		return prefs.settings.CheckInEvery
+	}
+
+	return prefs.checkInChoices[index]
+}
@@ -0,0 +1,133 @@
+package preferences
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// diagnosticsShortcut shows or hides the Diagnostics section
+var diagnosticsShortcut = &desktop.CustomShortcut{
+	KeyName:  fyne.KeyD,
+	Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift,
+}
+
+// diagnosticsControls pick the default log level and each subsystem's level.
+// The section is hidden unless the levels were changed or it was opened with
+// diagnosticsShortcut
+type diagnosticsControls struct {
+	title      *widget.Label
+	baseLabel  *widget.Label
+	base       *widget.Select
+	labels     map[string]*widget.Label
+	subsystems map[string]*widget.Select
+	content    *fyne.Container
+}
+
+func newDiagnosticsControls() diagnosticsControls {
+	title := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	baseLabel := widget.NewLabel("")
+	base := widget.NewSelect(LogLevelNames, nil)
+	rows := container.New(layout.NewFormLayout(), baseLabel, base)
+
+	labels := make(map[string]*widget.Label, len(LogSubsystems))
+	subsystems := make(map[string]*widget.Select, len(LogSubsystems))
+
+	for _, subsystem := range LogSubsystems {
+		labels[subsystem] = widget.NewLabel("")
+		subsystems[subsystem] = widget.NewSelect(nil, nil)
+		rows.Add(labels[subsystem])
+		rows.Add(subsystems[subsystem])
+	}
+
+	content := container.NewVBox(widget.NewSeparator(), title, rows)
+	content.Hide()
+
+	return diagnosticsControls{
+		title:      title,
+		baseLabel:  baseLabel,
+		base:       base,
+		labels:     labels,
+		subsystems: subsystems,
+		content:    content,
+	}
+}
+
+// diagnosticsLabelKeys maps each subsystem to its label
+var diagnosticsLabelKeys = map[string]string{
+	"timekeeper": "prefs.logLevelTimekeeper",
+	"overlay":    "prefs.logLevelOverlay",
+	"platform":   "prefs.logLevelPlatform",
+}
+
+// hasCustomLogLevels reports whether settings log differently from defaults
+func hasCustomLogLevels(settings Settings) bool {
+	return settings.LogLevelSummary() != DefaultSettings().LogLevelSummary()
+}
+
+// toggleDiagnostics shows or hides the Diagnostics section
+func (prefs *Window) toggleDiagnostics() {
+	if prefs.diagnostics.content.Visible() {
+		prefs.diagnostics.content.Hide()
+	} else {
+		prefs.diagnostics.content.Show()
+	}
+
+	prefs.resizeForNotices()
+}
+
+// setLogLevels shows the levels of settings in the Diagnostics section and
+// reveals it when they differ from the defaults
+func (prefs *Window) setLogLevels(settings Settings) {
+	prefs.diagnostics.base.SetSelected(settings.LogLevel)
+	prefs.refreshDiagnostics(settings)
+
+	if hasCustomLogLevels(settings) && !prefs.diagnostics.content.Visible() {
+		prefs.diagnostics.content.Show()
+		prefs.resizeForNotices()
+	}
+}
+
+// refreshDiagnostics relabels the Diagnostics section and selects the
+// subsystem levels of settings
+func (prefs *Window) refreshDiagnostics(settings Settings) {
+	prefs.diagnostics.title.SetText(prefs.uiLocalizer.T("prefs.diagnostics"))
+	prefs.diagnostics.baseLabel.SetText(prefs.uiLocalizer.T("prefs.logLevel"))
+
+	inherit := prefs.uiLocalizer.T("prefs.logLevelInherit")
+
+	for _, subsystem := range LogSubsystems {
+		prefs.diagnostics.labels[subsystem].SetText(prefs.uiLocalizer.T(diagnosticsLabelKeys[subsystem]))
+
+		selectBox := prefs.diagnostics.subsystems[subsystem]
+		selectBox.SetOptions(append([]string{inherit}, LogLevelNames...))
+
+		if level := settings.SubsystemLogLevel(subsystem); level != "" {
+			selectBox.SetSelected(level)
+		} else {
+			selectBox.SetSelectedIndex(0)
+		}
+	}
+}
+
+// formLogLevels are the levels selected in the Diagnostics section
+func (prefs *Window) formLogLevels() Settings {
+	settings := prefs.settings
+
+	if level := prefs.diagnostics.base.Selected; level != "" {
+		settings.LogLevel = level
+	}
+
+	for _, subsystem := range LogSubsystems {
+		level := ""
+		if index := prefs.diagnostics.subsystems[subsystem].SelectedIndex(); index > 0 {
+			level = LogLevelNames[index-1]
+		}
+
+		_ = settings.SetLogLevel(subsystem, level)
+	}
+
+	return settings
+}
@@ -0,0 +1,233 @@
+package preferences
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	durationEntryWidth = float32(150)
+	durationUnitWidth  = float32(80)
+	maxDurationInput   = 24 * time.Hour
+)
+
+var (
+	errDurationEmpty   = errors.New("enter a duration such as 25, 1m30s or 1:30")
+	errDurationInvalid = errors.New("not a duration, try 25, 12.5, 1m30s or 1:30")
+	errDurationRange   = fmt.Errorf("must be between 1s and %s", FormatDuration(maxDurationInput))
+)
+
+// durationUnits are the units offered by DurationEntry, smallest first
+var durationUnits = []time.Duration{time.Second, time.Minute, time.Hour}
+
+// durationWords maps spelled-out units to Go duration suffixes, longest first
+// so "minutes" is not read as "min" followed by "utes"
+var durationWords = strings.NewReplacer(
+	"hours", "h", "hour", "h", "hrs", "h", "hr", "h",
+	"minutes", "m", "minute", "m", "mins", "m", "min", "m",
+	"seconds", "s", "second", "s", "secs", "s", "sec", "s",
+	"мин", "m", "сек", "s", "ч", "h",
+)
+
+// DurationEntry edits a duration as a number in the selected unit. Text with
+// its own units, such as 90s, 1m30s or 1:30, ignores the selected unit
+type DurationEntry struct {
+	widget.BaseWidget
+
+	entry *widget.Entry
+	unit  *widget.Select
+
+	unitNames []string
+	unitSize  time.Duration
+}
+
+// NewDurationEntry returns an entry showing value in its most readable unit.
+func NewDurationEntry(value time.Duration) *DurationEntry {
+	durationEntry := &DurationEntry{
+		entry:     widget.NewEntry(),
+		unitNames: []string{"sec", "min", "h"},
+		unitSize:  time.Minute,
+	}
+
+	durationEntry.unit = widget.NewSelect(durationEntry.unitNames, durationEntry.selectUnit)
+	durationEntry.entry.Validator = func(text string) error {
+		_, err := ParseDurationInput(text, durationEntry.unitSize)
+
+		return err
+	}
+
+	durationEntry.SetDuration(value)
+	durationEntry.ExtendBaseWidget(durationEntry)
+
+	return durationEntry
+}
+
+// CreateRenderer lays out the text entry with the unit selector on its right.
+func (durationEntry *DurationEntry) CreateRenderer() fyne.WidgetRenderer {
+	unit := container.NewGridWrap(fyne.NewSize(durationUnitWidth, durationEntry.unit.MinSize().Height), durationEntry.unit)
+
+	return widget.NewSimpleRenderer(container.NewBorder(nil, nil, nil, unit, durationEntry.entry))
+}
+
+// Duration parses the current text in the selected unit.
+func (durationEntry *DurationEntry) Duration() (time.Duration, error) {
+	return ParseDurationInput(durationEntry.entry.Text, durationEntry.unitSize)
+}
+
+// SetDuration shows value in its most readable unit.
+func (durationEntry *DurationEntry) SetDuration(value time.Duration) {
+	text, unit := FormatDurationInput(value)
+
+	durationEntry.unitSize = unit
+	durationEntry.entry.SetText(text)
+	durationEntry.unit.SetSelectedIndex(unitIndex(unit))
+}
+
+// SetUnitNames relabels the seconds, minutes and hours options.
+func (durationEntry *DurationEntry) SetUnitNames(seconds, minutes, hours string) {
+	durationEntry.unitNames = []string{seconds, minutes, hours}
+	durationEntry.unit.Options = durationEntry.unitNames
+	durationEntry.unit.SetSelectedIndex(unitIndex(durationEntry.unitSize))
+}
+
+// Enable allows editing.
+func (durationEntry *DurationEntry) Enable() {
+	durationEntry.entry.Enable()
+	durationEntry.unit.Enable()
+}
+
+// Disable blocks editing.
+func (durationEntry *DurationEntry) Disable() {
+	durationEntry.entry.Disable()
+	durationEntry.unit.Disable()
+}
+
+// Disabled reports whether editing is blocked.
+func (durationEntry *DurationEntry) Disabled() bool {
+	return durationEntry.entry.Disabled()
+}
+
+// selectUnit reinterprets a bare number in the newly chosen unit
+func (durationEntry *DurationEntry) selectUnit(name string) {
+	for index, unitName := range durationEntry.unitNames {
+		if unitName == name {
+			durationEntry.unitSize = durationUnits[index]
+		}
+	}
+
+	_ = durationEntry.entry.Validate()
+}
+
+func unitIndex(unit time.Duration) int {
+	for index, candidate := range durationUnits {
+		if candidate == unit {
+			return index
+		}
+	}
+
+	return 1
+}
+
+// ParseDurationInput reads human duration input. Bare numbers, including
+// decimals with a comma or point, are in unit; "m:ss" is minutes and
+// seconds; anything else is a Go duration that may spell units out, such as
+// "1 min 30 sec". The result is rounded to whole seconds
+func ParseDurationInput(text string, unit time.Duration) (time.Duration, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return 0, errDurationEmpty
+	}
+
+	text = strings.ReplaceAll(text, ",", ".")
+
+	var (
+		value time.Duration
+		err   error
+	)
+
+	switch {
+	case strings.Contains(text, ":"):
+		value, err = parseClockInput(text)
+	default:
+		if number, numberErr := strconv.ParseFloat(text, 64); numberErr == nil {
+			value, err = scaleDuration(number, unit)
+		} else {
+			value, err = time.ParseDuration(durationWords.Replace(strings.ReplaceAll(text, " ", "")))
+		}
+	}
+
+	if err != nil {
+		return 0, errDurationInvalid
+	}
+
+	value = value.Round(time.Second)
+	if value < time.Second || value > maxDurationInput {
+		return 0, errDurationRange
+	}
+
+	return value, nil
+}
+
+// parseClockInput reads "m:ss"
+func parseClockInput(text string) (time.Duration, error) {
+	minutesText, secondsText, _ := strings.Cut(text, ":")
+
+	minutes, err := strconv.Atoi(minutesText)
+	if err != nil || minutes < 0 {
+		return 0, errDurationInvalid
+	}
+
+	seconds, err := strconv.Atoi(secondsText)
+	if err != nil || seconds < 0 || seconds >= 60 || len(secondsText) != 2 {
+		return 0, errDurationInvalid
+	}
+
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, nil
+}
+
+func scaleDuration(number float64, unit time.Duration) (time.Duration, error) {
+	if math.IsNaN(number) || number < 0 || number*float64(unit) > float64(maxDurationInput) {
+		return 0, errDurationRange
+	}
+
+	return time.Duration(number * float64(unit)), nil
+}
+
+// FormatDurationInput picks the largest unit that shows value as a whole
+// number or a half, falling back to seconds
+func FormatDurationInput(value time.Duration) (string, time.Duration) {
+	for index := len(durationUnits) - 1; index >= 0; index-- {
+		unit := durationUnits[index]
+		halves := value * 2 / unit
+
+		if value >= unit && value*2%unit == 0 {
+			return strconv.FormatFloat(float64(halves)/2, 'f', -1, 64), unit
+		}
+	}
+
+	return strconv.FormatInt(int64(value/time.Second), 10), time.Second
+}
+
+// FormatDuration renders value as a compact Go duration such as 25m, 1m30s
+// or 1h, without the trailing zero units of time.Duration.String
+func FormatDuration(value time.Duration) string {
+	text := value.String()
+
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+
+	return text
+}
@@ -0,0 +1,68 @@
+package preferences
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseDurationInput covers bare numbers, clock input and spelled units
+func TestParseDurationInput(t *testing.T) {
+	tests := []struct {
+		text string
+		unit time.Duration
+		want time.Duration
+	}{
+		{text: "25", unit: time.Minute, want: 25 * time.Minute},
+		{text: "12.5", unit: time.Minute, want: 12*time.Minute + 30*time.Second},
+		{text: "12,5", unit: time.Minute, want: 12*time.Minute + 30*time.Second},
+		{text: "90", unit: time.Second, want: 90 * time.Second},
+		{text: "1:30", unit: time.Hour, want: 90 * time.Second},
+		{text: "1m30s", unit: time.Hour, want: 90 * time.Second},
+		{text: "1 min 30 sec", unit: time.Second, want: 90 * time.Second},
+		{text: "2 hours", unit: time.Second, want: 2 * time.Hour},
+		{text: "1.5s", unit: time.Minute, want: 2 * time.Second},
+	}
+
+	for _, test := range tests {
+		got, err := ParseDurationInput(test.text, test.unit)
+		if err != nil || got != test.want {
+			t.Fatalf("ParseDurationInput(%q, %v) = %v, %v, want %v", test.text, test.unit, got, err, test.want)
+		}
+	}
+
+	for _, text := range []string{"", "soon", "0", "-5", "1:75", "25h", "0.2s"} {
+		if got, err := ParseDurationInput(text, time.Minute); err == nil {
+			t.Fatalf("ParseDurationInput(%q) = %v, want an error", text, got)
+		}
+	}
+}
+
+// TestFormatDurationInput verifies the unit chosen for display
+func TestFormatDurationInput(t *testing.T) {
+	tests := []struct {
+		value    time.Duration
+		wantText string
+		wantUnit time.Duration
+	}{
+		{value: 15 * time.Second, wantText: "15", wantUnit: time.Second},
+		{value: 20 * time.Minute, wantText: "20", wantUnit: time.Minute},
+		{value: 12*time.Minute + 30*time.Second, wantText: "12.5", wantUnit: time.Minute},
+		{value: 95 * time.Second, wantText: "95", wantUnit: time.Second},
+		{value: 2 * time.Hour, wantText: "2", wantUnit: time.Hour},
+	}
+
+	for _, test := range tests {
+		text, unit := FormatDurationInput(test.value)
+		if text != test.wantText || unit != test.wantUnit {
+			t.Fatalf("FormatDurationInput(%v) = %s %v, want %s %v", test.value, text, unit, test.wantText, test.wantUnit)
+		}
+	}
+
+	if got := FormatDuration(time.Hour + 30*time.Second); got != "1h0m30s" {
+		t.Fatalf("FormatDuration(1h0m30s) = %s", got)
+	}
+
+	if got := FormatDuration(25 * time.Minute); got != "25m" {
+		t.Fatalf("FormatDuration(25m) = %s, want 25m", got)
+	}
+}
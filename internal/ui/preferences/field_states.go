@@ -0,0 +1,137 @@
+package preferences
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// FieldState describes a setting that a layer other than the user controls.
+// Keys of the state map are settings.yaml field names
+type FieldState struct {
+	Disabled bool
+	Reason   string
+}
+
+// fieldLabelKeys maps settings.yaml fields to the label shown for them
+var fieldLabelKeys = map[string]string{
+	"short_interval":        "prefs.shortBreakEvery",
+	"short_duration":        "prefs.shortBreakDuration",
+	"long_interval":         "prefs.longBreakEvery",
+	"long_duration":         "prefs.longBreakDuration",
+	"strict_mode":           "prefs.strictMode",
+	"idle_enabled":          "prefs.idleTracking",
+	"overlay_opacity":       "prefs.overlayOpacity",
+	"fullscreen":            "prefs.fullscreenOverlay",
+	"run_on_startup":        "prefs.runOnStartup",
+	"weekly_report":         "prefs.weeklyReport",
+	"language":              "prefs.language",
+	"comfort_checkin_every": "prefs.checkIn",
+	"reminders":             "prefs.reminders",
+	"log_level":             "prefs.logLevel",
+	"log_level_timekeeper":  "prefs.logLevelTimekeeper",
+	"log_level_overlay":     "prefs.logLevelOverlay",
+	"log_level_platform":    "prefs.logLevelPlatform",
+}
+
+// fieldOrder lists fields in the order they appear in the window
+var fieldOrder = []string{
+	"short_interval",
+	"short_duration",
+	"long_interval",
+	"long_duration",
+	"strict_mode",
+	"idle_enabled",
+	"fullscreen",
+	"run_on_startup",
+	"weekly_report",
+	"language",
+	"comfort_checkin_every",
+	"reminders",
+	"overlay_opacity",
+	"log_level",
+	"log_level_timekeeper",
+	"log_level_overlay",
+	"log_level_platform",
+}
+
+func newFieldNote() (*widget.Label, *fyne.Container) {
+	note := widget.NewLabel("")
+	note.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(note, widget.NewSeparator())
+	content.Hide()
+
+	return note, content
+}
+
+// SetFieldStates disables fields controlled by policy or overrides and lists
+// the reasons above the form.
+func (prefs *Window) SetFieldStates(states map[string]FieldState) {
+	copied := make(map[string]FieldState, len(states))
+	for key, state := range states {
+		copied[key] = state
+	}
+
+	fyne.Do(func() {
+		prefs.fieldStates = copied
+		prefs.refreshFieldStates()
+	})
+}
+
+func (prefs *Window) fieldWidgets() map[string]fyne.Disableable {
+	return map[string]fyne.Disableable{
+		"short_interval":        prefs.shortInt,
+		"short_duration":        prefs.shortDur,
+		"long_interval":         prefs.longInt,
+		"long_duration":         prefs.longDur,
+		"strict_mode":           prefs.strict,
+		"idle_enabled":          prefs.idleCheck,
+		"overlay_opacity":       prefs.opacity,
+		"fullscreen":            prefs.fullscreen,
+		"run_on_startup":        prefs.runOnStartup,
+		"weekly_report":         prefs.weeklyReport,
+		"language":              prefs.languageSelect,
+		"comfort_checkin_every": prefs.checkInSelect,
+		"reminders":             prefs.manageReminders,
+		"log_level":             prefs.diagnostics.base,
+		"log_level_timekeeper":  prefs.diagnostics.subsystems["timekeeper"],
+		"log_level_overlay":     prefs.diagnostics.subsystems["overlay"],
+		"log_level_platform":    prefs.diagnostics.subsystems["platform"],
+	}
+}
+
+func (prefs *Window) refreshFieldStates() {
+	for key, item := range prefs.fieldWidgets() {
+		if prefs.fieldStates[key].Disabled {
+			item.Disable()
+		} else {
+			item.Enable()
+		}
+	}
+
+	lines := make([]string, 0, len(prefs.fieldStates))
+
+	for _, key := range fieldOrder {
+		state, ok := prefs.fieldStates[key]
+		if !ok || state.Reason == "" {
+			continue
+		}
+
+		label := strings.TrimSuffix(prefs.uiLocalizer.T(fieldLabelKeys[key]), ":")
+		lines = append(lines, fmt.Sprintf("• %s: %s", label, state.Reason))
+	}
+
+	if len(lines) == 0 {
+		prefs.fieldNote.SetText("")
+		prefs.fieldNoteBox.Hide()
+	} else {
+		prefs.fieldNote.SetText(prefs.uiLocalizer.T("prefs.managedTitle") + "\n" + strings.Join(lines, "\n"))
+		prefs.fieldNoteBox.Show()
+	}
+
+	prefs.resizeForNotices()
+}
@@ -0,0 +1,188 @@
+package preferences
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	historyDialogWidth  = float32(520)
+	historyDialogHeight = float32(420)
+	historyListWidth    = float32(200)
+	historyTimeFormat   = "2006-01-02 15:04"
+)
+
+// HistoryEntry is one recorded settings change. Source is the journal's
+// source name; Changes are display lines; Settings is the snapshot after the
+// change
+type HistoryEntry struct {
+	Time     time.Time
+	Source   string
+	Changes  []string
+	Settings Settings
+}
+
+// historySourceKeys maps journal source names to localization keys
+var historySourceKeys = map[string]string{
+	"preferences": "prefs.historyFromPreferences",
+	"file":        "prefs.historyFromFile",
+	"import":      "prefs.historyFromImport",
+	"cli":         "prefs.historyFromCLI",
+	"history":     "prefs.historyFromHistory",
+}
+
+// showHistoryDialog lists recorded changes, newest first, with actions to
+// restore a snapshot or the defaults
+func (prefs *Window) showHistoryDialog() {
+	if prefs.callbacks.OnLoadHistory == nil {
+		return
+	}
+
+	entries, err := prefs.callbacks.OnLoadHistory()
+	if err != nil {
+		dialog.ShowError(err, prefs.window)
+
+		return
+	}
+
+	details := widget.NewLabel(prefs.uiLocalizer.T("prefs.historyEmpty"))
+	details.Wrapping = fyne.TextWrapWord
+
+	revert := widget.NewButton(prefs.uiLocalizer.T("prefs.historyRevert"), nil)
+	revert.Disable()
+
+	reset := widget.NewButton(prefs.uiLocalizer.T("prefs.historyReset"), nil)
+
+	list := widget.NewList(
+		func() int {
+			return len(entries)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(index widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(prefs.historyEntryTitle(entries[index]))
+		},
+	)
+
+	selected := -1
+	list.OnSelected = func(index widget.ListItemID) {
+		selected = index
+		details.SetText(prefs.historyEntryText(entries[index]))
+		revert.Enable()
+	}
+
+	if len(entries) > 0 {
+		details.SetText(prefs.uiLocalizer.T("prefs.historySelect"))
+	}
+
+	var history dialog.Dialog
+
+	revert.OnTapped = func() {
+		if selected >= 0 {
+			prefs.confirmRestore(prefs.uiLocalizer.T("prefs.historyRevertTitle"), entries[selected].Settings, history)
+		}
+	}
+	reset.OnTapped = func() {
+		prefs.confirmRestore(prefs.uiLocalizer.T("prefs.historyResetTitle"), DefaultSettings(), history)
+	}
+
+	listPane := container.NewGridWrap(fyne.NewSize(historyListWidth, historyDialogHeight-120), list)
+	content := container.NewBorder(
+		nil,
+		container.NewHBox(revert, reset),
+		listPane,
+		nil,
+		container.NewVScroll(details),
+	)
+
+	history = dialog.NewCustom(
+		prefs.uiLocalizer.T("prefs.historyTitle"),
+		prefs.uiLocalizer.T("prefs.close"),
+		content,
+		prefs.window,
+	)
+	history.Resize(fyne.NewSize(historyDialogWidth, historyDialogHeight))
+	history.Show()
+}
+
+// confirmRestore previews what applying target would change and applies it
+// after confirmation
+func (prefs *Window) confirmRestore(title string, target Settings, history dialog.Dialog) {
+	var changes []string
+	if prefs.callbacks.OnPreviewRestore != nil {
+		changes = prefs.callbacks.OnPreviewRestore(target)
+	}
+
+	details := widget.NewLabel(prefs.restorePreviewText(changes))
+	details.Wrapping = fyne.TextWrapWord
+
+	confirm := dialog.NewCustomConfirm(
+		title,
+		prefs.uiLocalizer.T("prefs.reminderApply"),
+		prefs.uiLocalizer.T("prefs.cancel"),
+		container.NewVScroll(details),
+		func(apply bool) {
+			if !apply || len(changes) == 0 {
+				return
+			}
+
+			history.Hide()
+			prefs.UpdateSettings(target)
+
+			if prefs.callbacks.OnRestore != nil {
+				prefs.callbacks.OnRestore(target)
+			}
+		},
+		prefs.window,
+	)
+
+	confirm.Resize(fyne.NewSize(bundlePreviewWidth, bundlePreviewHeight))
+	confirm.Show()
+}
+
+func (prefs *Window) historyEntryTitle(entry HistoryEntry) string {
+	return fmt.Sprintf("%s · %s", entry.Time.Local().Format(historyTimeFormat), prefs.historySourceName(entry.Source))
+}
+
+func (prefs *Window) historyEntryText(entry HistoryEntry) string {
+	var builder strings.Builder
+
+	builder.WriteString(prefs.historyEntryTitle(entry))
+
+	for _, change := range entry.Changes {
+		fmt.Fprintf(&builder, "\n• %s", change)
+	}
+
+	return builder.String()
+}
+
+func (prefs *Window) historySourceName(source string) string {
+	if key, ok := historySourceKeys[source]; ok {
+		return prefs.uiLocalizer.T(key)
+	}
+
+	return source
+}
+
+func (prefs *Window) restorePreviewText(changes []string) string {
+	if len(changes) == 0 {
+		return prefs.uiLocalizer.T("prefs.historyNoChanges")
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(prefs.uiLocalizer.T("prefs.historyChanges"))
+
+	for _, change := range changes {
+		fmt.Fprintf(&builder, "\n• %s", change)
+	}
+
+	return builder.String()
+}
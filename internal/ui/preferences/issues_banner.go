@@ -0,0 +1,82 @@
+package preferences
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// issuesBanner lists settings.yaml entries that were ignored on load
+type issuesBanner struct {
+	title   *widget.Label
+	details *widget.Label
+	dismiss *widget.Button
+	content *fyne.Container
+}
+
+func newIssuesBanner() issuesBanner {
+	title := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	details := widget.NewLabel("")
+	details.Wrapping = fyne.TextWrapWord
+	dismiss := widget.NewButtonWithIcon("", theme.CancelIcon(), nil)
+	dismiss.Importance = widget.LowImportance
+
+	header := container.NewHBox(widget.NewIcon(theme.WarningIcon()), title, layout.NewSpacer(), dismiss)
+	content := container.NewVBox(header, details, widget.NewSeparator())
+	content.Hide()
+
+	return issuesBanner{
+		title:   title,
+		details: details,
+		dismiss: dismiss,
+		content: content,
+	}
+}
+
+// SetLoadIssues shows a dismissable banner listing settings that were ignored
+// when settings.yaml was loaded. An empty list hides the banner.
+func (prefs *Window) SetLoadIssues(issues []string) {
+	issues = append([]string(nil), issues...)
+
+	fyne.Do(func() {
+		prefs.loadIssues = issues
+		prefs.refreshIssuesBanner()
+	})
+}
+
+func (prefs *Window) dismissIssuesBanner() {
+	prefs.loadIssues = nil
+	prefs.refreshIssuesBanner()
+}
+
+// refreshIssuesBanner syncs banner texts and visibility
+func (prefs *Window) refreshIssuesBanner() {
+	prefs.issuesBanner.title.SetText(prefs.uiLocalizer.T("prefs.issuesTitle"))
+	prefs.issuesBanner.details.SetText(strings.Join(prefs.loadIssues, "\n"))
+
+	if len(prefs.loadIssues) == 0 {
+		prefs.issuesBanner.content.Hide()
+	} else {
+		prefs.issuesBanner.content.Show()
+	}
+
+	prefs.resizeForNotices()
+}
+
+// resizeForNotices grows the fixed-size window by the height of the notices
+// shown above the form
+func (prefs *Window) resizeForNotices() {
+	height := prefsWindowHeight
+
+	for _, notice := range []fyne.CanvasObject{prefs.issuesBanner.content, prefs.fieldNoteBox, prefs.diagnostics.content} {
+		if notice.Visible() {
+			height += notice.MinSize().Height
+		}
+	}
+
+	prefs.window.Resize(fyne.NewSize(prefsWindowWidth, height))
+}
@@ -0,0 +1,54 @@
+package preferences
+
+import (
+	"eagleeye/internal/core/model"
+	"strings"
+)
+
+// NormalizeReminders trims reminder fields, drops entries without a name or
+// a positive interval, keeps the first entry for duplicate names, and falls
+// back to the tray presentation for unknown values
+func NormalizeReminders(reminders []model.ReminderConfig) []model.ReminderConfig {
+	normalized := make([]model.ReminderConfig, 0, len(reminders))
+	seen := make(map[string]struct{}, len(reminders))
+
+	for _, reminder := range reminders {
+		reminder.Name = strings.TrimSpace(reminder.Name)
+		reminder.Message = strings.TrimSpace(reminder.Message)
+		reminder.Presentation = NormalizePresentation(reminder.Presentation)
+
+		if reminder.Name == "" || reminder.Interval <= 0 {
+			continue
+		}
+
+		key := strings.ToLower(reminder.Name)
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		normalized = append(normalized, reminder)
+	}
+
+	return normalized
+}
+
+// NormalizePresentation maps unknown reminder presentations to the tray flash
+func NormalizePresentation(presentation model.ReminderPresentation) model.ReminderPresentation {
+	switch model.ReminderPresentation(strings.ToLower(strings.TrimSpace(string(presentation)))) {
+	case model.PresentationNotification:
+		return model.PresentationNotification
+	case model.PresentationOverlay:
+		return model.PresentationOverlay
+	default:
+		return model.PresentationTray
+	}
+}
+
+// reminderPresentations lists presentations in the order the editor offers them
+var reminderPresentations = []model.ReminderPresentation{
+	model.PresentationTray,
+	model.PresentationNotification,
+	model.PresentationOverlay,
+}
@@ -0,0 +1,226 @@
+package preferences
+
+import (
+	"eagleeye/internal/core/model"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	reminderNameWidth     = float32(110)
+	reminderMessageWidth  = float32(150)
+	reminderSelectWidth   = float32(120)
+	reminderEditorWidth   = float32(600)
+	reminderEditorHeight  = float32(320)
+	reminderDefaultPeriod = 30 * time.Minute
+)
+
+// reminderControls is the summary row shown in the main preferences form
+type reminderControls struct {
+	label   *widget.Label
+	summary *widget.Label
+	manage  *widget.Button
+	row     fyne.CanvasObject
+}
+
+// reminderRow holds the editor widgets for one reminder
+type reminderRow struct {
+	name         *widget.Entry
+	interval     *DurationEntry
+	message      *widget.Entry
+	presentation *widget.Select
+	enabled      *widget.Check
+	remove       *widget.Button
+}
+
+func newReminderControls() reminderControls {
+	label := widget.NewLabel("")
+	summary := widget.NewLabel("")
+	manage := widget.NewButton("", nil)
+
+	return reminderControls{
+		label:   label,
+		summary: summary,
+		manage:  manage,
+		row:     container.NewHBox(label, summary, layout.NewSpacer(), manage),
+	}
+}
+
+// refreshReminderSummary shows how many pending reminders are enabled
+func (prefs *Window) refreshReminderSummary() {
+	active := 0
+
+	for _, reminder := range prefs.reminderDraft {
+		if reminder.Enabled {
+			active++
+		}
+	}
+
+	prefs.reminderSummary.SetText(prefs.uiLocalizer.T("prefs.remindersSummary", active, len(prefs.reminderDraft)))
+}
+
+// showReminderEditor opens the reminder management dialog. Changes apply to
+// the pending draft and are persisted by Save like every other field
+func (prefs *Window) showReminderEditor() {
+	rows := make([]*reminderRow, 0, len(prefs.reminderDraft))
+	list := container.NewVBox()
+
+	var rebuild func()
+
+	addRow := func(reminder model.ReminderConfig) {
+		row := prefs.newReminderRow(reminder)
+		row.remove.OnTapped = func() {
+			for index, candidate := range rows {
+				if candidate == row {
+					rows = append(rows[:index], rows[index+1:]...)
+
+					break
+				}
+			}
+
+			rebuild()
+		}
+		rows = append(rows, row)
+	}
+
+	rebuild = func() {
+		objects := make([]fyne.CanvasObject, 0, len(rows))
+
+		for _, row := range rows {
+			objects = append(objects, row.content())
+		}
+
+		list.Objects = objects
+		list.Refresh()
+	}
+
+	for _, reminder := range prefs.reminderDraft {
+		addRow(reminder)
+	}
+
+	rebuild()
+
+	addButton := widget.NewButtonWithIcon(prefs.uiLocalizer.T("prefs.reminderAdd"), theme.ContentAddIcon(), func() {
+		addRow(model.ReminderConfig{
+			Interval:     reminderDefaultPeriod,
+			Presentation: model.PresentationTray,
+			Enabled:      true,
+		})
+		rebuild()
+	})
+
+	content := container.NewBorder(nil, addButton, nil, nil, container.NewVScroll(list))
+	editor := dialog.NewCustomConfirm(
+		prefs.uiLocalizer.T("prefs.remindersTitle"),
+		prefs.uiLocalizer.T("prefs.reminderApply"),
+		prefs.uiLocalizer.T("prefs.cancel"),
+		content,
+		func(apply bool) {
+			if !apply {
+				return
+			}
+
+			reminders := make([]model.ReminderConfig, 0, len(rows))
+
+			for _, row := range rows {
+				reminders = append(reminders, prefs.reminderFromRow(row))
+			}
+
+			prefs.reminderDraft = NormalizeReminders(reminders)
+			prefs.refreshReminderSummary()
+		},
+		prefs.window,
+	)
+
+	editor.Resize(fyne.NewSize(reminderEditorWidth, reminderEditorHeight))
+	editor.Show()
+}
+
+func (prefs *Window) newReminderRow(reminder model.ReminderConfig) *reminderRow {
+	name := widget.NewEntry()
+	name.SetPlaceHolder(prefs.uiLocalizer.T("prefs.reminderName"))
+	name.SetText(reminder.Name)
+
+	interval := NewDurationEntry(reminder.Interval)
+	prefs.localizeDurationEntry(interval)
+
+	message := widget.NewEntry()
+	message.SetPlaceHolder(prefs.uiLocalizer.T("prefs.reminderMessage"))
+	message.SetText(reminder.Message)
+
+	presentation := widget.NewSelect(prefs.presentationOptions(), nil)
+	presentation.SetSelected(prefs.presentationLabel(NormalizePresentation(reminder.Presentation)))
+
+	enabled := widget.NewCheck("", nil)
+	enabled.SetChecked(reminder.Enabled)
+
+	return &reminderRow{
+		name:         name,
+		interval:     interval,
+		message:      message,
+		presentation: presentation,
+		enabled:      enabled,
+		remove:       widget.NewButtonWithIcon("", theme.DeleteIcon(), nil),
+	}
+}
+
+func (row *reminderRow) content() fyne.CanvasObject {
+	height := row.name.MinSize().Height
+
+	return container.NewHBox(
+		row.enabled,
+		container.NewGridWrap(fyne.NewSize(reminderNameWidth, height), row.name),
+		container.NewGridWrap(fyne.NewSize(durationEntryWidth, height), row.interval),
+		container.NewGridWrap(fyne.NewSize(reminderMessageWidth, height), row.message),
+		container.NewGridWrap(fyne.NewSize(reminderSelectWidth, height), row.presentation),
+		row.remove,
+	)
+}
+
+// reminderFromRow reads one editor row; an invalid interval drops the row
+// during normalization rather than silently inventing a value
+func (prefs *Window) reminderFromRow(row *reminderRow) model.ReminderConfig {
+	reminder := model.ReminderConfig{
+		Name:         row.name.Text,
+		Message:      row.message.Text,
+		Presentation: prefs.presentationFromLabel(row.presentation.Selected),
+		Enabled:      row.enabled.Checked,
+	}
+
+	if interval, err := row.interval.Duration(); err == nil {
+		reminder.Interval = interval
+	}
+
+	return reminder
+}
+
+func (prefs *Window) presentationOptions() []string {
+	options := make([]string, 0, len(reminderPresentations))
+
+	for _, presentation := range reminderPresentations {
+		options = append(options, prefs.presentationLabel(presentation))
+	}
+
+	return options
+}
+
+func (prefs *Window) presentationLabel(presentation model.ReminderPresentation) string {
+	return prefs.uiLocalizer.T(fmt.Sprintf("prefs.reminder.%s", presentation))
+}
+
+func (prefs *Window) presentationFromLabel(label string) model.ReminderPresentation {
+	for _, presentation := range reminderPresentations {
+		if prefs.presentationLabel(presentation) == label {
+			return presentation
+		}
+	}
+
+	return model.PresentationTray
+}
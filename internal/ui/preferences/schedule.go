@@ -11,16 +11,20 @@ import (
 const (
 	scheduleLabelWidthEN = float32(190)
 	scheduleLabelExtraRU = prefsWindowWidth * 0.2 // ~20% of fixed preferences width
-	valueEntryWidth      = float32(60)
 )
 
-// makeScheduleRow builds one fixed-width schedule row: label, numeric entry,
-// and unit label.
-func makeScheduleRow(label *widget.Label, labelWidth float32, entry *widget.Entry, entryWidth float32, unit *widget.Label) fyne.CanvasObject {
+// makeScheduleRow builds one fixed-width schedule row: label and duration
+// entry with its unit selector.
+func makeScheduleRow(label *widget.Label, labelWidth float32, entry *DurationEntry) fyne.CanvasObject {
 	labelObject := container.NewGridWrap(fyne.NewSize(labelWidth, entry.MinSize().Height), label)
-	entryObject := container.NewGridWrap(fyne.NewSize(entryWidth, entry.MinSize().Height), entry)
+	entryObject := container.NewGridWrap(fyne.NewSize(durationEntryWidth, entry.MinSize().Height), entry)
 
-	return container.NewHBox(labelObject, entryObject, unit)
+	return container.NewHBox(labelObject, entryObject)
+}
+
+// localizeDurationEntry names the unit options in the active language.
+func (prefs *Window) localizeDurationEntry(entry *DurationEntry) {
+	entry.SetUnitNames(prefs.uiLocalizer.T("unit.sec"), prefs.uiLocalizer.T("unit.min"), prefs.uiLocalizer.T("unit.hour"))
 }
 
 // scheduleLabelWidthForLang widens schedule labels for Russian text so the
@@ -37,16 +41,15 @@ func scheduleLabelWidthForLang(lang string) float32 {
 // the order they should appear in the preferences form.
 func buildScheduleRowGroup(
 	scheduleLabels map[string]*widget.Label,
-	labels map[string]*widget.Label,
 
 	labelWidth float32,
-	shortInt, shortDur, longInt, longDur *widget.Entry,
+	shortInt, shortDur, longInt, longDur *DurationEntry,
 ) []fyne.CanvasObject {
 	return []fyne.CanvasObject{
-		makeScheduleRow(scheduleLabels["shortInterval"], labelWidth, shortInt, valueEntryWidth, labels["shortInterval"]),
-		makeScheduleRow(scheduleLabels["shortDuration"], labelWidth, shortDur, valueEntryWidth, labels["shortDuration"]),
-		makeScheduleRow(scheduleLabels["longInterval"], labelWidth, longInt, valueEntryWidth, labels["longInterval"]),
-		makeScheduleRow(scheduleLabels["longDuration"], labelWidth, longDur, valueEntryWidth, labels["longDuration"]),
+		makeScheduleRow(scheduleLabels["shortInterval"], labelWidth, shortInt),
+		makeScheduleRow(scheduleLabels["shortDuration"], labelWidth, shortDur),
+		makeScheduleRow(scheduleLabels["longInterval"], labelWidth, longInt),
+		makeScheduleRow(scheduleLabels["longDuration"], labelWidth, longDur),
 	}
 }
 
@@ -63,7 +66,6 @@ func (prefs *Window) refreshScheduleLayoutIfNeeded() {
 
 	prefs.scheduleSection.Objects = buildScheduleRowGroup(
 		prefs.scheduleLabels,
-		prefs.labels,
 		labelWidth,
 		prefs.shortInt,
 		prefs.shortDur,
@@ -2,6 +2,9 @@ package preferences
 
 import (
 	"eagleeye/internal/core/model"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -23,6 +26,45 @@ type Settings struct {
 	Language       string
 
 	BreakTimerStarted bool
+
+	Reminders []model.ReminderConfig
+
+	// HistoryRetentionDays is how long break history is kept; 0 keeps it all
+	HistoryRetentionDays int
+	// WeeklyReport opens last week's report on Monday morning
+	WeeklyReport bool
+	// CheckInEvery asks for a comfort rating after every nth completed long
+	// break; 0 never asks
+	CheckInEvery int
+
+	// LogLevel is the default log level: debug, info, warn or error
+	LogLevel string
+	// TimekeeperLogLevel, OverlayLogLevel and PlatformLogLevel override
+	// LogLevel for one subsystem; empty uses LogLevel
+	TimekeeperLogLevel string
+	OverlayLogLevel    string
+	PlatformLogLevel   string
+}
+
+// LogLevelNames are the log levels settings accept, most verbose first
+var LogLevelNames = []string{"debug", "info", "warn", "error"}
+
+// LogSubsystems are the parts of the app with their own log level setting
+var LogSubsystems = []string{"timekeeper", "overlay", "platform"}
+
+// NormalizeLogLevel returns the LogLevelNames entry for name, accepting any
+// case and "warning", or "" when name is not a level
+func NormalizeLogLevel(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		name = "warn"
+	}
+
+	if !slices.Contains(LogLevelNames, name) {
+		return ""
+	}
+
+	return name
 }
 
 // DefaultSettings returns default settings for EagleEye
@@ -40,6 +82,10 @@ func DefaultSettings() Settings {
 		RunOnStartup:   true,
 
 		Language: "en",
+
+		HistoryRetentionDays: 365,
+
+		LogLevel: "info",
 	}
 }
 
@@ -62,5 +108,64 @@ func (settings Settings) TimeKeeperConfig() model.TimeKeeperConfig {
 		IdleResetEnabled:  settings.IdleEnabled,
 		IdleResetAfter:    5 * time.Minute,
 		IdleCheckInterval: idleCheckInterval,
+		Reminders:         append([]model.ReminderConfig(nil), settings.Reminders...),
 	}
 }
+
+// SubsystemLogLevel is the level set for subsystem, or "" when it uses LogLevel
+func (settings Settings) SubsystemLogLevel(subsystem string) string {
+	switch subsystem {
+	case "timekeeper":
+		return settings.TimekeeperLogLevel
+	case "overlay":
+		return settings.OverlayLogLevel
+	case "platform":
+		return settings.PlatformLogLevel
+	default:
+		return ""
+	}
+}
+
+// SetLogLevel sets LogLevel, or with a subsystem that subsystem's level. An
+// empty level or "default" makes the subsystem use LogLevel again
+func (settings *Settings) SetLogLevel(subsystem, level string) error {
+	if subsystem != "" && strings.EqualFold(strings.TrimSpace(level), "default") {
+		level = ""
+	}
+
+	normalized := NormalizeLogLevel(level)
+	if normalized == "" && (subsystem == "" || strings.TrimSpace(level) != "") {
+		return fmt.Errorf("unknown log level %q, want %s", level, strings.Join(LogLevelNames, ", "))
+	}
+
+	switch subsystem {
+	case "":
+		settings.LogLevel = normalized
+	case "timekeeper":
+		settings.TimekeeperLogLevel = normalized
+	case "overlay":
+		settings.OverlayLogLevel = normalized
+	case "platform":
+		settings.PlatformLogLevel = normalized
+	default:
+		return fmt.Errorf("unknown subsystem %q, want %s", subsystem, strings.Join(LogSubsystems, ", "))
+	}
+
+	return nil
+}
+
+// LogLevelSummary lists the default level and each subsystem's level, one
+// per line
+func (settings Settings) LogLevelSummary() string {
+	lines := []string{"default: " + settings.LogLevel}
+
+	for _, subsystem := range LogSubsystems {
+		if level := settings.SubsystemLogLevel(subsystem); level != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", subsystem, level))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: %s (default)", subsystem, settings.LogLevel))
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
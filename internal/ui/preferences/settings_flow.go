@@ -1,20 +1,19 @@
 package preferences
 
 import (
+	"eagleeye/internal/core/model"
 	"eagleeye/internal/ui/i18n"
-	"fmt"
-	"strconv"
-	"time"
 )
 
 // UpdateSettings replaces window values.
 func (prefs *Window) UpdateSettings(settings Settings) {
 	prefs.settings = settings
+	prefs.reminderDraft = append([]model.ReminderConfig(nil), settings.Reminders...)
 	prefs.uiLocalizer.SetLanguage(settings.Language)
-	prefs.shortInt.SetText(fmt.Sprintf("%d", int(settings.ShortInterval.Minutes())))
-	prefs.shortDur.SetText(fmt.Sprintf("%d", int(settings.ShortDuration.Seconds())))
-	prefs.longInt.SetText(fmt.Sprintf("%d", int(settings.LongInterval.Minutes())))
-	prefs.longDur.SetText(fmt.Sprintf("%d", int(settings.LongDuration.Minutes())))
+	prefs.shortInt.SetDuration(settings.ShortInterval)
+	prefs.shortDur.SetDuration(settings.ShortDuration)
+	prefs.longInt.SetDuration(settings.LongInterval)
+	prefs.longDur.SetDuration(settings.LongDuration)
 
 	prefs.strict.SetChecked(settings.StrictMode)
 	prefs.idleCheck.SetChecked(settings.IdleEnabled)
@@ -25,7 +24,10 @@ func (prefs *Window) UpdateSettings(settings Settings) {
 
 	prefs.fullscreen.SetChecked(settings.Fullscreen)
 	prefs.runOnStartup.SetChecked(settings.RunOnStartup)
+	prefs.weeklyReport.SetChecked(settings.WeeklyReport)
 	prefs.languageSelect.SetSelected(i18n.LanguageDisplayName(settings.Language))
+	prefs.setCheckInEvery(settings.CheckInEvery)
+	prefs.setLogLevels(settings)
 
 	prefs.RefreshLocalization()
 }
@@ -35,20 +37,20 @@ func (prefs *Window) UpdateSettings(settings Settings) {
 func (prefs *Window) handleSave() {
 	settings := prefs.settings
 
-	if minutes, ok := parsePositiveInt(prefs.shortInt.Text); ok {
-		settings.ShortInterval = time.Duration(minutes) * time.Minute
+	if value, err := prefs.shortInt.Duration(); err == nil {
+		settings.ShortInterval = value
 	}
 
-	if seconds, ok := parsePositiveInt(prefs.shortDur.Text); ok {
-		settings.ShortDuration = time.Duration(seconds) * time.Second
+	if value, err := prefs.shortDur.Duration(); err == nil {
+		settings.ShortDuration = value
 	}
 
-	if minutes, ok := parsePositiveInt(prefs.longInt.Text); ok {
-		settings.LongInterval = time.Duration(minutes) * time.Minute
+	if value, err := prefs.longInt.Duration(); err == nil {
+		settings.LongInterval = value
 	}
 
-	if minutes, ok := parsePositiveInt(prefs.longDur.Text); ok {
-		settings.LongDuration = time.Duration(minutes) * time.Minute
+	if value, err := prefs.longDur.Duration(); err == nil {
+		settings.LongDuration = value
 	}
 
 	settings.StrictMode = prefs.strict.Checked
@@ -56,7 +58,16 @@ func (prefs *Window) handleSave() {
 	settings.OverlayOpacity = prefs.opacity.Value
 	settings.Fullscreen = prefs.fullscreen.Checked
 	settings.RunOnStartup = prefs.runOnStartup.Checked
+	settings.WeeklyReport = prefs.weeklyReport.Checked
+	settings.CheckInEvery = prefs.checkInEvery()
 	settings.Language = i18n.LanguageFromDisplayName(prefs.languageSelect.Selected)
+	settings.Reminders = append([]model.ReminderConfig(nil), prefs.reminderDraft...)
+
+	levels := prefs.formLogLevels()
+	settings.LogLevel = levels.LogLevel
+	settings.TimekeeperLogLevel = levels.TimekeeperLogLevel
+	settings.OverlayLogLevel = levels.OverlayLogLevel
+	settings.PlatformLogLevel = levels.PlatformLogLevel
 
 	prefs.settings = settings
 
@@ -65,26 +76,17 @@ func (prefs *Window) handleSave() {
 	}
 }
 
-// parsePositiveInt accepts only positive integer form input; invalid values are
-// ignored by callers so the previous setting remains unchanged.
-func parsePositiveInt(value string) (int, bool) {
-	parsed, err := strconv.Atoi(value)
-
-	if err != nil || parsed <= 0 {
-		return 0, false
-	}
-
-	return parsed, true
-}
-
 // dismiss hides the window and restores transient UI state when changes were
 // cancelled rather than saved.
 func (prefs *Window) dismiss(saved bool) {
 	prefs.window.Hide()
 
 	if !saved {
+		prefs.reminderDraft = append([]model.ReminderConfig(nil), prefs.settings.Reminders...)
 		prefs.uiLocalizer.SetLanguage(prefs.settings.Language)
 		prefs.languageSelect.SetSelected(i18n.LanguageDisplayName(prefs.settings.Language))
+		prefs.setCheckInEvery(prefs.settings.CheckInEvery)
+		prefs.setLogLevels(prefs.settings)
 
 		prefs.RefreshLocalization()
 	}
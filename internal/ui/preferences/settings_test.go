@@ -1,6 +1,8 @@
 package preferences
 
 import (
+	"eagleeye/internal/core/model"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -14,3 +16,63 @@ func TestSettingsTimeKeeperConfigUsesTwentySecondIdleCheck(t *testing.T) {
 		t.Fatalf("IdleCheckInterval = %s, want 20s", config.IdleCheckInterval)
 	}
 }
+
+// TestNormalizeReminders drops unusable entries and defaults the presentation
+func TestNormalizeReminders(t *testing.T) {
+	got := NormalizeReminders([]model.ReminderConfig{
+		{Name: "  Hydrate ", Interval: time.Hour, Presentation: "NOTIFICATION", Enabled: true},
+		{Name: "hydrate", Interval: time.Minute},
+		{Name: "", Interval: time.Minute},
+		{Name: "stretch", Interval: 0},
+		{Name: "posture", Interval: 20 * time.Minute, Presentation: "blink"},
+	})
+	want := []model.ReminderConfig{
+		{Name: "Hydrate", Interval: time.Hour, Presentation: model.PresentationNotification, Enabled: true},
+		{Name: "posture", Interval: 20 * time.Minute, Presentation: model.PresentationTray},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeReminders() = %+v, want %+v", got, want)
+	}
+}
+
+// TestCheckInChoicesKeepCustomFrequency verifies a frequency set in settings.yaml stays selectable
+func TestCheckInChoicesKeepCustomFrequency(t *testing.T) {
+	if got := checkInChoices(2); !reflect.DeepEqual(got, checkInPresets) {
+		t.Fatalf("checkInChoices(2) = %v, want %v", got, checkInPresets)
+	}
+
+	if got, want := checkInChoices(5), []int{0, 1, 2, 3, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("checkInChoices(5) = %v, want %v", got, want)
+	}
+}
+
+// TestSetLogLevel verifies level names are normalized and subsystems can
+// go back to the default level
+func TestSetLogLevel(t *testing.T) {
+	settings := DefaultSettings()
+
+	if err := settings.SetLogLevel("", "WARNING"); err != nil || settings.LogLevel != "warn" {
+		t.Fatalf("SetLogLevel(warning) = %v, LogLevel %q, want nil, warn", err, settings.LogLevel)
+	}
+
+	if err := settings.SetLogLevel("overlay", "debug"); err != nil || settings.OverlayLogLevel != "debug" {
+		t.Fatalf("SetLogLevel(overlay, debug) = %v, OverlayLogLevel %q, want nil, debug", err, settings.OverlayLogLevel)
+	}
+
+	if got, want := settings.LogLevelSummary(), "default: warn\ntimekeeper: warn (default)\noverlay: debug\nplatform: warn (default)\n"; got != want {
+		t.Fatalf("LogLevelSummary() = %q, want %q", got, want)
+	}
+
+	if err := settings.SetLogLevel("overlay", "default"); err != nil || settings.OverlayLogLevel != "" {
+		t.Fatalf("SetLogLevel(overlay, default) = %v, OverlayLogLevel %q, want nil, empty", err, settings.OverlayLogLevel)
+	}
+
+	if err := settings.SetLogLevel("", ""); err == nil {
+		t.Fatalf("SetLogLevel(\"\", \"\") error = nil, want an error")
+	}
+
+	if err := settings.SetLogLevel("network", "debug"); err == nil {
+		t.Fatalf("SetLogLevel(network) error = nil, want an error")
+	}
+}
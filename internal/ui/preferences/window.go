@@ -1,9 +1,10 @@
 package preferences
 
 import (
+	"eagleeye/internal/core/model"
 	"eagleeye/internal/ui/i18n"
-	"fmt"
 	"image/color"
+	"io"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -20,6 +21,7 @@ const (
 	saveCancelButtonWidth     = float32(130 * 1.4)
 	saveCancelButtonHeight    = float32(40)
 	preferencesMidFormGap     = float32(12)
+	prefsWindowHeight         = float32(680)
 )
 
 // Callbacks defines preferences window actions.
@@ -28,6 +30,15 @@ type Callbacks struct {
 	OnCancel      func()
 	OnDismiss     func()
 	OnToggleTimer func()
+	OnStatistics  func()
+
+	OnExportBundle  func(writer io.Writer, includeMachine bool) error
+	OnPreviewImport func(data []byte, includeMachine bool) (ImportPreview, error)
+	OnImport        func(Settings)
+
+	OnLoadHistory    func() ([]HistoryEntry, error)
+	OnPreviewRestore func(target Settings) []string
+	OnRestore        func(Settings)
 }
 
 // Window handles the preferences UI.
@@ -37,24 +48,42 @@ type Window struct {
 	callbacks   Callbacks
 	uiLocalizer *i18n.Localizer
 
-	labels         map[string]*widget.Label
 	scheduleLabels map[string]*widget.Label
 
 	heading            *canvas.Text
-	shortInt           *widget.Entry
-	shortDur           *widget.Entry
-	longInt            *widget.Entry
-	longDur            *widget.Entry
+	shortInt           *DurationEntry
+	shortDur           *DurationEntry
+	longInt            *DurationEntry
+	longDur            *DurationEntry
 	strict             *widget.Check
 	idleCheck          *widget.Check
 	opacity            *widget.Slider
 	fullscreen         *widget.Check
 	runOnStartup       *widget.Check
+	weeklyReport       *widget.Check
 	languageLabel      *widget.Label
 	languageSelect     *widget.Select
+	checkInLabel       *widget.Label
+	checkInSelect      *widget.Select
+	checkInChoices     []int
 	overlayOpacityText *widget.Label
 	saveButton         *widget.Button
 	cancelButton       *widget.Button
+	statisticsButton   *widget.Button
+	remindersLabel     *widget.Label
+	reminderSummary    *widget.Label
+	manageReminders    *widget.Button
+	reminderDraft      []model.ReminderConfig
+	bundleLabel        *widget.Label
+	exportBundle       *widget.Button
+	importBundle       *widget.Button
+	showHistory        *widget.Button
+	diagnostics        diagnosticsControls
+	issuesBanner       issuesBanner
+	loadIssues         []string
+	fieldNote          *widget.Label
+	fieldNoteBox       *fyne.Container
+	fieldStates        map[string]FieldState
 
 	statusIndicatorDot *canvas.Circle
 	statusBarMain      *canvas.Text
@@ -69,10 +98,10 @@ type Window struct {
 }
 
 type scheduleEntries struct {
-	shortInt *widget.Entry
-	shortDur *widget.Entry
-	longInt  *widget.Entry
-	longDur  *widget.Entry
+	shortInt *DurationEntry
+	shortDur *DurationEntry
+	longInt  *DurationEntry
+	longDur  *DurationEntry
 }
 
 type preferenceChecks struct {
@@ -81,6 +110,7 @@ type preferenceChecks struct {
 	idleTrackingRow fyne.CanvasObject
 	fullscreen      *widget.Check
 	runOnStartup    *widget.Check
+	weeklyReport    *widget.Check
 }
 
 type languageControls struct {
@@ -92,6 +122,7 @@ type languageControls struct {
 type footerControls struct {
 	saveButton        *widget.Button
 	cancelButton      *widget.Button
+	statisticsButton  *widget.Button
 	timerToggleButton *widget.Button
 	content           fyne.CanvasObject
 }
@@ -99,7 +130,6 @@ type footerControls struct {
 type preferencesView struct {
 	content fyne.CanvasObject
 
-	labels         map[string]*widget.Label
 	scheduleLabels map[string]*widget.Label
 
 	heading            *canvas.Text
@@ -107,7 +137,14 @@ type preferencesView struct {
 	checks             preferenceChecks
 	opacity            *widget.Slider
 	language           languageControls
+	checkIn            checkInControls
 	overlayOpacityText *widget.Label
+	reminders          reminderControls
+	bundle             bundleControls
+	diagnostics        diagnosticsControls
+	issues             issuesBanner
+	fieldNote          *widget.Label
+	fieldNoteBox       *fyne.Container
 	footer             footerControls
 
 	statusIndicatorDot *canvas.Circle
@@ -130,6 +167,7 @@ func New(app fyne.App, settings Settings, callbacks Callbacks) *Window {
 	prefs := newWindowState(window, settings, callbacks, uiLocalizer, view)
 	prefs.bindActions()
 
+	prefs.setLogLevels(settings)
 	prefs.RefreshLocalization()
 	prefs.SetServiceNotStarted()
 
@@ -147,35 +185,47 @@ func newPreferencesWindow(app fyne.App, localizer *i18n.Localizer) fyne.Window {
 }
 
 func configurePreferencesWindow(window fyne.Window) {
-	window.Resize(fyne.NewSize(prefsWindowWidth, 520))
+	window.Resize(fyne.NewSize(prefsWindowWidth, prefsWindowHeight))
 	window.SetFixedSize(true)
 }
 
 func newPreferencesView(window fyne.Window, settings Settings, localizer *i18n.Localizer) *preferencesView {
 	entries := newScheduleEntries(settings)
-	labels, scheduleLabels := newScheduleLabels()
-	scheduleSection, scheduleLayoutLang := newScheduleSection(labels, scheduleLabels, entries, settings.Language)
+	scheduleLabels := newScheduleLabels()
+	scheduleSection, scheduleLayoutLang := newScheduleSection(scheduleLabels, entries, settings.Language)
 
 	checks := newPreferenceChecks(window, settings, localizer)
 	language := newLanguageControls(settings)
+	checkIn := newCheckInControls()
 	opacity, overlayOpacityLabel := newOpacityControls(settings)
+	reminders := newReminderControls()
+	bundle := newBundleControls()
+	diagnostics := newDiagnosticsControls()
+	issues := newIssuesBanner()
+	fieldNote, fieldNoteBox := newFieldNote()
 	footer := newFooterControls()
 	statusBar, statusDot, statusBarMain, statusBarTimer := newStatusBar()
 
 	heading := newPreferencesHeading()
-	form := newPreferencesForm(heading, scheduleSection, checks, language.row, overlayOpacityLabel, opacity)
-	content := newPreferencesContent(form, footer.content, statusBar)
+	form := newPreferencesForm(heading, scheduleSection, checks, language.row, checkIn.row, reminders.row, bundle.row, overlayOpacityLabel, opacity, diagnostics.content)
+	content := newPreferencesContent(container.NewVBox(issues.content, fieldNoteBox), form, footer.content, statusBar)
 
 	return &preferencesView{
 		content:            content,
-		labels:             labels,
 		scheduleLabels:     scheduleLabels,
 		heading:            heading,
 		entries:            entries,
 		checks:             checks,
 		opacity:            opacity,
 		language:           language,
+		checkIn:            checkIn,
 		overlayOpacityText: overlayOpacityLabel,
+		reminders:          reminders,
+		bundle:             bundle,
+		diagnostics:        diagnostics,
+		issues:             issues,
+		fieldNote:          fieldNote,
+		fieldNoteBox:       fieldNoteBox,
 		footer:             footer,
 		statusIndicatorDot: statusDot,
 		statusBarMain:      statusBarMain,
@@ -187,44 +237,27 @@ func newPreferencesView(window fyne.Window, settings Settings, localizer *i18n.L
 
 func newScheduleEntries(settings Settings) scheduleEntries {
 	return scheduleEntries{
-		shortInt: newNumberEntry(int(settings.ShortInterval.Minutes())),
-		shortDur: newNumberEntry(int(settings.ShortDuration.Seconds())),
-		longInt:  newNumberEntry(int(settings.LongInterval.Minutes())),
-		longDur:  newNumberEntry(int(settings.LongDuration.Minutes())),
+		shortInt: NewDurationEntry(settings.ShortInterval),
+		shortDur: NewDurationEntry(settings.ShortDuration),
+		longInt:  NewDurationEntry(settings.LongInterval),
+		longDur:  NewDurationEntry(settings.LongDuration),
 	}
 }
 
-func newNumberEntry(value int) *widget.Entry {
-	entry := widget.NewEntry()
-	entry.SetText(fmt.Sprintf("%d", value))
-
-	return entry
-}
-
-func newScheduleLabels() (map[string]*widget.Label, map[string]*widget.Label) {
-	labels := map[string]*widget.Label{
-		"shortInterval": widget.NewLabel(""),
-		"shortDuration": widget.NewLabel(""),
-		"longInterval":  widget.NewLabel(""),
-		"longDuration":  widget.NewLabel(""),
-	}
-
-	scheduleLabels := map[string]*widget.Label{
+func newScheduleLabels() map[string]*widget.Label {
+	return map[string]*widget.Label{
 		"shortInterval": widget.NewLabel(""),
 		"shortDuration": widget.NewLabel(""),
 		"longInterval":  widget.NewLabel(""),
 		"longDuration":  widget.NewLabel(""),
 	}
-
-	return labels, scheduleLabels
 }
 
-func newScheduleSection(labels map[string]*widget.Label, scheduleLabels map[string]*widget.Label, entries scheduleEntries, language string) (*fyne.Container, string) {
+func newScheduleSection(scheduleLabels map[string]*widget.Label, entries scheduleEntries, language string) (*fyne.Container, string) {
 	layoutLang := i18n.NormalizeLanguage(language)
 	labelWidth := scheduleLabelWidthForLang(layoutLang)
 	scheduleRows := buildScheduleRowGroup(
 		scheduleLabels,
-		labels,
 		labelWidth,
 		entries.shortInt,
 		entries.shortDur,
@@ -253,12 +286,16 @@ func newPreferenceChecks(window fyne.Window, settings Settings, localizer *i18n.
 	runOnStartup := widget.NewCheck("", nil)
 	runOnStartup.SetChecked(settings.RunOnStartup)
 
+	weeklyReport := widget.NewCheck("", nil)
+	weeklyReport.SetChecked(settings.WeeklyReport)
+
 	return preferenceChecks{
 		strict:          strict,
 		idleCheck:       idleCheck,
 		idleTrackingRow: idleTrackingRow,
 		fullscreen:      fullscreen,
 		runOnStartup:    runOnStartup,
+		weeklyReport:    weeklyReport,
 	}
 }
 
@@ -291,17 +328,19 @@ func newOpacityControls(settings Settings) (*widget.Slider, *widget.Label) {
 func newFooterControls() footerControls {
 	saveButton := widget.NewButton("", nil)
 	cancelButton := widget.NewButton("", nil)
+	statisticsButton := widget.NewButton("", nil)
 	timerToggleButton := widget.NewButton("", nil)
 	timerToggleButton.Disable()
 
 	saveWrap := container.NewGridWrap(fyne.NewSize(saveCancelButtonWidth, saveCancelButtonHeight), saveButton)
 	cancelWrap := container.NewGridWrap(fyne.NewSize(saveCancelButtonWidth, saveCancelButtonHeight), cancelButton)
-	buttons := container.NewHBox(saveWrap, layout.NewSpacer(), cancelWrap)
+	buttons := container.NewHBox(saveWrap, layout.NewSpacer(), statisticsButton, layout.NewSpacer(), cancelWrap)
 	content := container.NewVBox(newVerticalSpacer(15), buttons, timerToggleButton)
 
 	return footerControls{
 		saveButton:        saveButton,
 		cancelButton:      cancelButton,
+		statisticsButton:  statisticsButton,
 		timerToggleButton: timerToggleButton,
 		content:           content,
 	}
@@ -321,8 +360,12 @@ func newPreferencesForm(
 	scheduleSection fyne.CanvasObject,
 	checks preferenceChecks,
 	languageRow fyne.CanvasObject,
+	checkInRow fyne.CanvasObject,
+	remindersRow fyne.CanvasObject,
+	bundleRow fyne.CanvasObject,
 	overlayOpacityLabel *widget.Label,
 	opacity *widget.Slider,
+	diagnostics fyne.CanvasObject,
 ) fyne.CanvasObject {
 	return container.NewVBox(
 		newVerticalSpacer(5),
@@ -334,18 +377,23 @@ func newPreferencesForm(
 		checks.idleTrackingRow,
 		checks.fullscreen,
 		checks.runOnStartup,
+		checks.weeklyReport,
 		newVerticalSpacer(preferencesMidFormGap),
 		languageRow,
+		checkInRow,
+		remindersRow,
+		bundleRow,
 		newVerticalSpacer(preferencesMidFormGap),
 		overlayOpacityLabel,
 		opacity,
+		diagnostics,
 	)
 }
 
-func newPreferencesContent(form fyne.CanvasObject, footer fyne.CanvasObject, statusBar fyne.CanvasObject) fyne.CanvasObject {
+func newPreferencesContent(banner fyne.CanvasObject, form fyne.CanvasObject, footer fyne.CanvasObject, statusBar fyne.CanvasObject) fyne.CanvasObject {
 	center := container.NewVBox(form, footer, newVerticalSpacer(statusBarFromFooterGap))
 
-	return container.NewBorder(nil, statusBar, nil, nil, center)
+	return container.NewBorder(banner, statusBar, nil, nil, center)
 }
 
 func newWindowState(window fyne.Window, settings Settings, callbacks Callbacks, localizer *i18n.Localizer, view *preferencesView) *Window {
@@ -354,7 +402,6 @@ func newWindowState(window fyne.Window, settings Settings, callbacks Callbacks,
 		settings:            settings,
 		callbacks:           callbacks,
 		uiLocalizer:         localizer,
-		labels:              view.labels,
 		scheduleLabels:      view.scheduleLabels,
 		heading:             view.heading,
 		shortInt:            view.entries.shortInt,
@@ -366,11 +413,28 @@ func newWindowState(window fyne.Window, settings Settings, callbacks Callbacks,
 		opacity:             view.opacity,
 		fullscreen:          view.checks.fullscreen,
 		runOnStartup:        view.checks.runOnStartup,
+		weeklyReport:        view.checks.weeklyReport,
 		languageLabel:       view.language.label,
 		languageSelect:      view.language.selectBox,
+		checkInLabel:        view.checkIn.label,
+		checkInSelect:       view.checkIn.selectBox,
+		checkInChoices:      checkInChoices(settings.CheckInEvery),
 		overlayOpacityText:  view.overlayOpacityText,
 		saveButton:          view.footer.saveButton,
 		cancelButton:        view.footer.cancelButton,
+		statisticsButton:    view.footer.statisticsButton,
+		remindersLabel:      view.reminders.label,
+		reminderSummary:     view.reminders.summary,
+		manageReminders:     view.reminders.manage,
+		reminderDraft:       append([]model.ReminderConfig(nil), settings.Reminders...),
+		bundleLabel:         view.bundle.label,
+		exportBundle:        view.bundle.exportButton,
+		importBundle:        view.bundle.importButton,
+		showHistory:         view.bundle.historyButton,
+		diagnostics:         view.diagnostics,
+		issuesBanner:        view.issues,
+		fieldNote:           view.fieldNote,
+		fieldNoteBox:        view.fieldNoteBox,
 		statusIndicatorDot:  view.statusIndicatorDot,
 		statusBarMain:       view.statusBarMain,
 		statusBarTimer:      view.statusBarTimer,
@@ -387,14 +451,27 @@ func (prefs *Window) bindActions() {
 		prefs.uiLocalizer.SetLanguage(i18n.LanguageFromDisplayName(prefs.languageSelect.Selected))
 		prefs.RefreshLocalization()
 	}
+	prefs.manageReminders.OnTapped = prefs.showReminderEditor
+	prefs.exportBundle.OnTapped = prefs.showExportDialog
+	prefs.importBundle.OnTapped = prefs.showImportDialog
+	prefs.showHistory.OnTapped = prefs.showHistoryDialog
+	prefs.issuesBanner.dismiss.OnTapped = prefs.dismissIssuesBanner
 	prefs.cancelButton.OnTapped = func() {
 		prefs.dismiss(false)
 	}
+	prefs.statisticsButton.OnTapped = func() {
+		if prefs.callbacks.OnStatistics != nil {
+			prefs.callbacks.OnStatistics()
+		}
+	}
 	prefs.timerToggleButton.OnTapped = func() {
 		if prefs.callbacks.OnToggleTimer != nil {
 			prefs.callbacks.OnToggleTimer()
 		}
 	}
+	prefs.window.Canvas().AddShortcut(diagnosticsShortcut, func(fyne.Shortcut) {
+		prefs.toggleDiagnostics()
+	})
 	prefs.window.SetCloseIntercept(func() {
 		prefs.dismiss(false)
 	})
@@ -424,10 +501,10 @@ func (prefs *Window) RefreshLocalization() {
 		prefs.scheduleLabels["shortDuration"].SetText(prefs.uiLocalizer.T("prefs.shortBreakDuration"))
 		prefs.scheduleLabels["longInterval"].SetText(prefs.uiLocalizer.T("prefs.longBreakEvery"))
 		prefs.scheduleLabels["longDuration"].SetText(prefs.uiLocalizer.T("prefs.longBreakDuration"))
-		prefs.labels["shortInterval"].SetText(prefs.uiLocalizer.T("unit.min"))
-		prefs.labels["shortDuration"].SetText(prefs.uiLocalizer.T("unit.sec"))
-		prefs.labels["longInterval"].SetText(prefs.uiLocalizer.T("unit.min"))
-		prefs.labels["longDuration"].SetText(prefs.uiLocalizer.T("unit.min"))
+
+		for _, entry := range []*DurationEntry{prefs.shortInt, prefs.shortDur, prefs.longInt, prefs.longDur} {
+			prefs.localizeDurationEntry(entry)
+		}
 
 		prefs.strict.Text = prefs.uiLocalizer.T("prefs.strictMode")
 		prefs.strict.Refresh()
@@ -437,10 +514,25 @@ func (prefs *Window) RefreshLocalization() {
 		prefs.fullscreen.Refresh()
 		prefs.runOnStartup.Text = prefs.uiLocalizer.T("prefs.runOnStartup")
 		prefs.runOnStartup.Refresh()
+		prefs.weeklyReport.Text = prefs.uiLocalizer.T("prefs.weeklyReport")
+		prefs.weeklyReport.Refresh()
 		prefs.languageLabel.SetText(prefs.uiLocalizer.T("prefs.language"))
+		prefs.checkInLabel.SetText(prefs.uiLocalizer.T("prefs.checkIn"))
+		prefs.refreshCheckInOptions(prefs.checkInEvery())
 		prefs.overlayOpacityText.SetText(prefs.uiLocalizer.T("prefs.overlayOpacity"))
 		prefs.saveButton.SetText(prefs.uiLocalizer.T("prefs.save"))
 		prefs.cancelButton.SetText(prefs.uiLocalizer.T("prefs.cancel"))
+		prefs.statisticsButton.SetText(prefs.uiLocalizer.T("prefs.statistics"))
+		prefs.remindersLabel.SetText(prefs.uiLocalizer.T("prefs.reminders"))
+		prefs.manageReminders.SetText(prefs.uiLocalizer.T("prefs.remindersManage"))
+		prefs.refreshReminderSummary()
+		prefs.bundleLabel.SetText(prefs.uiLocalizer.T("prefs.bundle"))
+		prefs.exportBundle.SetText(prefs.uiLocalizer.T("prefs.bundleExportButton"))
+		prefs.importBundle.SetText(prefs.uiLocalizer.T("prefs.bundleImportButton"))
+		prefs.showHistory.SetText(prefs.uiLocalizer.T("prefs.historyButton"))
+		prefs.refreshDiagnostics(prefs.formLogLevels())
+		prefs.issuesBanner.title.SetText(prefs.uiLocalizer.T("prefs.issuesTitle"))
+		prefs.refreshFieldStates()
 
 		prefs.renderServiceStatus()
 		prefs.refreshScheduleLayoutIfNeeded()
@@ -0,0 +1,346 @@
+package statistics
+
+import (
+	"eagleeye/internal/history"
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	barHeight        = float32(14)
+	heatmapLabelSize = float32(11)
+	heatmapLabelGap  = float32(4)
+	heatmapCellGap   = float32(2)
+	heatmapHourStep  = 3
+	comfortChartDays = 28
+	comfortDotSize   = float32(8)
+)
+
+var (
+	takenColor   = color.NRGBA{R: 92, G: 184, B: 92, A: 255}
+	skippedColor = color.NRGBA{R: 232, G: 190, B: 66, A: 255}
+	emptyColor   = color.NRGBA{R: 128, G: 128, B: 128, A: 60}
+)
+
+// outcomeBar draws taken and skipped breaks as one proportional bar
+type outcomeBar struct {
+	widget.BaseWidget
+
+	taken   *canvas.Rectangle
+	skipped *canvas.Rectangle
+	empty   *canvas.Rectangle
+
+	takenCount   int
+	skippedCount int
+}
+
+func newOutcomeBar() *outcomeBar {
+	bar := &outcomeBar{
+		taken:   canvas.NewRectangle(takenColor),
+		skipped: canvas.NewRectangle(skippedColor),
+		empty:   canvas.NewRectangle(emptyColor),
+	}
+
+	bar.ExtendBaseWidget(bar)
+
+	return bar
+}
+
+// setCounts redraws the bar for taken and skipped breaks
+func (bar *outcomeBar) setCounts(taken, skipped int) {
+	bar.takenCount = taken
+	bar.skippedCount = skipped
+	bar.Refresh()
+}
+
+func (bar *outcomeBar) CreateRenderer() fyne.WidgetRenderer {
+	return &outcomeBarRenderer{bar: bar}
+}
+
+type outcomeBarRenderer struct {
+	bar *outcomeBar
+}
+
+func (renderer *outcomeBarRenderer) Layout(size fyne.Size) {
+	bar := renderer.bar
+	total := bar.takenCount + bar.skippedCount
+
+	bar.empty.Move(fyne.NewPos(0, 0))
+	bar.empty.Resize(size)
+
+	if total == 0 {
+		bar.taken.Resize(fyne.NewSize(0, 0))
+		bar.skipped.Resize(fyne.NewSize(0, 0))
+
+		return
+	}
+
+	takenWidth := size.Width * float32(bar.takenCount) / float32(total)
+
+	bar.taken.Move(fyne.NewPos(0, 0))
+	bar.taken.Resize(fyne.NewSize(takenWidth, size.Height))
+	bar.skipped.Move(fyne.NewPos(takenWidth, 0))
+	bar.skipped.Resize(fyne.NewSize(size.Width-takenWidth, size.Height))
+}
+
+func (renderer *outcomeBarRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(barHeight*4, barHeight)
+}
+
+func (renderer *outcomeBarRenderer) Refresh() {
+	renderer.Layout(renderer.bar.Size())
+	canvas.Refresh(renderer.bar)
+}
+
+func (renderer *outcomeBarRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{renderer.bar.empty, renderer.bar.taken, renderer.bar.skipped}
+}
+
+func (renderer *outcomeBarRenderer) Destroy() {}
+
+// heatmapChart draws a weekday by hour grid shaded by completed breaks
+type heatmapChart struct {
+	widget.BaseWidget
+
+	cells      [7][24]*canvas.Rectangle
+	dayLabels  [7]*canvas.Text
+	hourLabels []*canvas.Text
+}
+
+func newHeatmapChart() *heatmapChart {
+	chart := &heatmapChart{}
+
+	for day := range chart.cells {
+		for hour := range chart.cells[day] {
+			chart.cells[day][hour] = canvas.NewRectangle(emptyColor)
+		}
+
+		chart.dayLabels[day] = newChartLabel("")
+	}
+
+	for hour := 0; hour < 24; hour += heatmapHourStep {
+		chart.hourLabels = append(chart.hourLabels, newChartLabel(fmt.Sprintf("%02d", hour)))
+	}
+
+	chart.ExtendBaseWidget(chart)
+
+	return chart
+}
+
+// setHeatmap shades each cell relative to the busiest one
+func (chart *heatmapChart) setHeatmap(heatmap history.Heatmap) {
+	largest := heatmap.Max()
+
+	for day, hours := range heatmap {
+		for hour, count := range hours {
+			cell := chart.cells[day][hour]
+			cell.FillColor = emptyColor
+
+			if count > 0 && largest > 0 {
+				shade := takenColor
+				shade.A = uint8(60 + 195*count/largest)
+				cell.FillColor = shade
+			}
+
+			cell.Refresh()
+		}
+	}
+}
+
+// setDayNames labels the rows, Monday first
+func (chart *heatmapChart) setDayNames(names [7]string) {
+	for day, name := range names {
+		chart.dayLabels[day].Text = name
+		chart.dayLabels[day].Refresh()
+	}
+
+	chart.Refresh()
+}
+
+func (chart *heatmapChart) CreateRenderer() fyne.WidgetRenderer {
+	objects := make([]fyne.CanvasObject, 0, 7*24+7+len(chart.hourLabels))
+
+	for day := range chart.cells {
+		for hour := range chart.cells[day] {
+			objects = append(objects, chart.cells[day][hour])
+		}
+
+		objects = append(objects, chart.dayLabels[day])
+	}
+
+	for _, label := range chart.hourLabels {
+		objects = append(objects, label)
+	}
+
+	return &heatmapRenderer{chart: chart, objects: objects}
+}
+
+type heatmapRenderer struct {
+	chart   *heatmapChart
+	objects []fyne.CanvasObject
+}
+
+func (renderer *heatmapRenderer) Layout(size fyne.Size) {
+	chart := renderer.chart
+	labelWidth := float32(0)
+
+	for _, label := range chart.dayLabels {
+		labelWidth = max(labelWidth, label.MinSize().Width)
+	}
+
+	labelWidth += heatmapLabelGap
+	hourRow := chart.hourLabels[0].MinSize().Height + heatmapLabelGap
+	cellWidth := (size.Width - labelWidth) / 24
+	cellHeight := (size.Height - hourRow) / 7
+
+	for day := range chart.cells {
+		top := hourRow + float32(day)*cellHeight
+
+		label := chart.dayLabels[day]
+		label.Move(fyne.NewPos(0, top+(cellHeight-label.MinSize().Height)/2))
+		label.Resize(label.MinSize())
+
+		for hour, cell := range chart.cells[day] {
+			cell.Move(fyne.NewPos(labelWidth+float32(hour)*cellWidth, top))
+			cell.Resize(fyne.NewSize(cellWidth-heatmapCellGap, cellHeight-heatmapCellGap))
+		}
+	}
+
+	for index, label := range chart.hourLabels {
+		label.Move(fyne.NewPos(labelWidth+float32(index*heatmapHourStep)*cellWidth, 0))
+		label.Resize(label.MinSize())
+	}
+}
+
+func (renderer *heatmapRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(24*10+40, 7*12+heatmapLabelSize+heatmapLabelGap)
+}
+
+func (renderer *heatmapRenderer) Refresh() {
+	renderer.Layout(renderer.chart.Size())
+	canvas.Refresh(renderer.chart)
+}
+
+func (renderer *heatmapRenderer) Objects() []fyne.CanvasObject {
+	return renderer.objects
+}
+
+func (renderer *heatmapRenderer) Destroy() {}
+
+func newChartLabel(text string) *canvas.Text {
+	label := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	label.TextSize = heatmapLabelSize
+
+	return label
+}
+
+// comfortChart draws each day's share of breaks taken as a bar and its mean
+// comfort rating as a dot on the same scale, oldest day left
+type comfortChart struct {
+	widget.BaseWidget
+
+	bars []*canvas.Rectangle
+	dots []*canvas.Circle
+	days []history.ComfortDay
+}
+
+func newComfortChart() *comfortChart {
+	chart := &comfortChart{}
+
+	for index := 0; index < comfortChartDays; index++ {
+		chart.bars = append(chart.bars, canvas.NewRectangle(takenColor))
+		chart.dots = append(chart.dots, canvas.NewCircle(skippedColor))
+	}
+
+	chart.ExtendBaseWidget(chart)
+
+	return chart
+}
+
+// setDays shows the newest comfortChartDays days of a trend
+func (chart *comfortChart) setDays(days []history.ComfortDay) {
+	if len(days) > comfortChartDays {
+		days = days[len(days)-comfortChartDays:]
+	}
+
+	chart.days = days
+	chart.Refresh()
+}
+
+func (chart *comfortChart) CreateRenderer() fyne.WidgetRenderer {
+	background := canvas.NewRectangle(emptyColor)
+	objects := []fyne.CanvasObject{background}
+
+	for _, bar := range chart.bars {
+		objects = append(objects, bar)
+	}
+
+	for _, dot := range chart.dots {
+		objects = append(objects, dot)
+	}
+
+	return &comfortRenderer{chart: chart, background: background, objects: objects}
+}
+
+type comfortRenderer struct {
+	chart      *comfortChart
+	background *canvas.Rectangle
+	objects    []fyne.CanvasObject
+}
+
+func (renderer *comfortRenderer) Layout(size fyne.Size) {
+	chart := renderer.chart
+
+	renderer.background.Move(fyne.NewPos(0, 0))
+	renderer.background.Resize(size)
+
+	slot := size.Width / comfortChartDays
+
+	for index := range chart.bars {
+		bar, dot := chart.bars[index], chart.dots[index]
+		bar.Hide()
+		dot.Hide()
+
+		if index >= len(chart.days) {
+			continue
+		}
+
+		day := chart.days[index]
+		left := float32(index) * slot
+
+		if day.Decided > 0 {
+			height := size.Height * float32(day.Compliance)
+			bar.Move(fyne.NewPos(left+heatmapCellGap, size.Height-height))
+			bar.Resize(fyne.NewSize(slot-2*heatmapCellGap, height))
+			bar.Show()
+		}
+
+		if day.Ratings > 0 {
+			share := float32(day.Rating-history.MinRating) / float32(history.MaxRating-history.MinRating)
+			center := fyne.NewPos(left+slot/2, size.Height-share*(size.Height-comfortDotSize)-comfortDotSize/2)
+			dot.Move(fyne.NewPos(center.X-comfortDotSize/2, center.Y-comfortDotSize/2))
+			dot.Resize(fyne.NewSize(comfortDotSize, comfortDotSize))
+			dot.Show()
+		}
+	}
+}
+
+func (renderer *comfortRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(comfortChartDays*6, comfortDotSize*4)
+}
+
+func (renderer *comfortRenderer) Refresh() {
+	renderer.Layout(renderer.chart.Size())
+	canvas.Refresh(renderer.chart)
+}
+
+func (renderer *comfortRenderer) Objects() []fyne.CanvasObject {
+	return renderer.objects
+}
+
+func (renderer *comfortRenderer) Destroy() {}
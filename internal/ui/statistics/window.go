@@ -0,0 +1,281 @@
+package statistics
+
+import (
+	"eagleeye/internal/history"
+	"eagleeye/internal/ui/i18n"
+	"fmt"
+	"image/color"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	windowWidth   = float32(560)
+	windowHeight  = float32(700)
+	heatmapHeight = float32(190)
+	comfortHeight = float32(100)
+	legendSize    = float32(12)
+
+	// comfortLinkThreshold is the correlation below which the link between
+	// breaks taken and comfort is reported as unclear
+	comfortLinkThreshold = 0.3
+)
+
+// summaryRow is the title, bar and figures for one period
+type summaryRow struct {
+	title   *widget.Label
+	bar     *outcomeBar
+	figures *widget.Label
+	content fyne.CanvasObject
+}
+
+func newSummaryRow() summaryRow {
+	title := widget.NewLabel("")
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	bar := newOutcomeBar()
+	figures := widget.NewLabel("")
+
+	return summaryRow{
+		title:   title,
+		bar:     bar,
+		figures: figures,
+		content: container.NewVBox(title, bar, figures),
+	}
+}
+
+// Window shows today's and this week's break statistics. Update may be
+// called from any goroutine while the window is open
+type Window struct {
+	window    fyne.Window
+	localizer *i18n.Localizer
+	overview  history.Overview
+	visible   atomic.Bool
+
+	heading      *canvas.Text
+	today        summaryRow
+	week         summaryRow
+	stretch      *widget.Label
+	activity     *widget.Label
+	heatmapTitle *widget.Label
+	heatmap      *heatmapChart
+	takenLegend  *widget.Label
+	skipLegend   *widget.Label
+	comfortTitle *widget.Label
+	comfort      *comfortChart
+	shareLegend  *widget.Label
+	ratingLegend *widget.Label
+	comfortNote  *widget.Label
+}
+
+// New creates the hidden statistics window.
+func New(app fyne.App, localizer *i18n.Localizer) *Window {
+	if localizer == nil {
+		localizer = i18n.New(i18n.LanguageEN)
+	}
+
+	stats := &Window{
+		window:       app.NewWindow(""),
+		localizer:    localizer,
+		heading:      canvas.NewText("", theme.Color(theme.ColorNameForeground)),
+		today:        newSummaryRow(),
+		week:         newSummaryRow(),
+		stretch:      widget.NewLabel(""),
+		activity:     widget.NewLabel(""),
+		heatmapTitle: widget.NewLabel(""),
+		heatmap:      newHeatmapChart(),
+		takenLegend:  widget.NewLabel(""),
+		skipLegend:   widget.NewLabel(""),
+		comfortTitle: widget.NewLabel(""),
+		comfort:      newComfortChart(),
+		shareLegend:  widget.NewLabel(""),
+		ratingLegend: widget.NewLabel(""),
+		comfortNote:  widget.NewLabel(""),
+	}
+
+	stats.heading.TextSize = 19
+	stats.heading.TextStyle = fyne.TextStyle{Bold: true}
+	stats.heading.Alignment = fyne.TextAlignCenter
+	stats.heatmapTitle.TextStyle = fyne.TextStyle{Bold: true}
+	stats.comfortTitle.TextStyle = fyne.TextStyle{Bold: true}
+	stats.comfortNote.Wrapping = fyne.TextWrapWord
+
+	legend := container.NewHBox(
+		legendSwatch(takenColor), stats.takenLegend,
+		legendSwatch(skippedColor), stats.skipLegend,
+	)
+	heatmap := container.NewGridWrap(fyne.NewSize(windowWidth-2*theme.Padding()-20, heatmapHeight), stats.heatmap)
+	comfortLegend := container.NewHBox(
+		legendSwatch(takenColor), stats.shareLegend,
+		legendSwatch(skippedColor), stats.ratingLegend,
+	)
+	comfort := container.NewGridWrap(fyne.NewSize(windowWidth-2*theme.Padding()-20, comfortHeight), stats.comfort)
+
+	stats.window.SetContent(container.NewPadded(container.NewVBox(
+		container.NewCenter(stats.heading),
+		container.NewGridWithColumns(2, stats.today.content, stats.week.content),
+		legend,
+		stats.stretch,
+		stats.activity,
+		stats.heatmapTitle,
+		heatmap,
+		stats.comfortTitle,
+		comfort,
+		comfortLegend,
+		stats.comfortNote,
+		layout.NewSpacer(),
+	)))
+	stats.window.Resize(fyne.NewSize(windowWidth, windowHeight))
+	stats.window.SetCloseIntercept(stats.Hide)
+
+	stats.refreshLocalization()
+
+	return stats
+}
+
+// Show opens the window. It must run on the Fyne UI thread.
+func (stats *Window) Show() {
+	stats.visible.Store(true)
+	stats.window.Show()
+	stats.window.RequestFocus()
+}
+
+// Hide closes the window without destroying it.
+func (stats *Window) Hide() {
+	stats.visible.Store(false)
+	stats.window.Hide()
+}
+
+// Visible reports whether the window is open, so callers can skip loading
+// data nobody sees.
+func (stats *Window) Visible() bool {
+	return stats.visible.Load()
+}
+
+// Update redraws the window with overview.
+func (stats *Window) Update(overview history.Overview) {
+	fyne.Do(func() {
+		stats.overview = overview
+		stats.refreshFigures()
+	})
+}
+
+// RefreshLocalization refreshes all texts after a language change.
+func (stats *Window) RefreshLocalization() {
+	fyne.Do(stats.refreshLocalization)
+}
+
+func (stats *Window) refreshLocalization() {
+	stats.window.SetTitle(stats.localizer.T("stats.windowTitle"))
+	stats.heading.Text = stats.localizer.T("stats.windowTitle")
+	stats.heading.Refresh()
+	stats.today.title.SetText(stats.localizer.T("stats.today"))
+	stats.week.title.SetText(stats.localizer.T("stats.week"))
+	stats.heatmapTitle.SetText(stats.localizer.T("stats.heatmap"))
+	stats.takenLegend.SetText(stats.localizer.T("stats.legendTaken"))
+	stats.skipLegend.SetText(stats.localizer.T("stats.legendSkipped"))
+	stats.comfortTitle.SetText(stats.localizer.T("stats.comfort"))
+	stats.shareLegend.SetText(stats.localizer.T("stats.legendShare"))
+	stats.ratingLegend.SetText(stats.localizer.T("stats.legendComfort"))
+
+	var days [7]string
+	for day := range days {
+		days[day] = stats.localizer.T(fmt.Sprintf("stats.weekday%d", day))
+	}
+
+	stats.heatmap.setDayNames(days)
+	stats.refreshFigures()
+}
+
+func (stats *Window) refreshFigures() {
+	stats.setSummary(stats.today, stats.overview.Today)
+	stats.setSummary(stats.week, stats.overview.Week)
+	stats.heatmap.setHeatmap(stats.overview.Heatmap)
+
+	if stats.overview.AverageStretch > 0 {
+		stats.stretch.SetText(stats.localizer.T("stats.averageStretch", stats.formatStretch(stats.overview.AverageStretch)))
+	} else {
+		stats.stretch.SetText(stats.localizer.T("stats.averageStretch", "—"))
+	}
+
+	stats.activity.SetText(stats.activitySummary(stats.overview.ActivityToday, stats.overview.ActivityWeek))
+	stats.comfort.setDays(stats.overview.Comfort.Days)
+	stats.comfortNote.SetText(stats.comfortSummary(stats.overview.Comfort))
+}
+
+// activitySummary shows today's and this week's screen time on two lines
+func (stats *Window) activitySummary(today, week history.ActivityDay) string {
+	if week.Active+week.Idle == 0 {
+		return stats.localizer.T("stats.activityNone")
+	}
+
+	return stats.localizer.T("stats.activityToday",
+		stats.formatStretch(today.Active),
+		stats.formatStretch(today.Idle),
+		stats.formatStretch(today.LongestActive),
+	) + "\n" + stats.localizer.T("stats.activityWeek",
+		stats.formatStretch(week.Active),
+		stats.formatStretch(week.Idle),
+	)
+}
+
+// comfortSummary puts the link between breaks taken and comfort in words
+func (stats *Window) comfortSummary(trend history.ComfortTrend) string {
+	rated := false
+	for _, day := range trend.Days {
+		rated = rated || day.Ratings > 0
+	}
+
+	switch {
+	case !rated:
+		return stats.localizer.T("stats.comfortNone")
+	case !trend.Correlated:
+		return stats.localizer.T("stats.comfortTooFew")
+	case trend.Correlation >= comfortLinkThreshold:
+		return stats.localizer.T("stats.comfortBetter", trend.Correlation)
+	case trend.Correlation <= -comfortLinkThreshold:
+		return stats.localizer.T("stats.comfortWorse", trend.Correlation)
+	default:
+		return stats.localizer.T("stats.comfortUnclear", trend.Correlation)
+	}
+}
+
+func (stats *Window) setSummary(row summaryRow, summary history.Summary) {
+	skipped := summary.Skipped + summary.Postponed
+	row.bar.setCounts(summary.Completed, skipped)
+
+	if summary.Completed+skipped == 0 {
+		row.figures.SetText(stats.localizer.T("stats.noBreaks"))
+
+		return
+	}
+
+	row.figures.SetText(stats.localizer.T("stats.figures",
+		summary.Completed,
+		skipped,
+		int(math.Round(summary.Compliance()*100)),
+	))
+}
+
+// formatStretch shows a duration rounded to minutes as "1 h 5 min" or "24 min"
+func (stats *Window) formatStretch(stretch time.Duration) string {
+	minutes := int(stretch.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%d %s", minutes, stats.localizer.T("unit.min"))
+	}
+
+	return fmt.Sprintf("%d %s %d %s", minutes/60, stats.localizer.T("unit.hour"), minutes%60, stats.localizer.T("unit.min"))
+}
+
+func legendSwatch(fill color.Color) fyne.CanvasObject {
+	swatch := canvas.NewRectangle(fill)
+
+	return container.NewCenter(container.NewGridWrap(fyne.NewSize(legendSize, legendSize), swatch))
+}
@@ -14,14 +14,24 @@ import (
 // Callbacks defines tray action handlers.
 type Callbacks struct {
 	OnPreferences func()
+	OnStatistics  func()
+	OnReport      func()
 	OnTogglePause func()
 	OnForceNext   func()
 	OnSkipBreak   func()
 	OnPauseFor    func(time.Duration)
 	OnForceLong   func()
 	OnQuit        func()
+
+	OnSnoozeReminder func(name string, duration time.Duration)
 }
 
+// reminderFlashDuration is how long a tray reminder replaces the status line
+const reminderFlashDuration = 15 * time.Second
+
+// reminderSnoozeDurations lists snooze choices offered for every reminder
+var reminderSnoozeDurations = []time.Duration{15 * time.Minute, 30 * time.Minute, 60 * time.Minute}
+
 // Manager handles system tray state.
 type Manager struct {
 	mu sync.Mutex
@@ -33,6 +43,8 @@ type Manager struct {
 	statusItem      *fyne.MenuItem
 	forceNextItem   *fyne.MenuItem
 	preferencesItem *fyne.MenuItem
+	statisticsItem  *fyne.MenuItem
+	reportItem      *fyne.MenuItem
 	pauseItem       *fyne.MenuItem
 	skipItem        *fyne.MenuItem
 	pauseForItem    *fyne.MenuItem
@@ -41,11 +53,19 @@ type Manager struct {
 	pause30Item     *fyne.MenuItem
 	pause60Item     *fyne.MenuItem
 	forceLongItem   *fyne.MenuItem
+	snoozeItem      *fyne.MenuItem
+	goalsItem       *fyne.MenuItem
 	quitItem        *fyne.MenuItem
 
 	paused      bool
 	inBreak     bool
 	statusLabel string
+	activity    string
+
+	reminderNames []string
+	goalLines     []string
+	flashLabel    string
+	flashUntil    time.Time
 
 	tooltipEnabled bool
 }
@@ -81,6 +101,8 @@ func (manager *Manager) initMenuItems() {
 
 	manager.forceNextItem = fyne.NewMenuItem("", manager.handleForceNext)
 	manager.preferencesItem = fyne.NewMenuItem("", manager.handlePreferences)
+	manager.statisticsItem = fyne.NewMenuItem("", manager.handleStatistics)
+	manager.reportItem = fyne.NewMenuItem("", manager.handleReport)
 
 	manager.initPauseForItems()
 
@@ -88,6 +110,8 @@ func (manager *Manager) initMenuItems() {
 	manager.pauseItem = fyne.NewMenuItem("", manager.handleTogglePause)
 	manager.skipItem = fyne.NewMenuItem("", manager.handleSkipBreak)
 	manager.skipItem.Disabled = true
+	manager.snoozeItem = fyne.NewMenuItem("", nil)
+	manager.goalsItem = fyne.NewMenuItem("", nil)
 	manager.quitItem = fyne.NewMenuItem("", manager.handleQuit)
 }
 
@@ -113,6 +137,18 @@ func (manager *Manager) handlePreferences() {
 	}
 }
 
+func (manager *Manager) handleStatistics() {
+	if manager.callbacks.OnStatistics != nil {
+		manager.callbacks.OnStatistics()
+	}
+}
+
+func (manager *Manager) handleReport() {
+	if manager.callbacks.OnReport != nil {
+		manager.callbacks.OnReport()
+	}
+}
+
 func (manager *Manager) handleTogglePause() {
 	if manager.callbacks.OnTogglePause != nil {
 		manager.callbacks.OnTogglePause()
@@ -149,6 +185,51 @@ func (manager *Manager) handleQuit() {
 	}
 }
 
+// SetReminders replaces the reminders offered in the snooze submenu.
+func (manager *Manager) SetReminders(names []string) {
+	names = append([]string(nil), names...)
+
+	fyne.Do(func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+
+		manager.reminderNames = names
+
+		manager.refreshSnoozeMenuLocked()
+		manager.refreshMenuLocked()
+	})
+}
+
+// FlashReminder temporarily replaces the tray status with a reminder message.
+func (manager *Manager) FlashReminder(name, message string) {
+	fyne.Do(func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+
+		manager.flashLabel = manager.localizer.T("tray.reminderFlash", name, message)
+		manager.flashUntil = time.Now().Add(reminderFlashDuration)
+
+		manager.refreshStatusLocked()
+		manager.refreshMenuLocked()
+	})
+
+	time.AfterFunc(reminderFlashDuration, func() {
+		fyne.Do(func() {
+			manager.mu.Lock()
+			defer manager.mu.Unlock()
+
+			manager.refreshStatusLocked()
+			manager.refreshMenuLocked()
+		})
+	})
+}
+
+func (manager *Manager) handleSnoozeReminder(name string, duration time.Duration) {
+	if manager.callbacks.OnSnoozeReminder != nil {
+		manager.callbacks.OnSnoozeReminder(name, duration)
+	}
+}
+
 // RefreshLocalization updates tray texts after language changes.
 func (manager *Manager) RefreshLocalization() {
 	fyne.Do(func() {
@@ -160,6 +241,22 @@ func (manager *Manager) RefreshLocalization() {
 	})
 }
 
+// SetGoals lists goal streaks and earned badges, one line each, in a Goals
+// submenu. The submenu is hidden while lines is empty.
+func (manager *Manager) SetGoals(lines []string) {
+	lines = append([]string(nil), lines...)
+
+	fyne.Do(func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+
+		manager.goalLines = lines
+
+		manager.refreshGoalsMenuLocked()
+		manager.refreshMenuLocked()
+	})
+}
+
 // SetStatus updates the status label.
 func (manager *Manager) SetStatus(status string) {
 	fyne.Do(func() {
@@ -175,6 +272,20 @@ func (manager *Manager) SetStatus(status string) {
 	})
 }
 
+// SetActivity shows today's screen time after the status, or nothing when
+// activity is empty.
+func (manager *Manager) SetActivity(activity string) {
+	fyne.Do(func() {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+
+		manager.activity = activity
+
+		manager.refreshStatusLocked()
+		manager.refreshMenuLocked()
+	})
+}
+
 // SetPaused updates pause state.
 func (manager *Manager) SetPaused(paused bool) {
 	fyne.Do(func() {
@@ -207,6 +318,8 @@ func (manager *Manager) SetInBreak(inBreak bool) {
 func (manager *Manager) refreshLocalizationLocked() {
 	manager.forceNextItem.Label = manager.localizer.T("tray.takeNextBreakNow")
 	manager.preferencesItem.Label = manager.localizer.T("tray.preferences")
+	manager.statisticsItem.Label = manager.localizer.T("tray.statistics")
+	manager.reportItem.Label = manager.localizer.T("tray.weeklyReport")
 	manager.pauseForItem.Label = manager.localizer.T("tray.disableBreaksFor")
 	manager.pause5Item.Label = manager.localizer.T("tray.pauseForMinutes", 5)
 	manager.pause15Item.Label = manager.localizer.T("tray.pauseForMinutes", 15)
@@ -221,18 +334,68 @@ func (manager *Manager) refreshLocalizationLocked() {
 	}
 
 	manager.skipItem.Label = manager.localizer.T("tray.skipBreak")
+	manager.snoozeItem.Label = manager.localizer.T("tray.snoozeReminders")
+	manager.goalsItem.Label = manager.localizer.T("tray.goals")
 	manager.quitItem.Label = manager.localizer.T("tray.quit")
 
+	manager.refreshSnoozeMenuLocked()
 	manager.refreshStatusLocked()
 }
 
+// refreshGoalsMenuLocked rebuilds the read-only goals submenu
+func (manager *Manager) refreshGoalsMenuLocked() {
+	items := make([]*fyne.MenuItem, 0, len(manager.goalLines))
+
+	for _, line := range manager.goalLines {
+		item := fyne.NewMenuItem(line, nil)
+		item.Disabled = true
+		items = append(items, item)
+	}
+
+	manager.goalsItem.ChildMenu = fyne.NewMenu("", items...)
+}
+
+// refreshSnoozeMenuLocked rebuilds the per-reminder snooze submenu
+func (manager *Manager) refreshSnoozeMenuLocked() {
+	items := make([]*fyne.MenuItem, 0, len(manager.reminderNames))
+
+	for _, name := range manager.reminderNames {
+		name := name
+		durations := make([]*fyne.MenuItem, 0, len(reminderSnoozeDurations))
+
+		for _, duration := range reminderSnoozeDurations {
+			duration := duration
+			durations = append(durations, fyne.NewMenuItem(
+				manager.localizer.T("tray.pauseForMinutes", int(duration.Minutes())),
+				func() {
+					manager.handleSnoozeReminder(name, duration)
+				},
+			))
+		}
+
+		item := fyne.NewMenuItem(name, nil)
+		item.ChildMenu = fyne.NewMenu("", durations...)
+		items = append(items, item)
+	}
+
+	manager.snoozeItem.ChildMenu = fyne.NewMenu("", items...)
+}
+
 func (manager *Manager) refreshStatusLocked() {
 	status := manager.statusLabel
 
+	if manager.flashLabel != "" && time.Now().Before(manager.flashUntil) {
+		status = manager.flashLabel
+	}
+
 	if manager.paused {
 		status = fmt.Sprintf("%s %s", status, manager.localizer.T("tray.pausedSuffix"))
 	}
 
+	if manager.activity != "" {
+		status = fmt.Sprintf("%s · %s", status, manager.activity)
+	}
+
 	manager.statusItem.Label = manager.localizer.T("tray.statusFormat", status)
 
 	if manager.tooltipEnabled {
@@ -245,15 +408,30 @@ func (manager *Manager) refreshMenuLocked() {
 		return
 	}
 
-	manager.app.SetSystemTrayMenu(fyne.NewMenu(
-		manager.localizer.T("tray.menuTitle"),
+	items := []*fyne.MenuItem{
 		manager.statusItem,
 		manager.forceNextItem,
 		manager.preferencesItem,
+		manager.statisticsItem,
+		manager.reportItem,
+	}
+
+	if len(manager.goalLines) > 0 {
+		items = append(items, manager.goalsItem)
+	}
+
+	items = append(items,
 		manager.pauseForItem,
 		manager.forceLongItem,
 		manager.pauseItem,
 		manager.skipItem,
-		manager.quitItem,
-	))
+	)
+
+	if len(manager.reminderNames) > 0 {
+		items = append(items, manager.snoozeItem)
+	}
+
+	items = append(items, manager.quitItem)
+
+	manager.app.SetSystemTrayMenu(fyne.NewMenu(manager.localizer.T("tray.menuTitle"), items...))
 }